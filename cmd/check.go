@@ -1,51 +1,74 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"net/http"
 	"os"
 
 	"github.com/ramonvermeulen/pre-commit-bump/config"
 	"github.com/ramonvermeulen/pre-commit-bump/core/bumper"
-	"github.com/ramonvermeulen/pre-commit-bump/core/io"
-	"github.com/ramonvermeulen/pre-commit-bump/core/parser"
 	"github.com/spf13/cobra"
+	"go.uber.org/zap"
 )
 
 var checkCmd = &cobra.Command{
 	Use:   "check",
 	Short: "Check for available updates without modifying the \".pre-commit-config.yaml\" file",
 	Long: `Check for available updates without modifying the ".pre-commit-config.yaml" file.
-This command will exit with a non-zero status code if there are updates available.`,
+This command exits with status code 0 when all hooks are up-to-date, 1 when updates are available
+and 2 when the check itself failed, e.g. due to an API error. With --fail-if-empty it exits with status code 4
+when there is nothing to check, e.g. a configuration with only local and meta hooks.`,
 	Run: runCheck,
 }
 
 func init() {
 	rootCmd.AddCommand(checkCmd)
+	checkCmd.Flags().Bool(config.FlagFailIfEmpty, false, "Exit with status code 4 when no repository could be checked, e.g. when all hooks are local, meta or not pinned to a version")
+
+	config.BindFlag(checkCmd.Flags(), config.FlagFailIfEmpty)
 }
 
 func runCheck(cmd *cobra.Command, args []string) {
 	cfg, err := config.FromViper()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error reading configuration: %v\n", err)
-		os.Exit(1)
+		os.Exit(config.ExitCodeError)
 	}
+	discoverConfig(cmd, cfg)
 
-	cfg.Logger.Sugar().Debugf("Starting check command - config_path: %s", cfg.PreCommitConfigPath)
+	cfg.Logger.Sugar().Debugf("Starting check command - config_paths: %v", cfg.PreCommitConfigPaths)
 
-	filesystem := io.NewOSFileSystem()
-	httpClient := &http.Client{
-		Timeout: config.DefaultHTTPTimeout,
-	}
-	resultWriter := io.NewResultWriter(filesystem, cfg.Logger)
-	p := parser.NewParser(cfg.Logger)
+	bmp := newBumper(cfg)
+
+	os.Exit(check(cmd.Context(), bmp, cfg.Logger, cfg.FailIfEmpty))
+}
 
-	bmp := bumper.NewBumper(p, cfg, resultWriter, httpClient)
+// checker checks the pre-commit configuration file for updates, it is implemented by bumper.Bumper.
+type checker interface {
+	Check(ctx context.Context) error
+}
 
-	if err := bmp.Check(); err != nil {
+// check runs the check and returns the exit code of the check command.
+// Available updates and a failing check result in different exit codes, so CI can tell them apart.
+// Nothing to check is reported as up-to-date with a warning, unless failIfEmpty is set.
+func check(ctx context.Context, c checker, logger *zap.Logger, failIfEmpty bool) int {
+	err := c.Check(ctx)
+	switch {
+	case err == nil:
+		logger.Sugar().Info("Check completed successfully, all hooks are up-to-date")
+		return config.ExitCodeUpToDate
+	case errors.Is(err, bumper.ErrNothingToCheck) && failIfEmpty:
+		fmt.Fprintf(os.Stderr, "Check failed: %v\n", err)
+		return config.ExitCodeNothingToCheck
+	case errors.Is(err, bumper.ErrNothingToCheck):
+		logger.Sugar().Warnf("Check completed, %v", err)
+		return config.ExitCodeUpToDate
+	case errors.Is(err, bumper.ErrUpdatesAvailable):
 		fmt.Fprintf(os.Stderr, "Check failed: %v\n", err)
-		os.Exit(1)
+		return config.ExitCodeUpdatesAvailable
+	default:
+		fmt.Fprintf(os.Stderr, "Check failed: %v\n", err)
+		return config.ExitCodeError
 	}
-
-	cfg.Logger.Sugar().Info("Check completed successfully, all hooks are up-to-date")
 }
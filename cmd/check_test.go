@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/ramonvermeulen/pre-commit-bump/config"
+	"github.com/ramonvermeulen/pre-commit-bump/core/bumper"
+)
+
+// checkerFunc allows a function to be used as a checker in tests
+type checkerFunc func() error
+
+func (f checkerFunc) Check(ctx context.Context) error {
+	return f()
+}
+
+func TestCheck_ExitCodes(t *testing.T) {
+	tests := []struct {
+		name             string
+		err              error
+		failIfEmpty      bool
+		expectedExitCode int
+	}{
+		{
+			name:             "up to date",
+			expectedExitCode: config.ExitCodeUpToDate,
+		},
+		{
+			name:             "updates available",
+			err:              bumper.ErrUpdatesAvailable,
+			expectedExitCode: config.ExitCodeUpdatesAvailable,
+		},
+		{
+			name:             "updates available error",
+			err:              &bumper.UpdatesAvailableError{},
+			expectedExitCode: config.ExitCodeUpdatesAvailable,
+		},
+		{
+			name:             "wrapped updates available",
+			err:              fmt.Errorf("check: %w", bumper.ErrUpdatesAvailable),
+			expectedExitCode: config.ExitCodeUpdatesAvailable,
+		},
+		{
+			name:             "operational error",
+			err:              fmt.Errorf("errors occurred while checking repositories: GitHub API returned status 500 (https://github.com/owner/repo)"),
+			expectedExitCode: config.ExitCodeError,
+		},
+		{
+			name:             "nothing to check",
+			err:              bumper.ErrNothingToCheck,
+			expectedExitCode: config.ExitCodeUpToDate,
+		},
+		{
+			name:             "nothing to check with fail if empty",
+			err:              bumper.ErrNothingToCheck,
+			failIfEmpty:      true,
+			expectedExitCode: config.ExitCodeNothingToCheck,
+		},
+		{
+			name:             "up to date with fail if empty",
+			failIfEmpty:      true,
+			expectedExitCode: config.ExitCodeUpToDate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exitCode := check(t.Context(), checkerFunc(func() error { return tt.err }), zap.NewNop(), tt.failIfEmpty)
+			assert.Equal(t, tt.expectedExitCode, exitCode)
+		})
+	}
+}
+
+func TestCheck_EndToEnd(t *testing.T) {
+	tests := []struct {
+		name             string
+		rev              string
+		status           int
+		expectedExitCode int
+	}{
+		{
+			name:             "up to date",
+			rev:              "v1.1.0",
+			status:           http.StatusOK,
+			expectedExitCode: config.ExitCodeUpToDate,
+		},
+		{
+			name:             "updates available",
+			rev:              "v1.0.0",
+			status:           http.StatusOK,
+			expectedExitCode: config.ExitCodeUpdatesAvailable,
+		},
+		{
+			name:             "API error",
+			rev:              "v1.0.0",
+			status:           http.StatusInternalServerError,
+			expectedExitCode: config.ExitCodeError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(`[{"ref": "refs/tags/v1.0.0"}, {"ref": "refs/tags/v1.1.0"}]`))
+			}))
+			defer server.Close()
+
+			configPath := filepath.Join(t.TempDir(), ".pre-commit-config.yaml")
+			content := fmt.Sprintf("repos:\n  - repo: %s/owner/repo\n    rev: %s\n", server.URL, tt.rev)
+			require.NoError(t, os.WriteFile(configPath, []byte(content), 0644))
+
+			cfg := &config.Config{
+				PreCommitConfigPaths: []string{configPath},
+				Allow:                config.BumpMajor,
+				GitHubAPIURL:         server.URL,
+				MaxAttempts:          1,
+				Logger:               zap.NewNop(),
+			}
+
+			assert.Equal(t, tt.expectedExitCode, check(t.Context(), newBumper(cfg), cfg.Logger, false))
+		})
+	}
+}
+
+func TestCheck_NothingToCheck(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".pre-commit-config.yaml")
+	content := "repos:\n  - repo: local\n    hooks:\n      - id: lint\n  - repo: meta\n    hooks:\n      - id: check-hooks-apply\n"
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0644))
+
+	cfg := &config.Config{
+		PreCommitConfigPaths: []string{configPath},
+		Allow:                config.BumpMajor,
+		MaxAttempts:          1,
+		Logger:               zap.NewNop(),
+	}
+
+	assert.ErrorIs(t, newBumper(cfg).Check(t.Context()), bumper.ErrNothingToCheck)
+	assert.Equal(t, config.ExitCodeUpToDate, check(t.Context(), newBumper(cfg), cfg.Logger, false))
+	assert.Equal(t, config.ExitCodeNothingToCheck, check(t.Context(), newBumper(cfg), cfg.Logger, true))
+}
+
+// captureOutput redirects stdout and stderr to temporary files while fn runs and returns what was written to them.
+func captureOutput(t *testing.T, fn func()) (string, string) {
+	t.Helper()
+
+	stdout, err := os.CreateTemp(t.TempDir(), "stdout")
+	require.NoError(t, err)
+	stderr, err := os.CreateTemp(t.TempDir(), "stderr")
+	require.NoError(t, err)
+
+	originalStdout, originalStderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = stdout, stderr
+	defer func() {
+		os.Stdout, os.Stderr = originalStdout, originalStderr
+	}()
+
+	fn()
+
+	stdoutData, err := os.ReadFile(stdout.Name())
+	require.NoError(t, err)
+	stderrData, err := os.ReadFile(stderr.Name())
+	require.NoError(t, err)
+	return string(stdoutData), string(stderrData)
+}
+
+func TestCheck_Quiet(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"ref": "refs/tags/v1.0.0"}, {"ref": "refs/tags/v1.1.0"}]`))
+	}))
+	defer server.Close()
+
+	configPath := filepath.Join(t.TempDir(), ".pre-commit-config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("repos:\n  - repo: "+server.URL+"/owner/repo\n    rev: v1.1.0\n"), 0644))
+
+	viper.Set(config.FlagConfig, []string{configPath})
+	viper.Set(config.FlagAllow, config.BumpMajor)
+	viper.Set(config.FlagGitHubAPIURL, server.URL)
+	viper.Set(config.FlagMaxAttempts, 1)
+	viper.Set(config.FlagMaxConcurrency, 1)
+	viper.Set(config.FlagQuiet, true)
+
+	var exitCode int
+	stdout, stderr := captureOutput(t, func() {
+		cfg, err := config.FromViper()
+		require.NoError(t, err)
+		exitCode = check(t.Context(), newBumper(cfg), cfg.Logger, false)
+	})
+
+	assert.Equal(t, config.ExitCodeUpToDate, exitCode)
+	assert.Empty(t, stdout)
+	assert.Empty(t, stderr)
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/ramonvermeulen/pre-commit-bump/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// discoverConfig searches the pre-commit configuration file upward from the working directory when --config is not set.
+// An explicit --config, or a config key in the ".pre-commit-bump.yaml" file, always wins. Relative paths from that file
+// are resolved against the directory of the file, so they keep working from a subdirectory. When no configuration file
+// is found the default path is kept, so the usual "path does not exist" error is reported.
+func discoverConfig(cmd *cobra.Command, cfg *config.Config) {
+	if cmd.Flags().Changed(config.FlagConfig) {
+		return
+	}
+	if viper.InConfig(config.FlagConfig) {
+		cfg.PreCommitConfigPaths = resolveToolConfigPaths(cfg.PreCommitConfigPaths, filepath.Dir(viper.ConfigFileUsed()))
+		return
+	}
+
+	workingDir, err := os.Getwd()
+	if err != nil {
+		cfg.Logger.Sugar().Debugf("Skipping config discovery, failed to get working directory: %v", err)
+		return
+	}
+
+	if configPath, ok := discoverConfigPath(workingDir); ok {
+		cfg.Logger.Sugar().Debugf("Discovered configuration file: %s", configPath)
+		cfg.PreCommitConfigPaths = []string{configPath}
+	}
+}
+
+// resolveToolConfigPaths joins the relative paths, and globs, of the ".pre-commit-bump.yaml" file in toolConfigDir with
+// that directory. The paths are returned relative to the working directory when possible, stdin and absolute paths are
+// kept as is.
+func resolveToolConfigPaths(paths []string, toolConfigDir string) []string {
+	workingDir, err := os.Getwd()
+	if err != nil {
+		workingDir = ""
+	}
+
+	resolved := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if path == config.StdinPath || filepath.IsAbs(path) {
+			resolved = append(resolved, path)
+			continue
+		}
+		path = filepath.Join(toolConfigDir, path)
+		if workingDir != "" {
+			if relative, err := filepath.Rel(workingDir, path); err == nil {
+				path = relative
+			}
+		}
+		resolved = append(resolved, path)
+	}
+	return resolved
+}
+
+// discoverConfigPath walks from dir up through its parent directories until it finds the default pre-commit
+// configuration file, either ".pre-commit-config.yaml" or ".pre-commit-config.yml" with ".yaml" taking precedence
+// within a directory. The search stops at the git root, the directory that contains ".git", or the file system root.
+// It returns the path relative to dir when possible, and false when no configuration file is found.
+func discoverConfigPath(dir string) (string, bool) {
+	var configPath string
+	walkUp(dir, func(current string) bool {
+		for _, name := range config.DefaultConfigFiles {
+			candidate := filepath.Join(current, name)
+			if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+				configPath = candidate
+				return true
+			}
+		}
+		return isGitRoot(current)
+	})
+	if configPath == "" {
+		return "", false
+	}
+
+	if relative, err := filepath.Rel(dir, configPath); err == nil {
+		return relative, true
+	}
+	return configPath, true
+}
+
+// findGitRoot walks from dir up through its parent directories until it finds the directory that contains ".git".
+// It returns false when dir is not inside a git repository.
+func findGitRoot(dir string) (string, bool) {
+	return walkUp(dir, isGitRoot)
+}
+
+// isGitRoot reports whether dir contains ".git".
+func isGitRoot(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, ".git"))
+	return err == nil
+}
+
+// walkUp calls stop with the absolute path of dir and then of each of its parent directories, until stop returns true
+// or the file system root is passed. It returns the directory stop returned true for, and false when it never did.
+func walkUp(dir string, stop func(current string) bool) (string, bool) {
+	current, err := filepath.Abs(dir)
+	if err != nil {
+		return "", false
+	}
+
+	for {
+		if stop(current) {
+			return current, true
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			return "", false
+		}
+		current = parent
+	}
+}
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/ramonvermeulen/pre-commit-bump/config"
+)
+
+func TestDiscoverConfigPath(t *testing.T) {
+	tests := []struct {
+		name       string
+		files      []string
+		dirs       []string
+		workingDir string
+		expected   string
+		expectedOk bool
+	}{
+		{
+			name:       "config in working directory",
+			files:      []string{"repo/.pre-commit-config.yaml"},
+			dirs:       []string{"repo/.git"},
+			workingDir: "repo",
+			expected:   ".pre-commit-config.yaml",
+			expectedOk: true,
+		},
+		{
+			name:       "config in parent directory",
+			files:      []string{"repo/.pre-commit-config.yaml"},
+			dirs:       []string{"repo/.git", "repo/services/api"},
+			workingDir: "repo/services/api",
+			expected:   filepath.Join("..", "..", ".pre-commit-config.yaml"),
+			expectedOk: true,
+		},
+		{
+			name:       "nearest config wins",
+			files:      []string{"repo/.pre-commit-config.yaml", "repo/services/.pre-commit-config.yaml"},
+			dirs:       []string{"repo/.git", "repo/services/api"},
+			workingDir: "repo/services/api",
+			expected:   filepath.Join("..", ".pre-commit-config.yaml"),
+			expectedOk: true,
+		},
+		{
+			name:       "yml extension",
+			files:      []string{"repo/.pre-commit-config.yml"},
+			dirs:       []string{"repo/.git"},
+			workingDir: "repo",
+			expected:   ".pre-commit-config.yml",
+			expectedOk: true,
+		},
+		{
+			name:       "yaml extension takes precedence over yml",
+			files:      []string{"repo/.pre-commit-config.yaml", "repo/.pre-commit-config.yml"},
+			dirs:       []string{"repo/.git"},
+			workingDir: "repo",
+			expected:   ".pre-commit-config.yaml",
+			expectedOk: true,
+		},
+		{
+			name:       "nearest yml config wins over yaml in a parent directory",
+			files:      []string{"repo/.pre-commit-config.yaml", "repo/services/.pre-commit-config.yml"},
+			dirs:       []string{"repo/.git", "repo/services/api"},
+			workingDir: "repo/services/api",
+			expected:   filepath.Join("..", ".pre-commit-config.yml"),
+			expectedOk: true,
+		},
+		{
+			name:       "search stops at the git root",
+			files:      []string{".pre-commit-config.yaml"},
+			dirs:       []string{"repo/.git", "repo/services"},
+			workingDir: "repo/services",
+			expectedOk: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			for _, dir := range tt.dirs {
+				require.NoError(t, os.MkdirAll(filepath.Join(root, dir), 0755))
+			}
+			for _, file := range tt.files {
+				require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(root, file)), 0755))
+				require.NoError(t, os.WriteFile(filepath.Join(root, file), []byte("repos: []\n"), 0644))
+			}
+
+			workingDir := filepath.Join(root, tt.workingDir)
+			t.Chdir(workingDir)
+
+			configPath, ok := discoverConfigPath(workingDir)
+
+			assert.Equal(t, tt.expectedOk, ok)
+			assert.Equal(t, tt.expected, configPath)
+			if ok {
+				_, err := os.Stat(configPath)
+				assert.NoError(t, err, "discovered path should resolve from the working directory")
+				assert.Contains(t, config.DefaultConfigFiles, filepath.Base(configPath))
+			}
+		})
+	}
+}
+
+func TestFindGitRoot(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "services", "api")
+	require.NoError(t, os.MkdirAll(nested, 0755))
+
+	_, ok := findGitRoot(nested)
+	assert.False(t, ok)
+
+	require.NoError(t, os.Mkdir(filepath.Join(root, ".git"), 0755))
+	gitRoot, ok := findGitRoot(nested)
+	assert.True(t, ok)
+	assert.Equal(t, root, gitRoot)
+}
+
+func TestDiscoverConfig_ToolConfigPaths(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, ".git"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "services", "api"), 0755))
+	toolConfig := "config:\n  - configs/.pre-commit-config.yaml\n  - services/*/.pre-commit-config.yaml\n  - \"-\"\n  - /abs/.pre-commit-config.yaml\n"
+	require.NoError(t, os.WriteFile(filepath.Join(root, config.ToolConfigName+".yaml"), []byte(toolConfig), 0644))
+
+	t.Chdir(filepath.Join(root, "services", "api"))
+	require.NoError(t, config.ReadToolConfig(".", root))
+
+	cmd := &cobra.Command{}
+	cmd.Flags().StringSlice(config.FlagConfig, nil, "")
+	cfg := &config.Config{PreCommitConfigPaths: viper.GetStringSlice(config.FlagConfig), Logger: zap.NewNop()}
+
+	discoverConfig(cmd, cfg)
+
+	expected := []string{
+		filepath.Join("..", "..", "configs", ".pre-commit-config.yaml"),
+		filepath.Join("..", "*", ".pre-commit-config.yaml"),
+		config.StdinPath,
+		"/abs/.pre-commit-config.yaml",
+	}
+	assert.Equal(t, expected, cfg.PreCommitConfigPaths, "paths from the tool config are relative to its directory")
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/ramonvermeulen/pre-commit-bump/config"
+	"github.com/ramonvermeulen/pre-commit-bump/core/bumper"
+	"github.com/spf13/cobra"
+)
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the repositories in the \".pre-commit-config.yaml\" file and whether they are checked",
+	Long: `List every repository in the ".pre-commit-config.yaml" file with its detected vendor and current revision.
+Repositories that are not checked for updates, like local hooks or revisions that are not a version, are listed
+with the reason they are skipped. This command does not query any API.`,
+	Args: cobra.NoArgs,
+	Run:  runList,
+}
+
+func init() {
+	rootCmd.AddCommand(listCmd)
+}
+
+func runList(cmd *cobra.Command, args []string) {
+	cfg, err := config.FromViper()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading configuration: %v\n", err)
+		os.Exit(1)
+	}
+	discoverConfig(cmd, cfg)
+
+	cfg.Logger.Sugar().Debugf("Starting list command - config_paths: %v", cfg.PreCommitConfigPaths)
+
+	parsedConfigs, err := newBumper(cfg).ParseConfigs()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "List failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := writeRepoList(cmd.OutOrStdout(), parsedConfigs); err != nil {
+		fmt.Fprintf(os.Stderr, "List failed: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// writeRepoList writes a table of the repositories of the parsed configuration files to w.
+// The configuration file is only included as a column when multiple files were parsed.
+func writeRepoList(w io.Writer, parsedConfigs []bumper.ParsedConfig) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	withPath := len(parsedConfigs) > 1
+
+	if withPath {
+		fmt.Fprint(tw, "CONFIG\t")
+	}
+	fmt.Fprintln(tw, "REPO\tVENDOR\tREV\tSTATUS")
+
+	for _, parsed := range parsedConfigs {
+		for _, repo := range parsed.Config.Repos {
+			vendor := repo.GetVendor()
+			if vendor == "" {
+				vendor = "-"
+			}
+			rev := repo.Rev
+			if rev == "" {
+				rev = "-"
+			}
+			status := "checked"
+			if reason := repo.SkipReason(); reason != "" {
+				status = "skipped: " + reason
+			}
+
+			if withPath {
+				fmt.Fprintf(tw, "%s\t", parsed.Path)
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", repo.Repo, vendor, rev, status)
+		}
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/ramonvermeulen/pre-commit-bump/config"
+)
+
+func TestWriteRepoList(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".pre-commit-config.yaml")
+	content := `repos:
+  - repo: https://github.com/psf/black
+    rev: 24.1.0
+  - repo: https://gitlab.com/owner/repo
+    rev: a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2
+  - repo: https://git.example.org/owner/repo
+    rev: main
+  - repo: local
+    hooks:
+      - id: local-hook
+  - repo: meta
+    hooks:
+      - id: check-hooks-apply
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0644))
+
+	cfg := &config.Config{
+		PreCommitConfigPaths: []string{configPath},
+		Logger:               zap.NewNop(),
+	}
+	parsedConfigs, err := newBumper(cfg).ParseConfigs()
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, writeRepoList(&out, parsedConfigs))
+
+	expected := `REPO                                VENDOR  REV                                       STATUS
+https://github.com/psf/black        github  24.1.0                                    checked
+https://gitlab.com/owner/repo       gitlab  a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2  skipped: revision is not a version
+https://git.example.org/owner/repo  -       main                                      skipped: revision is not a version
+local                               -       -                                         skipped: local or meta hooks
+meta                                -       -                                         skipped: local or meta hooks
+`
+	assert.Equal(t, expected, out.String())
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ramonvermeulen/pre-commit-bump/core/types"
+)
+
+// promptHelp explains the answers of the prompt of the interactive mode.
+const promptHelp = `y - apply this update
+n - skip this update
+a - apply this update and all remaining updates
+q - skip this update and all remaining updates
+`
+
+// promptApprover asks for every available update whether it should be applied, like "git add --patch".
+// Answering "a" applies the remaining updates without asking, "q" or the end of the input skips them.
+type promptApprover struct {
+	in   *bufio.Reader
+	out  io.Writer
+	all  bool
+	quit bool
+}
+
+// newPromptApprover creates a promptApprover that reads the answers from in and writes the prompts to out.
+func newPromptApprover(in io.Reader, out io.Writer) *promptApprover {
+	return &promptApprover{
+		in:  bufio.NewReader(in),
+		out: out,
+	}
+}
+
+// Approve prompts whether the update of the result should be applied, until a valid answer is given.
+func (p *promptApprover) Approve(result types.UpdateResult) (bool, error) {
+	switch {
+	case p.quit:
+		return false, nil
+	case p.all:
+		return true, nil
+	}
+
+	for {
+		fmt.Fprintf(p.out, "%s: %s → %s (%s) [y,n,a,q,?]? ",
+			result.Name(), result.CurrentVersion(), result.BumpVersion().String(), result.BumpVersion().GetBumpType(result.CurrentSemVer()))
+
+		answer, err := p.in.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return false, fmt.Errorf("failed to read answer: %w", err)
+		}
+		if errors.Is(err, io.EOF) && answer == "" {
+			fmt.Fprintln(p.out)
+			p.quit = true
+			return false, nil
+		}
+
+		switch strings.ToLower(strings.TrimSpace(answer)) {
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		case "a", "all":
+			p.all = true
+			return true, nil
+		case "q", "quit":
+			p.quit = true
+			return false, nil
+		default:
+			fmt.Fprint(p.out, promptHelp)
+		}
+	}
+}
+
+// isTerminal reports whether the file is a terminal, the interactive mode is not available otherwise.
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
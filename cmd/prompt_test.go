@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ramonvermeulen/pre-commit-bump/core/types"
+)
+
+func TestPromptApprover_Approve(t *testing.T) {
+	results := []types.UpdateResult{
+		{
+			Repo:           types.Repo{Repo: "https://github.com/owner/a", Rev: "v1.0.0", SemVer: &types.SemanticVersion{Major: 1}},
+			LatestVersion:  &types.SemanticVersion{Major: 2},
+			UpdateRequired: true,
+		},
+		{
+			Repo:           types.Repo{Repo: "https://github.com/owner/b", Rev: "v1.0.0", SemVer: &types.SemanticVersion{Major: 1}},
+			LatestVersion:  &types.SemanticVersion{Major: 1, Minor: 1},
+			UpdateRequired: true,
+		},
+		{
+			Repo:           types.Repo{Repo: "https://github.com/owner/c", Rev: "v1.0.0", SemVer: &types.SemanticVersion{Major: 1}},
+			LatestVersion:  &types.SemanticVersion{Major: 1, Patch: 1},
+			UpdateRequired: true,
+		},
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected []bool
+		prompts  int
+	}{
+		{name: "yes and no", input: "y\nn\nyes\n", expected: []bool{true, false, true}, prompts: 3},
+		{name: "answers are case-insensitive", input: "Y\nNO\nn\n", expected: []bool{true, false, false}, prompts: 3},
+		{name: "all applies the remaining updates", input: "n\na\n", expected: []bool{false, true, true}, prompts: 2},
+		{name: "quit skips the remaining updates", input: "y\nq\n", expected: []bool{true, false, false}, prompts: 2},
+		{name: "invalid answer prompts again", input: "maybe\ny\nn\nn\n", expected: []bool{true, false, false}, prompts: 4},
+		{name: "end of input skips the remaining updates", input: "y", expected: []bool{true, false, false}, prompts: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			approver := newPromptApprover(strings.NewReader(tt.input), &out)
+
+			var approved []bool
+			for _, result := range results {
+				ok, err := approver.Approve(result)
+				require.NoError(t, err)
+				approved = append(approved, ok)
+			}
+
+			assert.Equal(t, tt.expected, approved)
+			assert.Equal(t, tt.prompts, strings.Count(out.String(), "[y,n,a,q,?]? "))
+		})
+	}
+}
+
+func TestPromptApprover_PromptText(t *testing.T) {
+	var out bytes.Buffer
+	approver := newPromptApprover(strings.NewReader("?\ny\n"), &out)
+
+	approved, err := approver.Approve(types.UpdateResult{
+		Repo:           types.Repo{Repo: "https://github.com/owner/repo", Rev: "v1.2.3", SemVer: &types.SemanticVersion{Major: 1, Minor: 2, Patch: 3}},
+		LatestVersion:  &types.SemanticVersion{Major: 1, Minor: 3},
+		UpdateRequired: true,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, approved)
+	assert.Equal(t, "https://github.com/owner/repo: v1.2.3 → 1.3.0 (minor) [y,n,a,q,?]? "+promptHelp+
+		"https://github.com/owner/repo: v1.2.3 → 1.3.0 (minor) [y,n,a,q,?]? ", out.String())
+}
@@ -1,56 +1,280 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/url"
 	"os"
-	"slices"
+	"os/exec"
+	"os/signal"
+	"syscall"
 
 	"github.com/ramonvermeulen/pre-commit-bump/config"
+	"github.com/ramonvermeulen/pre-commit-bump/core/bumper"
+	"github.com/ramonvermeulen/pre-commit-bump/core/io"
+	"github.com/ramonvermeulen/pre-commit-bump/core/parser"
+	"github.com/ramonvermeulen/pre-commit-bump/core/types"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var rootCmd = &cobra.Command{
-	Use:     "pre-commit-bump",
-	Short:   "A tool to bump pre-commit hooks",
-	Long:    `pre-commit-bump is a command-line tool designed to help you manage and update pre-commit hooks in your projects.`,
-	PreRunE: validateGlobalFlags,
+	Use:               "pre-commit-bump",
+	Short:             "A tool to bump pre-commit hooks",
+	Long:              `pre-commit-bump is a command-line tool designed to help you manage and update pre-commit hooks in your projects.`,
+	PersistentPreRunE: preRun,
 	CompletionOptions: cobra.CompletionOptions{
 		DisableDefaultCmd: true,
 	},
 }
 
 func init() {
-	rootCmd.PersistentFlags().StringP(config.FlagConfig, "c", ".pre-commit-config.yaml", "Path to the pre-commit configuration file")
+	rootCmd.PersistentFlags().StringArrayP(config.FlagConfig, "c", []string{config.DefaultConfigFile}, "Path or glob of the pre-commit configuration files, can be repeated, - reads it from stdin and writes updates to stdout (default searches parent directories up to the git root)")
 	rootCmd.PersistentFlags().BoolP(config.FlagVerbose, "v", false, "Enable verbose logging output")
-	rootCmd.PersistentFlags().StringP(config.FlagAllow, "a", "major", "Version bump type to allow (major, minor, patch)")
+	rootCmd.PersistentFlags().BoolP(config.FlagQuiet, "q", false, "Suppress all output except errors, the exit code still reports the result")
+	rootCmd.PersistentFlags().Bool(config.FlagNoColor, false, "Disable colored output (env "+config.EnvNoColor+")")
+	rootCmd.PersistentFlags().String(config.FlagJSONLogsFile, "", "Path to additionally append the logs to as JSON lines, at the same level as the console output")
+	rootCmd.PersistentFlags().StringP(config.FlagAllow, "a", "major", "Version bump type to allow (major, minor, patch, none to only report updates)")
+	rootCmd.PersistentFlags().StringArray(config.FlagIgnore, nil, "Skip repositories matching the URL, glob or substring, can be repeated")
+	rootCmd.PersistentFlags().StringArray(config.FlagHookID, nil, "Only process repositories that provide a hook with the id, e.g. black, can be repeated")
+	rootCmd.PersistentFlags().StringArray(config.FlagOnly, nil, "Only process repositories matching the URL, glob or substring, can be repeated (--ignore filters within the selection)")
+	rootCmd.PersistentFlags().Bool(config.FlagBumpDeps, false, "Also bump additional_dependencies of hooks that are pinned with == to their latest version on PyPI")
+	rootCmd.PersistentFlags().Bool(config.FlagBumpNpmDeps, false, "Also bump additional_dependencies of hooks that are pinned with @, e.g. eslint@8.56.0, to their latest version on npm")
+	rootCmd.PersistentFlags().StringArray(config.FlagLocalVersionPattern, nil, "Also bump versions pinned in the entry or args of local hooks matching <registry>=<regex>, the regex has a name and version group, e.g. pypi=(?P<name>ruff)==(?P<version>[0-9.]+), can be repeated")
+	rootCmd.PersistentFlags().Bool(config.FlagGitFallback, false, "List the tags of repositories on unknown hosts with git ls-remote, requires git on PATH")
+	rootCmd.PersistentFlags().Bool(config.FlagStableOnly, false, "Skip pre-release versions when selecting the latest version, also for hooks on a pre-release")
+	rootCmd.PersistentFlags().String(config.FlagVersionScheme, config.VersionSchemeAuto, "Version scheme of the revisions and tags (auto, semver, calver)")
+	rootCmd.PersistentFlags().String(config.FlagTagPrefix, "", "Only select tags that start with the prefix directly followed by the version, e.g. release/ for release/1.2.3")
+	rootCmd.PersistentFlags().Bool(config.FlagStrictVersions, false, "Only accept revisions and tags that are the version as a whole, optionally preceded by v or the tag prefix")
+	rootCmd.PersistentFlags().Bool(config.FlagZeroMinorBreaking, false, "Treat minor bumps of 0.y.z versions as major, as they may be breaking under semver, so --allow minor only allows patch bumps for them")
+	rootCmd.PersistentFlags().Bool(config.FlagDisallowMutableRefs, false, "Report hooks pinned to a branch or HEAD instead of a version or full commit SHA as errors instead of skipping them")
+	rootCmd.PersistentFlags().StringToString(config.FlagVendorHost, map[string]string{}, "Map a self-hosted host to its vendor (github, gitlab, gitea), e.g. git.example.org=gitea")
+	rootCmd.PersistentFlags().StringSlice(config.FlagGitHubHosts, nil, "Hosts of GitHub Enterprise Server instances, checked with the API at https://<host>/api/v3, e.g. ghe.corp.com")
+	rootCmd.PersistentFlags().StringSlice(config.FlagGitLabHosts, nil, "Hosts of self-hosted GitLab instances, checked with the API at https://<host>/api/v4, e.g. gitlab.corp.com")
+	rootCmd.PersistentFlags().Bool(config.FlagGitHubReleases, false, "Select the versions of GitHub repositories from their published releases instead of their tags, skipping drafts and pre-releases")
+	rootCmd.PersistentFlags().String(config.FlagGitHubTagsEndpoint, config.GitHubTagsEndpointRefs, "GitHub API endpoint to list the tags from (refs for the git refs, tags for the tags with the commit they point to)")
+	rootCmd.PersistentFlags().Bool(config.FlagGitLabReleases, false, "Select the versions of GitLab repositories from their releases instead of their tags, skipping tags without a release")
+	rootCmd.PersistentFlags().String(config.FlagGitHubAPIURL, config.DefaultGitHubAPIURL, "Base URL of the GitHub API, e.g. https://github.mycorp.com/api/v3 for GitHub Enterprise Server (env "+config.EnvGitHubAPIURL+")")
+	rootCmd.PersistentFlags().String(config.FlagGitLabAPIURL, config.DefaultGitLabAPIURL, "Base URL of the GitLab API, e.g. https://gitlab.mycorp.com/api/v4 for self-hosted GitLab (env "+config.EnvGitLabAPIURL+")")
+	rootCmd.PersistentFlags().Int(config.FlagMaxAttempts, config.DefaultMaxAttempts, "Number of attempts for API requests that fail with a network error, 429 or 5xx")
+	rootCmd.PersistentFlags().String(config.FlagCacheDir, "", "Directory to cache API responses in between runs, disabled when empty (env "+config.EnvCacheDir+")")
+	rootCmd.PersistentFlags().Duration(config.FlagCacheExpiry, config.DefaultCacheExpiry, "Age after which cached API responses are no longer used, 0 keeps them forever")
+	rootCmd.PersistentFlags().Duration(config.FlagNegativeCacheExpiry, config.DefaultNegativeCacheExpiry, "Age after which repositories that had no version tags are fetched again, 0 disables caching them")
+	rootCmd.PersistentFlags().Duration(config.FlagHTTPTimeout, config.DefaultHTTPTimeout, "Timeout of a single API request, e.g. 10s or 2m (env "+config.EnvHTTPTimeout+")")
+	rootCmd.PersistentFlags().String(config.FlagProxy, "", "URL of the proxy to send API requests through, e.g. http://proxy.example.org:3128 (default uses HTTP_PROXY and HTTPS_PROXY)")
+	rootCmd.PersistentFlags().String(config.FlagUserAgent, "", "User-Agent header sent with API requests (default pre-commit-bump/<version>, env "+config.EnvUserAgent+")")
+	rootCmd.PersistentFlags().Float64(config.FlagRateLimit, 0, "Maximum number of API requests per second to a single host, e.g. 0.5 for one request every two seconds, 0 disables the limit")
+	rootCmd.PersistentFlags().Int(config.FlagMaxConcurrency, config.DefaultMaxConcurrency, "Maximum number of repositories that are checked concurrently")
+	rootCmd.PersistentFlags().StringP(config.FlagOutput, "o", config.FormatText, "Output format to emit the results in (text, junit, github, json for the planned edits, sarif)")
+	rootCmd.PersistentFlags().String(config.FlagReportFile, "pre-commit-bump-report.xml", "Path to write the report to for file based formats (junit)")
+
+	addFormatAlias(rootCmd.PersistentFlags())
+	rootCmd.MarkFlagsMutuallyExclusive(config.FlagQuiet, config.FlagVerbose)
 
 	config.BindFlag(rootCmd.PersistentFlags(), config.FlagConfig)
 	config.BindFlag(rootCmd.PersistentFlags(), config.FlagVerbose)
+	config.BindFlag(rootCmd.PersistentFlags(), config.FlagQuiet)
+	config.BindFlag(rootCmd.PersistentFlags(), config.FlagNoColor)
+	config.BindFlag(rootCmd.PersistentFlags(), config.FlagJSONLogsFile)
 	config.BindFlag(rootCmd.PersistentFlags(), config.FlagAllow)
+	config.BindFlag(rootCmd.PersistentFlags(), config.FlagIgnore)
+	config.BindFlag(rootCmd.PersistentFlags(), config.FlagOnly)
+	config.BindFlag(rootCmd.PersistentFlags(), config.FlagHookID)
+	config.BindFlag(rootCmd.PersistentFlags(), config.FlagBumpDeps)
+	config.BindFlag(rootCmd.PersistentFlags(), config.FlagBumpNpmDeps)
+	config.BindFlag(rootCmd.PersistentFlags(), config.FlagLocalVersionPattern)
+	config.BindFlag(rootCmd.PersistentFlags(), config.FlagGitFallback)
+	config.BindFlag(rootCmd.PersistentFlags(), config.FlagStableOnly)
+	config.BindFlag(rootCmd.PersistentFlags(), config.FlagStrictVersions)
+	config.BindFlag(rootCmd.PersistentFlags(), config.FlagDisallowMutableRefs)
+	config.BindFlag(rootCmd.PersistentFlags(), config.FlagZeroMinorBreaking)
+	config.BindFlag(rootCmd.PersistentFlags(), config.FlagVersionScheme)
+	config.BindFlag(rootCmd.PersistentFlags(), config.FlagTagPrefix)
+	config.BindFlag(rootCmd.PersistentFlags(), config.FlagVendorHost)
+	config.BindFlag(rootCmd.PersistentFlags(), config.FlagGitHubHosts)
+	config.BindFlag(rootCmd.PersistentFlags(), config.FlagGitLabHosts)
+	config.BindFlag(rootCmd.PersistentFlags(), config.FlagGitHubReleases)
+	config.BindFlag(rootCmd.PersistentFlags(), config.FlagGitHubTagsEndpoint)
+	config.BindFlag(rootCmd.PersistentFlags(), config.FlagGitLabReleases)
+	config.BindFlag(rootCmd.PersistentFlags(), config.FlagGitHubAPIURL)
+	config.BindFlag(rootCmd.PersistentFlags(), config.FlagGitLabAPIURL)
+	config.BindFlag(rootCmd.PersistentFlags(), config.FlagMaxAttempts)
+	config.BindFlag(rootCmd.PersistentFlags(), config.FlagCacheDir)
+	config.BindFlag(rootCmd.PersistentFlags(), config.FlagCacheExpiry)
+	config.BindFlag(rootCmd.PersistentFlags(), config.FlagNegativeCacheExpiry)
+	config.BindFlag(rootCmd.PersistentFlags(), config.FlagHTTPTimeout)
+	config.BindFlag(rootCmd.PersistentFlags(), config.FlagProxy)
+	config.BindFlag(rootCmd.PersistentFlags(), config.FlagUserAgent)
+	config.BindFlag(rootCmd.PersistentFlags(), config.FlagRateLimit)
+	config.BindFlag(rootCmd.PersistentFlags(), config.FlagMaxConcurrency)
+	config.BindFlag(rootCmd.PersistentFlags(), config.FlagOutput)
+	config.BindFlag(rootCmd.PersistentFlags(), config.FlagReportFile)
+
+	config.BindEnv(config.FlagGitHubAPIURL, config.EnvGitHubAPIURL)
+	config.BindEnv(config.FlagGitLabAPIURL, config.EnvGitLabAPIURL)
+	config.BindEnv(config.FlagCacheDir, config.EnvCacheDir)
+	config.BindEnv(config.FlagHTTPTimeout, config.EnvHTTPTimeout)
+	config.BindEnv(config.FlagUserAgent, config.EnvUserAgent)
+	config.BindEnv(config.KeyGitHubToken, config.EnvGitHubToken, config.EnvGitHubTokenFallback)
+	config.BindEnv(config.KeyGitLabToken, config.EnvGitLabToken, config.EnvGitLabTokenFallback)
 }
 
 // Execute is the entrypoint for the CLI application
+// The context of the commands is cancelled on an interrupt or termination signal, which aborts in-flight requests.
 func Execute() {
-	err := rootCmd.Execute()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	err := rootCmd.ExecuteContext(ctx)
+	stop()
 	if err != nil {
 		os.Exit(1)
 	}
 }
 
-// validateGlobalFlags checks the global flags before executing any command
+// newBumper wires up a Bumper that reads the pre-commit configuration files from disk or stdin and queries the vendor APIs.
+func newBumper(cfg *config.Config) *bumper.Bumper {
+	filesystem := io.NewStdioFileSystem(io.NewOSFileSystem(), os.Stdin, os.Stdout)
+	httpClient := newHTTPClient(cfg)
+	resultWriter := io.NewResultWriter(filesystem, os.Stdout, cfg.Logger)
+	p := parser.NewParser(cfg.Logger, filesystem)
+
+	return bumper.NewBumper(p, cfg, resultWriter, httpClient)
+}
+
+// newHTTPClient creates the client for the vendor APIs.
+// Requests are sent through the --proxy URL when set, otherwise through the proxy from the HTTP_PROXY, HTTPS_PROXY
+// and NO_PROXY environment variables. Every request carries the --user-agent header, "pre-commit-bump/<version>" by default.
+// With --rate-limit the requests to each host are throttled, on top of the --max-concurrency limit.
+func newHTTPClient(cfg *config.Config) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	if cfg.Proxy != "" {
+		if proxyURL, err := url.Parse(cfg.Proxy); err == nil {
+			transport.Proxy = http.ProxyURL(proxyURL)
+		}
+	}
+
+	var roundTripper http.RoundTripper = transport
+	if cfg.RateLimit > 0 {
+		roundTripper = bumper.NewThrottledTransport(transport, cfg.RateLimit)
+	}
+
+	userAgent := cfg.UserAgent
+	if userAgent == "" {
+		userAgent = "pre-commit-bump/" + getBuildInfo().version
+	}
+
+	return &http.Client{
+		Timeout:   cfg.HTTPTimeout,
+		Transport: &userAgentTransport{userAgent: userAgent, next: roundTripper},
+	}
+}
+
+// userAgentTransport is an http.RoundTripper that sets the User-Agent header on requests that do not set one.
+type userAgentTransport struct {
+	userAgent string
+	next      http.RoundTripper
+}
+
+// RoundTrip sets the User-Agent header on a copy of the request and sends it with the wrapped RoundTripper.
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("User-Agent") != "" {
+		return t.next.RoundTrip(req)
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.userAgent)
+	return t.next.RoundTrip(req)
+}
+
+// addFormatAlias registers --format and its -f shorthand, the former name of --output, as a hidden flag that sets
+// --output, so existing invocations keep working. The -f shorthand is deprecated in favor of -o.
+func addFormatAlias(flags *pflag.FlagSet) {
+	flags.VarP(&flagAlias{flags: flags, name: config.FlagOutput}, config.FlagFormatAlias, "f", "")
+	_ = flags.MarkHidden(config.FlagFormatAlias)
+	_ = flags.MarkShorthandDeprecated(config.FlagFormatAlias, "use -o or --"+config.FlagOutput+" instead")
+}
+
+// flagAlias is a pflag.Value that sets the value of another flag of the same FlagSet.
+type flagAlias struct {
+	flags *pflag.FlagSet
+	name  string
+}
+
+// Set sets the value of the aliased flag, which marks it as changed.
+func (a *flagAlias) Set(value string) error {
+	return a.flags.Set(a.name, value)
+}
+
+// String returns the value of the aliased flag.
+func (a *flagAlias) String() string {
+	if flag := a.flags.Lookup(a.name); flag != nil {
+		return flag.Value.String()
+	}
+	return ""
+}
+
+// Type returns the type of the aliased flag.
+func (a *flagAlias) Type() string {
+	if flag := a.flags.Lookup(a.name); flag != nil {
+		return flag.Value.Type()
+	}
+	return "string"
+}
+
+// preRun reads the ".pre-commit-bump.yaml" configuration file from the working directory or the git root
+// and validates the global flags before executing any command.
+func preRun(cmd *cobra.Command, args []string) error {
+	dirs := []string{"."}
+	if workingDir, err := os.Getwd(); err == nil {
+		if gitRoot, ok := findGitRoot(workingDir); ok {
+			dirs = append(dirs, gitRoot)
+		}
+	}
+
+	if err := config.ReadToolConfig(dirs...); err != nil {
+		return err
+	}
+
+	return validateGlobalFlags(cmd, args)
+}
+
+// validateGlobalFlags checks the global flags before executing any command.
+// The values of the flags with a fixed set of values or a valid range are validated after they are resolved from the
+// flags, the environment and the tool configuration file, see config.FromViper. The checks here need the file system,
+// the PATH or a parser that the config package can not depend on.
 func validateGlobalFlags(cmd *cobra.Command, args []string) error {
 	if cmd.Flags().Changed(config.FlagConfig) {
-		configPath, _ := cmd.Flags().GetString(config.FlagConfig)
-		if _, err := os.Stat(configPath); os.IsNotExist(err) {
-			return err
+		configPaths, _ := cmd.Flags().GetStringArray(config.FlagConfig)
+		for _, configPath := range configPaths {
+			if configPath == config.StdinPath || config.IsGlob(configPath) {
+				continue
+			}
+			if _, err := os.Stat(configPath); os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+
+	if cmd.Flags().Changed(config.FlagLocalVersionPattern) {
+		patterns, _ := cmd.Flags().GetStringArray(config.FlagLocalVersionPattern)
+		for _, pattern := range patterns {
+			if _, err := types.ParseLocalVersionPattern(pattern); err != nil {
+				return fmt.Errorf("invalid value for --local-version-pattern: %w", err)
+			}
+		}
+	}
+
+	if enabled, _ := cmd.Flags().GetBool(config.FlagGitFallback); enabled {
+		if _, err := exec.LookPath("git"); err != nil {
+			return fmt.Errorf("--%s requires git on PATH: %w", config.FlagGitFallback, err)
 		}
 	}
 
-	if cmd.Flags().Changed(config.FlagAllow) {
-		allow, _ := cmd.Flags().GetString(config.FlagAllow)
-		allowValues := []string{"major", "minor", "patch"}
-		if !slices.Contains(allowValues, allow) {
-			return fmt.Errorf("invalid value for --allow: %s. Allowed values are: %v", allow, allowValues)
+	if cmd.Flags().Changed(config.FlagHTTPTimeout) {
+		httpTimeout, _ := cmd.Flags().GetDuration(config.FlagHTTPTimeout)
+		if httpTimeout <= 0 {
+			return fmt.Errorf("invalid value for --http-timeout: %s. Must be positive", httpTimeout)
 		}
 	}
 
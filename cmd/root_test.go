@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/ramonvermeulen/pre-commit-bump/config"
+	"github.com/ramonvermeulen/pre-commit-bump/core/bumper"
+	"github.com/ramonvermeulen/pre-commit-bump/core/types"
+)
+
+func TestAddFormatAlias(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{
+			name: "former long name",
+			args: []string{"--format", config.FormatGitHub},
+		},
+		{
+			name: "deprecated shorthand",
+			args: []string{"-f", config.FormatGitHub},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			flagSet.SetOutput(io.Discard)
+			flagSet.StringP(config.FlagOutput, "o", config.FormatText, "")
+			addFormatAlias(flagSet)
+
+			require.NoError(t, flagSet.Parse(tt.args))
+
+			output, _ := flagSet.GetString(config.FlagOutput)
+			assert.Equal(t, config.FormatGitHub, output)
+			assert.True(t, flagSet.Changed(config.FlagOutput), "--output should be validated like it was passed itself")
+		})
+	}
+}
+
+func TestValidateGlobalFlags_HTTPTimeout(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		expectedError string
+	}{
+		{
+			name: "valid timeout",
+			args: []string{"--http-timeout", "10s"},
+		},
+		{
+			name:          "zero timeout",
+			args:          []string{"--http-timeout", "0s"},
+			expectedError: "invalid value for --http-timeout: 0s. Must be positive",
+		},
+		{
+			name:          "negative timeout",
+			args:          []string{"--http-timeout", "-5s"},
+			expectedError: "invalid value for --http-timeout: -5s. Must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().Duration(config.FlagHTTPTimeout, config.DefaultHTTPTimeout, "")
+			require.NoError(t, cmd.ParseFlags(tt.args))
+
+			err := validateGlobalFlags(cmd, nil)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestHTTPTimeoutFlag_Unparseable(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().Duration(config.FlagHTTPTimeout, config.DefaultHTTPTimeout, "")
+
+	err := cmd.ParseFlags([]string{"--http-timeout", "soon"})
+	assert.ErrorContains(t, err, `invalid argument "soon" for "--http-timeout" flag`)
+}
+
+func TestNewHTTPClient_Proxy(t *testing.T) {
+	client := newHTTPClient(&config.Config{Proxy: "http://proxy.example.org:3128", HTTPTimeout: config.DefaultHTTPTimeout})
+
+	userAgent, ok := client.Transport.(*userAgentTransport)
+	require.True(t, ok)
+	transport, ok := userAgent.next.(*http.Transport)
+	require.True(t, ok)
+
+	req, err := http.NewRequest(http.MethodGet, "https://api.github.com/repos/owner/repo/git/refs/tags", nil)
+	require.NoError(t, err)
+
+	proxyURL, err := transport.Proxy(req)
+	require.NoError(t, err)
+	assert.Equal(t, "http://proxy.example.org:3128", proxyURL.String())
+	assert.Equal(t, config.DefaultHTTPTimeout, client.Timeout)
+}
+
+func TestNewHTTPClient_UserAgent(t *testing.T) {
+	tests := []struct {
+		name      string
+		userAgent string
+		expected  string
+	}{
+		{name: "default", userAgent: "", expected: "pre-commit-bump/" + getBuildInfo().version},
+		{name: "configured", userAgent: "my-bot/1.0", expected: "my-bot/1.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received = r.Header.Get("User-Agent")
+				_, _ = w.Write([]byte(`[{"ref": "refs/tags/v1.0.0"}]`))
+			}))
+			defer server.Close()
+
+			client := newHTTPClient(&config.Config{UserAgent: tt.userAgent, HTTPTimeout: config.DefaultHTTPTimeout})
+			_, err := bumper.NewGithubBumper(zap.NewNop(), client, bumper.NewRetryPolicy(1), bumper.GithubOptions{APIURL: server.URL}).
+				GetVersions(t.Context(), &types.Repo{Repo: server.URL + "/owner/repo"})
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expected, received)
+		})
+	}
+}
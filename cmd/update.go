@@ -1,14 +1,13 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
-	"net/http"
 	"os"
+	"slices"
 
 	"github.com/ramonvermeulen/pre-commit-bump/config"
 	"github.com/ramonvermeulen/pre-commit-bump/core/bumper"
-	"github.com/ramonvermeulen/pre-commit-bump/core/io"
-	"github.com/ramonvermeulen/pre-commit-bump/core/parser"
 	"github.com/spf13/cobra"
 )
 
@@ -23,10 +22,26 @@ Generates a "summary.md" file that can be used to review the changes made.`,
 func init() {
 	rootCmd.AddCommand(updateCmd)
 	updateCmd.Flags().BoolP(config.FlagNoSummary, "n", false, "Disable summary generation")
-	updateCmd.Flags().BoolP(config.FlagDryRun, "d", false, "Perform a dry run showing only the diff of the \".pre-commit-config.yaml\" file without modifying it")
+	updateCmd.Flags().Bool(config.FlagAlwaysSummary, false, "Write the summary even if there are no updates or on a dry run, --no-summary takes precedence")
+	updateCmd.Flags().String(config.FlagSummaryTemplate, "", "Path of a Go text/template file to render the summary with instead of the default format")
+	updateCmd.Flags().BoolP(config.FlagDryRun, "d", false, "Perform a dry run showing only the diff of the \".pre-commit-config.yaml\" file and the summary without modifying it")
+	updateCmd.Flags().String(config.FlagConfigOut, "", "Write the updated configuration to this path instead of modifying the \".pre-commit-config.yaml\" file in place, requires a single configuration file")
+	updateCmd.Flags().Bool(config.FlagVerify, false, "Validate the updated \".pre-commit-config.yaml\" file with \"pre-commit validate-config\" (skipped when pre-commit is not installed)")
+	updateCmd.Flags().Bool(config.FlagContinueOnError, false, "Write the successful updates even if some repositories failed to be checked, exits with status code 3 in that case")
+	updateCmd.Flags().Int(config.FlagMaxBumps, 0, "Only write the first N available updates in the order of the configuration file and defer the others to a later run, 0 is unlimited")
+	updateCmd.Flags().Bool(config.FlagFreeze, false, "Write the commit SHA the new tag points to as revision, with a \"# frozen: <tag>\" comment like \"pre-commit autoupdate --freeze\"")
+	updateCmd.Flags().BoolP(config.FlagInteractive, "i", false, "Prompt for every available update whether it should be applied, requires a terminal")
 
 	config.BindFlag(updateCmd.Flags(), config.FlagNoSummary)
+	config.BindFlag(updateCmd.Flags(), config.FlagAlwaysSummary)
+	config.BindFlag(updateCmd.Flags(), config.FlagSummaryTemplate)
 	config.BindFlag(updateCmd.Flags(), config.FlagDryRun)
+	config.BindFlag(updateCmd.Flags(), config.FlagConfigOut)
+	config.BindFlag(updateCmd.Flags(), config.FlagVerify)
+	config.BindFlag(updateCmd.Flags(), config.FlagContinueOnError)
+	config.BindFlag(updateCmd.Flags(), config.FlagMaxBumps)
+	config.BindFlag(updateCmd.Flags(), config.FlagFreeze)
+	config.BindFlag(updateCmd.Flags(), config.FlagInteractive)
 }
 
 func runUpdate(cmd *cobra.Command, args []string) {
@@ -35,23 +50,47 @@ func runUpdate(cmd *cobra.Command, args []string) {
 		fmt.Fprintf(os.Stderr, "Error reading configuration: %v\n", err)
 		os.Exit(1)
 	}
+	discoverConfig(cmd, cfg)
+	if err := validateUpdateOutput(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
 
-	cfg.Logger.Sugar().Debugf("Starting update command - config_path: %s, dry_run: %t, no_summary: %t",
-		cfg.PreCommitConfigPath, cfg.DryRun, cfg.NoSummary)
+	cfg.Logger.Sugar().Debugf("Starting update command - config_paths: %v, dry_run: %t, config_out: %s, no_summary: %t, always_summary: %t, verify: %t, continue_on_error: %t, freeze: %t, interactive: %t",
+		cfg.PreCommitConfigPaths, cfg.DryRun, cfg.ConfigOut, cfg.NoSummary, cfg.AlwaysSummary, cfg.Verify, cfg.ContinueOnError, cfg.Freeze, cfg.Interactive)
 
-	filesystem := io.NewOSFileSystem()
-	httpClient := &http.Client{
-		Timeout: config.DefaultHTTPTimeout,
+	bmp := newBumper(cfg)
+	if cfg.SummaryTemplate != "" {
+		if err := bmp.SetSummaryTemplate(cfg.SummaryTemplate); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if cfg.Interactive {
+		if !isTerminal(os.Stdin) {
+			fmt.Fprintf(os.Stderr, "Error: --%s requires a terminal on stdin\n", config.FlagInteractive)
+			os.Exit(1)
+		}
+		bmp.SetApprover(newPromptApprover(os.Stdin, os.Stderr))
 	}
-	resultWriter := io.NewResultWriter(filesystem, cfg.Logger)
-	p := parser.NewParser(cfg.Logger)
-
-	bmp := bumper.NewBumper(p, cfg, resultWriter, httpClient)
 
-	if err := bmp.Update(); err != nil {
+	if err := bmp.Update(cmd.Context()); errors.Is(err, bumper.ErrPartialUpdate) {
+		fmt.Fprintf(os.Stderr, "Update completed with errors: %v\n", err)
+		os.Exit(config.ExitCodePartialUpdate)
+	} else if err != nil {
 		fmt.Fprintf(os.Stderr, "Update failed: %v\n", err)
 		os.Exit(1)
 	}
 
 	cfg.Logger.Sugar().Info("Update completed successfully")
 }
+
+// validateUpdateOutput rejects the output formats that write to stdout when the updated configuration from -c - is
+// written to stdout as well. The resolved configuration is checked, so the output format and the configuration paths
+// from the tool configuration file or the environment are rejected like flags.
+func validateUpdateOutput(cfg *config.Config) error {
+	if slices.Contains(config.StdoutFormats, cfg.Format) && !cfg.DryRun && slices.Contains(cfg.PreCommitConfigPaths, config.StdinPath) {
+		return fmt.Errorf("--%s %s writes to stdout and can not be combined with -c - on update without --%s", config.FlagOutput, cfg.Format, config.FlagDryRun)
+	}
+	return nil
+}
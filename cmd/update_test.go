@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ramonvermeulen/pre-commit-bump/config"
+)
+
+func TestValidateUpdateOutput(t *testing.T) {
+	tests := []struct {
+		name          string
+		cfg           config.Config
+		expectedError string
+	}{
+		{
+			name:          "json with stdin",
+			cfg:           config.Config{Format: config.FormatJSON, PreCommitConfigPaths: []string{config.StdinPath}},
+			expectedError: "--output json writes to stdout",
+		},
+		{
+			name:          "sarif with stdin",
+			cfg:           config.Config{Format: config.FormatSARIF, PreCommitConfigPaths: []string{config.StdinPath}},
+			expectedError: "--output sarif writes to stdout",
+		},
+		{
+			name:          "github with stdin",
+			cfg:           config.Config{Format: config.FormatGitHub, PreCommitConfigPaths: []string{config.StdinPath}},
+			expectedError: "--output github writes to stdout",
+		},
+		{
+			name: "junit with stdin",
+			cfg:  config.Config{Format: config.FormatJUnit, PreCommitConfigPaths: []string{config.StdinPath}},
+		},
+		{
+			name: "json with stdin on a dry run",
+			cfg:  config.Config{Format: config.FormatJSON, PreCommitConfigPaths: []string{config.StdinPath}, DryRun: true},
+		},
+		{
+			name: "json with a configuration file",
+			cfg:  config.Config{Format: config.FormatJSON, PreCommitConfigPaths: []string{".pre-commit-config.yaml"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateUpdateOutput(&tt.cfg)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateUpdateOutput_ToolConfig(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, config.ToolConfigName+".yaml"), []byte("output: sarif\n"), 0644))
+	t.Chdir(dir)
+	require.NoError(t, config.ReadToolConfig(dir))
+	viper.Set(config.FlagConfig, []string{config.StdinPath})
+	viper.Set(config.FlagAllow, config.BumpMajor)
+
+	cfg, err := config.FromViper()
+	require.NoError(t, err)
+
+	assert.ErrorContains(t, validateUpdateOutput(cfg), "--output sarif writes to stdout", "the output format from the tool configuration file should be rejected")
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ramonvermeulen/pre-commit-bump/config"
+	"github.com/ramonvermeulen/pre-commit-bump/core/bumper"
+	"github.com/spf13/cobra"
+)
+
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate the \".pre-commit-config.yaml\" file without checking for updates",
+	Long: `Validate the ".pre-commit-config.yaml" file without checking for updates.
+The configuration is parsed and checked for structural problems, like a repository without a URL or revision,
+which exit with status code 1. Repositories that are listed more than once and revisions that are not a version are
+reported as warnings, with --strict they exit with status code 1 as well. This command does not query any API, so it is fast enough to run as a pre-commit hook itself.`,
+	Args: cobra.NoArgs,
+	Run:  runValidate,
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+	validateCmd.Flags().Bool(config.FlagStrict, false, "Exit with status code 1 when the configuration has warnings, e.g. a repository that is listed more than once")
+
+	config.BindFlag(validateCmd.Flags(), config.FlagStrict)
+}
+
+func runValidate(cmd *cobra.Command, args []string) {
+	cfg, err := config.FromViper()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading configuration: %v\n", err)
+		os.Exit(1)
+	}
+	discoverConfig(cmd, cfg)
+
+	cfg.Logger.Sugar().Debugf("Starting validate command - config_paths: %v", cfg.PreCommitConfigPaths)
+
+	os.Exit(validate(cmd.OutOrStdout(), newBumper(cfg), cfg.Strict))
+}
+
+// configParser parses the pre-commit configuration files, it is implemented by bumper.Bumper.
+type configParser interface {
+	ParseConfigs() ([]bumper.ParsedConfig, error)
+}
+
+// validate parses the configuration files, writes their warnings to w and returns the exit code of the validate command.
+// A configuration that cannot be parsed fails the validation, warnings are informational unless strict is set.
+func validate(w io.Writer, p configParser, strict bool) int {
+	parsedConfigs, err := p.ParseConfigs()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Validation failed: %v\n", err)
+		return 1
+	}
+
+	warningCount := 0
+	for _, parsed := range parsedConfigs {
+		warnings := parsed.Config.Warnings()
+		for _, warning := range warnings {
+			fmt.Fprintf(w, "%s: warning: %s\n", parsed.Path, warning)
+		}
+		fmt.Fprintf(w, "%s: valid (repositories: %d, warnings: %d)\n", parsed.Path, len(parsed.Config.Repos), len(warnings))
+		warningCount += len(warnings)
+	}
+
+	if strict && warningCount > 0 {
+		fmt.Fprintf(os.Stderr, "Validation failed: %d warnings with --%s\n", warningCount, config.FlagStrict)
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/ramonvermeulen/pre-commit-bump/config"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name             string
+		content          string
+		strict           bool
+		expectedExitCode int
+		expectedOutput   []string
+		expectedError    string
+	}{
+		{
+			name:             "valid config",
+			content:          "repos:\n  - repo: https://github.com/psf/black\n    rev: 24.1.0\n  - repo: local\n    hooks:\n      - id: lint\n",
+			expectedExitCode: 0,
+			expectedOutput:   []string{"valid (repositories: 2, warnings: 0)"},
+		},
+		{
+			name:             "empty repository URL",
+			content:          "repos:\n  - repo: \"\"\n    rev: 24.1.0\n",
+			expectedExitCode: 1,
+			expectedError:    "repository URL is empty (line 2)",
+		},
+		{
+			name:             "missing revision",
+			content:          "repos:\n  - repo: https://github.com/psf/black\n    hooks:\n      - id: black\n",
+			expectedExitCode: 1,
+			expectedError:    "revision is empty for repository: https://github.com/psf/black (line 2)",
+		},
+		{
+			name:             "no repositories",
+			content:          "repos: []\n",
+			expectedExitCode: 1,
+			expectedError:    "no repositories found in config",
+		},
+		{
+			name:             "duplicate repository",
+			content:          "repos:\n  - repo: https://github.com/psf/black\n    rev: 24.1.0\n  - repo: https://github.com/psf/black\n    rev: 24.1.0\n",
+			expectedExitCode: 0,
+			expectedOutput: []string{
+				"warning: repository https://github.com/psf/black is listed more than once (line 4), first listed on line 2",
+				"valid (repositories: 2, warnings: 1)",
+			},
+		},
+		{
+			name:             "duplicate repository with different revisions",
+			content:          "repos:\n  - repo: https://github.com/psf/black\n    rev: 24.1.0\n  - repo: git@github.com:psf/black.git\n    rev: 23.1.0\n",
+			expectedExitCode: 0,
+			expectedOutput: []string{
+				"warning: repository git@github.com:psf/black.git is listed more than once with different revisions 24.1.0 and 23.1.0 (line 4), first listed on line 2",
+			},
+		},
+		{
+			name:             "duplicate repository with strict",
+			content:          "repos:\n  - repo: https://github.com/psf/black\n    rev: 24.1.0\n  - repo: https://github.com/psf/black\n    rev: 23.1.0\n",
+			strict:           true,
+			expectedExitCode: 1,
+			expectedOutput:   []string{"valid (repositories: 2, warnings: 1)"},
+			expectedError:    "1 warnings with --strict",
+		},
+		{
+			name:             "valid config with strict",
+			content:          "repos:\n  - repo: https://github.com/psf/black\n    rev: 24.1.0\n",
+			strict:           true,
+			expectedExitCode: 0,
+			expectedOutput:   []string{"valid (repositories: 1, warnings: 0)"},
+		},
+		{
+			name:             "revision that is not a version",
+			content:          "repos:\n  - repo: https://github.com/owner/repo\n    rev: main\n",
+			expectedExitCode: 0,
+			expectedOutput: []string{
+				"warning: revision main of repository https://github.com/owner/repo is not a version and is never bumped (line 2)",
+				"valid (repositories: 1, warnings: 1)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), ".pre-commit-config.yaml")
+			require.NoError(t, os.WriteFile(configPath, []byte(tt.content), 0644))
+
+			cfg := &config.Config{
+				PreCommitConfigPaths: []string{configPath},
+				Logger:               zap.NewNop(),
+			}
+
+			var out bytes.Buffer
+			var exitCode int
+			_, stderr := captureOutput(t, func() {
+				exitCode = validate(&out, newBumper(cfg), tt.strict)
+			})
+
+			assert.Equal(t, tt.expectedExitCode, exitCode)
+			for _, expected := range tt.expectedOutput {
+				assert.Contains(t, out.String(), configPath+": "+expected)
+			}
+			if tt.expectedError != "" {
+				assert.Contains(t, stderr, tt.expectedError)
+			} else {
+				assert.Empty(t, stderr)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"github.com/spf13/cobra"
+)
+
+// Build information, set at build time with e.g.
+// -ldflags "-X github.com/ramonvermeulen/pre-commit-bump/cmd.version=v1.2.3 -X github.com/ramonvermeulen/pre-commit-bump/cmd.commit=abc1234"
+var (
+	version = ""
+	commit  = ""
+	date    = ""
+)
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print the version of pre-commit-bump",
+	Long: `Print the version, commit and build date of pre-commit-bump.
+Binaries built with "go install" report the module version and the VCS information embedded by the Go toolchain.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		info := getBuildInfo()
+		fmt.Fprintf(cmd.OutOrStdout(), "pre-commit-bump %s\ncommit: %s\nbuilt: %s\n", info.version, info.commit, info.date)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(versionCmd)
+}
+
+// buildInfo holds the version information of the running binary.
+type buildInfo struct {
+	version string
+	commit  string
+	date    string
+}
+
+// getBuildInfo returns the build information set with -ldflags.
+// Missing values fall back to the build information embedded by the Go toolchain, and to "dev" or "unknown" after that.
+func getBuildInfo() buildInfo {
+	info := buildInfo{version: version, commit: commit, date: date}
+
+	if embedded, ok := debug.ReadBuildInfo(); ok {
+		if info.version == "" && embedded.Main.Version != "" && embedded.Main.Version != "(devel)" {
+			info.version = embedded.Main.Version
+		}
+		for _, setting := range embedded.Settings {
+			switch {
+			case setting.Key == "vcs.revision" && info.commit == "":
+				info.commit = setting.Value
+			case setting.Key == "vcs.time" && info.date == "":
+				info.date = setting.Value
+			}
+		}
+	}
+
+	if info.version == "" {
+		info.version = "dev"
+	}
+	if info.commit == "" {
+		info.commit = "unknown"
+	}
+	if info.date == "" {
+		info.date = "unknown"
+	}
+	return info
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionCommand(t *testing.T) {
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetArgs([]string{"version"})
+	t.Cleanup(func() {
+		rootCmd.SetOut(nil)
+		rootCmd.SetArgs(nil)
+	})
+
+	require.NoError(t, rootCmd.Execute())
+
+	assert.Regexp(t, `^pre-commit-bump \S+\ncommit: \S+\nbuilt: \S+\n$`, out.String())
+}
+
+func TestGetBuildInfo_LdflagsTakePrecedence(t *testing.T) {
+	original := [3]string{version, commit, date}
+	version, commit, date = "v1.2.3", "abc1234", "2024-01-01T00:00:00Z"
+	t.Cleanup(func() {
+		version, commit, date = original[0], original[1], original[2]
+	})
+
+	assert.Equal(t, buildInfo{version: "v1.2.3", commit: "abc1234", date: "2024-01-01T00:00:00Z"}, getBuildInfo())
+}
@@ -1,9 +1,13 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
 	"os"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/spf13/pflag"
 
@@ -14,18 +18,159 @@ import (
 
 // Config holds all configuration values for the pre-commit bumper tool
 type Config struct {
-	// PreCommitConfigPath is the path to the pre-commit configuration file
-	PreCommitConfigPath string
+	// PreCommitConfigPaths holds the paths or globs of the pre-commit configuration files to process
+	PreCommitConfigPaths []string
 
 	// Allow specifies the version bump type to allow (major, minor, patch)
 	Allow string
 
+	// Ignore holds URLs, globs or substrings of repositories that are skipped and reported as ignored
+	Ignore []string
+
+	// Only holds URLs, globs or substrings of repositories to select, all other repositories are skipped when set
+	Only []string
+
+	// HookIDs selects the repositories that provide any of the hook ids, all other repositories are skipped when set
+	HookIDs []string
+
+	// BumpDeps enables bumping pinned additional_dependencies of hooks to their latest version on PyPI
+	BumpDeps bool
+
+	// BumpNpmDeps enables bumping additional_dependencies of hooks pinned with "@" to their latest version on the npm registry
+	BumpNpmDeps bool
+
+	// LocalVersionPatterns are "<registry>=<regex>" patterns of versions pinned in the entry or args of local hooks, e.g. "pypi=(?P<name>ruff)==(?P<version>[0-9.]+)"
+	LocalVersionPatterns []string
+
+	// GitFallback lists the tags of repositories on unknown hosts with git ls-remote, requires git on PATH
+	GitFallback bool
+
+	// StableOnly skips pre-release versions when selecting the latest version, also for hooks on a pre-release
+	StableOnly bool
+
+	// ZeroMinorBreaking treats minor bumps of 0.y.z versions as major, so --allow minor only allows patch bumps for them
+	ZeroMinorBreaking bool
+
+	// DisallowMutableRefs reports repositories pinned to a branch or "HEAD" as errors instead of skipping them
+	DisallowMutableRefs bool
+
+	// StrictVersions only accepts revisions and tags that are the version as a whole, optionally preceded by "v" or the tag prefix
+	StrictVersions bool
+
+	// VersionScheme determines how revisions and tags are parsed (auto, semver, calver)
+	VersionScheme string
+
+	// TagPrefix only selects tags that start with the prefix, e.g. "release/" for tags like "release/1.2.3"
+	TagPrefix string
+
+	// VendorHosts maps self-hosted hosts to the vendor serving them (github, gitlab, gitea)
+	VendorHosts map[string]string
+
+	// GitHubHosts are hosts of GitHub Enterprise Server instances, their repositories are checked with the GitHub API of the host
+	GitHubHosts []string
+
+	// GitLabHosts are hosts of self-hosted GitLab instances, their repositories are checked with the GitLab API of the host
+	GitLabHosts []string
+
+	// GitHubReleases reads the versions of GitHub repositories from their published releases instead of their tags
+	GitHubReleases bool
+
+	// GitHubTagsEndpoint is the GitHub API endpoint the tags are listed from, either "refs" or "tags"
+	GitHubTagsEndpoint string
+
+	// GitLabReleases reads the versions of GitLab repositories from their releases instead of their tags
+	GitLabReleases bool
+
+	// GitHubAPIURL is the base URL of the GitHub API, overridden for GitHub Enterprise Server
+	GitHubAPIURL string
+
+	// GitLabAPIURL is the base URL of the GitLab API, overridden for self-hosted GitLab
+	GitLabAPIURL string
+
+	// GitHubToken is used to authenticate GitHub API requests, read from the environment only
+	GitHubToken string
+
+	// GitLabToken is used to authenticate GitLab API requests, read from the environment only
+	GitLabToken string
+
+	// MaxAttempts is the number of attempts for API requests that fail transiently
+	MaxAttempts int
+
+	// CacheDir is the directory API responses are cached in between runs, caching is disabled when empty
+	CacheDir string
+
+	// CacheExpiry is the age after which cached API responses are no longer used
+	CacheExpiry time.Duration
+
+	// NegativeCacheExpiry is the age after which repositories that had no version tags are fetched again, 0 disables it
+	NegativeCacheExpiry time.Duration
+
+	// HTTPTimeout is the timeout of a single API request, including reading the response body
+	HTTPTimeout time.Duration
+
+	// Proxy is the URL of the proxy API requests are sent through, HTTP_PROXY and HTTPS_PROXY are used when empty
+	Proxy string
+
+	// UserAgent is the User-Agent header sent with API requests, "pre-commit-bump/<version>" when empty
+	UserAgent string
+
+	// RateLimit is the maximum number of API requests per second to a single host, unlimited when zero
+	RateLimit float64
+
+	// MaxConcurrency is the maximum number of repositories that are checked concurrently
+	MaxConcurrency int
+
 	// NoSummary disables summary generation (update command only)
 	NoSummary bool
 
+	// AlwaysSummary writes the summary even if there are no updates or on a dry run, NoSummary takes precedence (update command only)
+	AlwaysSummary bool
+
+	// SummaryTemplate is the path of a Go text/template the summary is rendered with instead of the default format (update command only)
+	SummaryTemplate string
+
 	// DryRun performs a dry run without modifying files (update command only)
 	DryRun bool
 
+	// ConfigOut is the path the updated configuration is written to instead of the configuration file itself (update command only)
+	ConfigOut string
+
+	// Verify validates the rewritten file with pre-commit after updating (update command only)
+	Verify bool
+
+	// ContinueOnError writes the successful updates even if some repositories failed to be checked (update command only)
+	ContinueOnError bool
+
+	// FailIfEmpty exits with a non-zero status code when no repository could be checked (check command only)
+	FailIfEmpty bool
+
+	// Strict fails the validation when the configuration has warnings, like a repository that is listed more than once (validate command only)
+	Strict bool
+
+	// MaxBumps limits the number of updates that are written in a single run, 0 is unlimited (update command only)
+	MaxBumps int
+
+	// Freeze writes the commit SHA the new tag points to as revision, with a "# frozen: <tag>" comment (update command only)
+	Freeze bool
+
+	// Interactive prompts for every available update whether it should be applied (update command only)
+	Interactive bool
+
+	// Format is the output format to emit the results in (text, junit, github, json, sarif)
+	Format string
+
+	// ReportFile is the path the report is written to for file based formats
+	ReportFile string
+
+	// NoColor is set when output is not colored, due to --no-color, the NO_COLOR environment variable or a non-terminal stderr
+	NoColor bool
+
+	// Quiet suppresses all output except errors, set with --quiet
+	Quiet bool
+
+	// JSONLogsFile is the path the logs are additionally written to as JSON lines, disabled when empty
+	JSONLogsFile string
+
 	// LogLevel determines the logging verbosity
 	LogLevel zapcore.Level
 
@@ -33,7 +178,23 @@ type Config struct {
 	Logger *zap.Logger
 }
 
-// getLogLevel determines the log level from verbose flag and environment variable
+// getToken reads a token from the environment. The tool configuration file is committed to the repository, so it can
+// not hold the token itself, only the name of the environment variable to read it from under envKey. That variable takes
+// precedence over the default environment variables bound to key.
+func getToken(key, envKey string) (string, error) {
+	if viper.InConfig(key) {
+		return "", fmt.Errorf("%s can not be set in the %s configuration file, name the environment variable that holds it with %s instead", key, ToolConfigName, envKey)
+	}
+	if envName := viper.GetString(envKey); envName != "" {
+		if token := os.Getenv(envName); token != "" {
+			return token, nil
+		}
+	}
+	return viper.GetString(key), nil
+}
+
+// getLogLevel determines the log level from the quiet and verbose flags and the PCB_LOG environment variable.
+// The quiet flag takes precedence, it only lets errors through.
 func getLogLevel() zapcore.Level {
 	levelMap := map[string]zapcore.Level{
 		"DEBUG":   zapcore.DebugLevel,
@@ -43,6 +204,10 @@ func getLogLevel() zapcore.Level {
 		"ERROR":   zapcore.ErrorLevel,
 	}
 
+	if viper.GetBool(FlagQuiet) {
+		return zapcore.ErrorLevel
+	}
+
 	if envLevel := os.Getenv("PCB_LOG"); envLevel != "" {
 		if lvl, ok := levelMap[strings.ToUpper(envLevel)]; ok {
 			return lvl
@@ -56,33 +221,277 @@ func getLogLevel() zapcore.Level {
 	return zapcore.InfoLevel
 }
 
-// newLogger creates a basic zap logger
-func newLogger(level zapcore.Level) *zap.Logger {
+// getHTTPTimeout determines the timeout of API requests.
+// The --http-timeout flag is validated before the command runs, an invalid PCB_HTTP_TIMEOUT value falls back to the default.
+func getHTTPTimeout() time.Duration {
+	value := viper.GetString(FlagHTTPTimeout)
+	if value == "" {
+		return DefaultHTTPTimeout
+	}
+
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		fmt.Fprintf(os.Stderr, "Warning: invalid HTTP timeout %q, using the default of %s\n", value, DefaultHTTPTimeout)
+		return DefaultHTTPTimeout
+	}
+	return timeout
+}
+
+// useColor determines whether the log output is colored.
+// Colors are disabled by the --no-color flag, a non-empty NO_COLOR environment variable, or when stderr is not a terminal.
+func useColor() bool {
+	if viper.GetBool(FlagNoColor) || os.Getenv(EnvNoColor) != "" {
+		return false
+	}
+
+	info, err := os.Stderr.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// newLoggerConfig creates the zap configuration for the logger, with colored levels when color is set
+func newLoggerConfig(level zapcore.Level, color bool) zap.Config {
 	config := zap.NewDevelopmentConfig()
 	config.Level = zap.NewAtomicLevelAt(level)
 	config.DisableCaller = true
-	logger, _ := config.Build()
-	return logger
+	if color {
+		config.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+	} else {
+		config.EncoderConfig.EncodeLevel = zapcore.CapitalLevelEncoder
+	}
+	return config
+}
+
+// newLogger creates a basic zap logger.
+// When jsonLogsFile is set, the logs are also appended to that file as JSON lines at the same level, while the console
+// output stays as is.
+func newLogger(level zapcore.Level, color bool, jsonLogsFile string) (*zap.Logger, error) {
+	logger, _ := newLoggerConfig(level, color).Build()
+	if jsonLogsFile == "" {
+		return logger, nil
+	}
+
+	file, err := os.OpenFile(jsonLogsFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open JSON logs file: %w", err)
+	}
+
+	encoderConfig := zap.NewProductionEncoderConfig()
+	encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	fileCore := zapcore.NewCore(zapcore.NewJSONEncoder(encoderConfig), zapcore.Lock(file), level)
+
+	return logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return zapcore.NewTee(core, fileCore)
+	})), nil
 }
 
-// FromViper creates a Config from viper values
+// FromViper creates a Config from viper values.
+// The values of the flags with a fixed set of values or a valid range are validated regardless of whether they were
+// set with a flag, an environment variable or a config file, see validateValues.
 func FromViper() (*Config, error) {
-	configPath := viper.GetString(FlagConfig)
+	if err := validateValues(); err != nil {
+		return nil, err
+	}
+
+	configPaths := viper.GetStringSlice(FlagConfig)
 	allow := viper.GetString(FlagAllow)
+	ignore := viper.GetStringSlice(FlagIgnore)
+	only := viper.GetStringSlice(FlagOnly)
+	hookIDs := viper.GetStringSlice(FlagHookID)
+	bumpDeps := viper.GetBool(FlagBumpDeps)
+	bumpNpmDeps := viper.GetBool(FlagBumpNpmDeps)
+	localVersionPatterns := viper.GetStringSlice(FlagLocalVersionPattern)
+	gitFallback := viper.GetBool(FlagGitFallback)
+	stableOnly := viper.GetBool(FlagStableOnly)
+	strictVersions := viper.GetBool(FlagStrictVersions)
+	disallowMutableRefs := viper.GetBool(FlagDisallowMutableRefs)
+	zeroMinorBreaking := viper.GetBool(FlagZeroMinorBreaking)
+	versionScheme := viper.GetString(FlagVersionScheme)
+	tagPrefix := viper.GetString(FlagTagPrefix)
+	vendorHosts := viper.GetStringMapString(FlagVendorHost)
+	gitHubHosts := viper.GetStringSlice(FlagGitHubHosts)
+	gitLabHosts := viper.GetStringSlice(FlagGitLabHosts)
+	gitHubReleases := viper.GetBool(FlagGitHubReleases)
+	gitHubTagsEndpoint := viper.GetString(FlagGitHubTagsEndpoint)
+	gitLabReleases := viper.GetBool(FlagGitLabReleases)
+	gitHubAPIURL := viper.GetString(FlagGitHubAPIURL)
+	gitLabAPIURL := viper.GetString(FlagGitLabAPIURL)
+	gitHubToken, err := getToken(KeyGitHubToken, KeyGitHubTokenEnv)
+	if err != nil {
+		return nil, err
+	}
+	gitLabToken, err := getToken(KeyGitLabToken, KeyGitLabTokenEnv)
+	if err != nil {
+		return nil, err
+	}
+	maxAttempts := viper.GetInt(FlagMaxAttempts)
+	cacheDir := viper.GetString(FlagCacheDir)
+	cacheExpiry := viper.GetDuration(FlagCacheExpiry)
+	negativeCacheExpiry := viper.GetDuration(FlagNegativeCacheExpiry)
+	httpTimeout := getHTTPTimeout()
+	proxy := viper.GetString(FlagProxy)
+	userAgent := viper.GetString(FlagUserAgent)
+	rateLimit := viper.GetFloat64(FlagRateLimit)
+	maxConcurrency := viper.GetInt(FlagMaxConcurrency)
 	noSummary := viper.GetBool(FlagNoSummary)
+	alwaysSummary := viper.GetBool(FlagAlwaysSummary)
+	summaryTemplate := viper.GetString(FlagSummaryTemplate)
 	dryRun := viper.GetBool(FlagDryRun)
+	configOut := viper.GetString(FlagConfigOut)
+	continueOnError := viper.GetBool(FlagContinueOnError)
+	failIfEmpty := viper.GetBool(FlagFailIfEmpty)
+	strict := viper.GetBool(FlagStrict)
+	freeze := viper.GetBool(FlagFreeze)
+	maxBumps := viper.GetInt(FlagMaxBumps)
+	interactive := viper.GetBool(FlagInteractive)
+	verify := viper.GetBool(FlagVerify)
+	format := viper.GetString(FlagOutput)
+	reportFile := viper.GetString(FlagReportFile)
+	quiet := viper.GetBool(FlagQuiet)
 	logLevel := getLogLevel()
+	color := useColor()
+	jsonLogsFile := viper.GetString(FlagJSONLogsFile)
+	logger, err := newLogger(logLevel, color, jsonLogsFile)
+	if err != nil {
+		return nil, err
+	}
 
 	return &Config{
-		PreCommitConfigPath: configPath,
-		Allow:               allow,
-		NoSummary:           noSummary,
-		DryRun:              dryRun,
-		LogLevel:            logLevel,
-		Logger:              newLogger(logLevel),
+		PreCommitConfigPaths: configPaths,
+		Allow:                allow,
+		Ignore:               ignore,
+		Only:                 only,
+		HookIDs:              hookIDs,
+		BumpDeps:             bumpDeps,
+		BumpNpmDeps:          bumpNpmDeps,
+		LocalVersionPatterns: localVersionPatterns,
+		GitFallback:          gitFallback,
+		StableOnly:           stableOnly,
+		StrictVersions:       strictVersions,
+		DisallowMutableRefs:  disallowMutableRefs,
+		ZeroMinorBreaking:    zeroMinorBreaking,
+		VersionScheme:        versionScheme,
+		TagPrefix:            tagPrefix,
+		VendorHosts:          vendorHosts,
+		GitHubHosts:          gitHubHosts,
+		GitLabHosts:          gitLabHosts,
+		GitHubReleases:       gitHubReleases,
+		GitHubTagsEndpoint:   gitHubTagsEndpoint,
+		GitLabReleases:       gitLabReleases,
+		GitHubAPIURL:         gitHubAPIURL,
+		GitLabAPIURL:         gitLabAPIURL,
+		GitHubToken:          gitHubToken,
+		GitLabToken:          gitLabToken,
+		MaxAttempts:          maxAttempts,
+		CacheDir:             cacheDir,
+		CacheExpiry:          cacheExpiry,
+		NegativeCacheExpiry:  negativeCacheExpiry,
+		HTTPTimeout:          httpTimeout,
+		Proxy:                proxy,
+		UserAgent:            userAgent,
+		RateLimit:            rateLimit,
+		MaxConcurrency:       maxConcurrency,
+		NoSummary:            noSummary,
+		AlwaysSummary:        alwaysSummary,
+		SummaryTemplate:      summaryTemplate,
+		DryRun:               dryRun,
+		ConfigOut:            configOut,
+		ContinueOnError:      continueOnError,
+		FailIfEmpty:          failIfEmpty,
+		Strict:               strict,
+		Freeze:               freeze,
+		MaxBumps:             maxBumps,
+		Interactive:          interactive,
+		Verify:               verify,
+		Format:               format,
+		ReportFile:           reportFile,
+		NoColor:              !color,
+		Quiet:                quiet,
+		JSONLogsFile:         jsonLogsFile,
+		LogLevel:             logLevel,
+		Logger:               logger,
 	}, nil
 }
 
+// validateValues validates the resolved viper values of the flags with a fixed set of values or a valid range.
+// The allow level is always validated, other values only when they are set, so the defaults of unbound flags are not
+// rejected. The HTTP timeout falls back to its default instead, see getHTTPTimeout.
+func validateValues() error {
+	allow := viper.GetString(FlagAllow)
+	if !slices.Contains(AllowValues, allow) {
+		return fmt.Errorf("invalid value for %s: %q. Allowed values are: %v", FlagAllow, allow, AllowValues)
+	}
+
+	for _, option := range []struct {
+		key    string
+		values []string
+	}{
+		{FlagOutput, OutputValues},
+		{FlagVersionScheme, VersionSchemeValues},
+		{FlagGitHubTagsEndpoint, GitHubTagsEndpointValues},
+	} {
+		if value := viper.GetString(option.key); viper.IsSet(option.key) && !slices.Contains(option.values, value) {
+			return fmt.Errorf("invalid value for %s: %q. Allowed values are: %v", option.key, value, option.values)
+		}
+	}
+
+	for host, vendor := range viper.GetStringMapString(FlagVendorHost) {
+		if !slices.Contains(VendorHostValues, vendor) {
+			return fmt.Errorf("invalid vendor for %s %s: %q. Allowed values are: %v", FlagVendorHost, host, vendor, VendorHostValues)
+		}
+	}
+
+	for _, key := range []string{FlagMaxAttempts, FlagMaxConcurrency} {
+		if value := viper.GetInt(key); viper.IsSet(key) && value < 1 {
+			return fmt.Errorf("invalid value for %s: %d. Must be at least 1", key, value)
+		}
+	}
+	if maxBumps := viper.GetInt(FlagMaxBumps); maxBumps < 0 {
+		return fmt.Errorf("invalid value for %s: %d. Must not be negative", FlagMaxBumps, maxBumps)
+	}
+	if rateLimit := viper.GetFloat64(FlagRateLimit); rateLimit < 0 {
+		return fmt.Errorf("invalid value for %s: %g. Must not be negative", FlagRateLimit, rateLimit)
+	}
+	for _, key := range []string{FlagCacheExpiry, FlagNegativeCacheExpiry} {
+		if value := viper.GetDuration(key); value < 0 {
+			return fmt.Errorf("invalid value for %s: %s. Must not be negative", key, value)
+		}
+	}
+
+	for _, key := range []string{FlagGitHubAPIURL, FlagGitLabAPIURL} {
+		value := viper.GetString(key)
+		if parsed, err := url.Parse(value); viper.IsSet(key) && (err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "") {
+			return fmt.Errorf("invalid value for %s: %q. Expected an absolute http(s) URL", key, value)
+		}
+	}
+	if proxy := viper.GetString(FlagProxy); proxy != "" {
+		if parsed, err := url.Parse(proxy); err != nil || !slices.Contains(ProxySchemes, parsed.Scheme) || parsed.Host == "" {
+			return fmt.Errorf("invalid value for %s: %q. Expected an absolute URL with one of the schemes %v", FlagProxy, proxy, ProxySchemes)
+		}
+	}
+
+	return nil
+}
+
+// ReadToolConfig reads the defaults of the flags from the first ".pre-commit-bump.yaml" found in the directories.
+// The keys of the file are the flag names, e.g. "allow: minor" or "max-concurrency: 4". Flags take precedence over
+// environment variables, which take precedence over the file. A missing file is not an error.
+func ReadToolConfig(dirs ...string) error {
+	viper.SetConfigName(ToolConfigName)
+	viper.SetConfigType("yaml")
+	for _, dir := range dirs {
+		viper.AddConfigPath(dir)
+	}
+
+	if err := viper.ReadInConfig(); err != nil {
+		var notFound viper.ConfigFileNotFoundError
+		if errors.As(err, &notFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to read %s configuration file: %w", ToolConfigName, err)
+	}
+	return nil
+}
+
 // BindFlag binds a flag from a FlagSet to viper and handles errors during binding
 func BindFlag(flagSet *pflag.FlagSet, flagName string) {
 	if err := viper.BindPFlag(flagName, flagSet.Lookup(flagName)); err != nil {
@@ -90,3 +499,17 @@ func BindFlag(flagSet *pflag.FlagSet, flagName string) {
 		os.Exit(1)
 	}
 }
+
+// BindEnv binds one or more environment variables to a viper key and handles errors during binding
+// When multiple environment variables are given, the first one that is set takes precedence.
+func BindEnv(key string, envNames ...string) {
+	if err := viper.BindEnv(append([]string{key}, envNames...)...); err != nil {
+		fmt.Fprintf(os.Stderr, "Error binding environment variables %v: %v\n", envNames, err)
+		os.Exit(1)
+	}
+}
+
+// IsGlob reports whether the path contains glob meta characters and should be expanded
+func IsGlob(path string) bool {
+	return strings.ContainsAny(path, "*?[")
+}
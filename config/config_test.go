@@ -0,0 +1,419 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestUseColor_NoColor(t *testing.T) {
+	tests := []struct {
+		name    string
+		noColor string
+		flag    bool
+	}{
+		{
+			name:    "NO_COLOR environment variable",
+			noColor: "1",
+		},
+		{
+			name: "--no-color flag",
+			flag: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(EnvNoColor, tt.noColor)
+			viper.Set(FlagNoColor, tt.flag)
+			t.Cleanup(viper.Reset)
+
+			color := useColor()
+			assert.False(t, color)
+
+			encodeLevel := newLoggerConfig(zapcore.InfoLevel, color).EncoderConfig.EncodeLevel
+			assert.Equal(t, reflect.ValueOf(zapcore.CapitalLevelEncoder).Pointer(), reflect.ValueOf(encodeLevel).Pointer())
+		})
+	}
+}
+
+func TestNewLoggerConfig_Color(t *testing.T) {
+	encodeLevel := newLoggerConfig(zapcore.InfoLevel, true).EncoderConfig.EncodeLevel
+	assert.Equal(t, reflect.ValueOf(zapcore.CapitalColorLevelEncoder).Pointer(), reflect.ValueOf(encodeLevel).Pointer())
+}
+
+func TestNewLogger_JSONLogsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs.jsonl")
+
+	logger, err := newLogger(zapcore.InfoLevel, false, path)
+	require.NoError(t, err)
+
+	logger.Sugar().Debugf("Parsing configuration file: %s", ".pre-commit-config.yaml")
+	logger.Sugar().Infof("Update available for %s: %s -> %s", "https://github.com/psf/black", "24.1.0", "24.2.0")
+	logger.Sugar().Warnf("Check completed, %s", "nothing to check")
+	_ = logger.Sync()
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2, "debug logs should be filtered at the info level")
+	for _, line := range lines {
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry), "each line should be valid JSON: %s", line)
+		assert.Contains(t, entry, "ts")
+	}
+	assert.Contains(t, lines[0], `"level":"info"`)
+	assert.Contains(t, lines[0], `"msg":"Update available for https://github.com/psf/black: 24.1.0 -> 24.2.0"`)
+	assert.Contains(t, lines[1], `"level":"warn"`)
+}
+
+func TestNewLogger_JSONLogsFileError(t *testing.T) {
+	_, err := newLogger(zapcore.InfoLevel, false, filepath.Join(t.TempDir(), "missing", "logs.jsonl"))
+	assert.ErrorContains(t, err, "failed to open JSON logs file")
+}
+
+func TestGetLogLevel(t *testing.T) {
+	tests := []struct {
+		name     string
+		quiet    bool
+		verbose  bool
+		envLevel string
+		expected zapcore.Level
+	}{
+		{name: "default", expected: zapcore.InfoLevel},
+		{name: "verbose", verbose: true, expected: zapcore.DebugLevel},
+		{name: "PCB_LOG", envLevel: "warn", expected: zapcore.WarnLevel},
+		{name: "quiet", quiet: true, expected: zapcore.ErrorLevel},
+		{name: "quiet takes precedence over PCB_LOG", quiet: true, envLevel: "debug", expected: zapcore.ErrorLevel},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(viper.Reset)
+			t.Setenv("PCB_LOG", tt.envLevel)
+			viper.Set(FlagQuiet, tt.quiet)
+			viper.Set(FlagVerbose, tt.verbose)
+
+			assert.Equal(t, tt.expected, getLogLevel())
+		})
+	}
+}
+
+func TestGetHTTPTimeout(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Duration
+	}{
+		{name: "unset uses default", value: "", expected: DefaultHTTPTimeout},
+		{name: "valid duration", value: "10s", expected: 10 * time.Second},
+		{name: "unparseable falls back to default", value: "soon", expected: DefaultHTTPTimeout},
+		{name: "non positive falls back to default", value: "0s", expected: DefaultHTTPTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(viper.Reset)
+			t.Setenv(EnvHTTPTimeout, tt.value)
+			BindEnv(FlagHTTPTimeout, EnvHTTPTimeout)
+
+			assert.Equal(t, tt.expected, getHTTPTimeout())
+		})
+	}
+}
+
+func TestFromViper(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	viper.Set(FlagConfig, []string{".pre-commit-config.yaml", "services/*/.pre-commit-config.yaml"})
+	viper.Set(FlagAllow, BumpMinor)
+	viper.Set(FlagIgnore, []string{"https://github.com/psf/black"})
+	viper.Set(FlagOnly, []string{"pycqa"})
+	viper.Set(FlagBumpDeps, true)
+	viper.Set(FlagGitFallback, true)
+	viper.Set(FlagStableOnly, true)
+	viper.Set(FlagVersionScheme, VersionSchemeCalVer)
+	viper.Set(FlagVendorHost, map[string]string{"git.example.org": VendorGitea})
+	viper.Set(FlagGitHubAPIURL, "https://github.example.org/api/v3")
+	viper.Set(FlagGitLabAPIURL, "https://gitlab.example.org/api/v4")
+	viper.Set(KeyGitHubToken, "github-token")
+	viper.Set(KeyGitLabToken, "gitlab-token")
+	viper.Set(FlagMaxAttempts, 5)
+	viper.Set(FlagCacheDir, "/tmp/cache")
+	viper.Set(FlagCacheExpiry, "2h")
+	viper.Set(FlagHTTPTimeout, "45s")
+	viper.Set(FlagProxy, "http://proxy.example.org:3128")
+	viper.Set(FlagUserAgent, "my-bot/1.0")
+	viper.Set(FlagRateLimit, 2.5)
+	viper.Set(FlagMaxConcurrency, 4)
+	viper.Set(FlagNoSummary, true)
+	viper.Set(FlagDryRun, true)
+	viper.Set(FlagContinueOnError, true)
+	viper.Set(FlagFreeze, true)
+	viper.Set(FlagVerify, true)
+	viper.Set(FlagOutput, FormatJUnit)
+	viper.Set(FlagReportFile, "report.xml")
+	viper.Set(FlagQuiet, true)
+
+	cfg, err := FromViper()
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{".pre-commit-config.yaml", "services/*/.pre-commit-config.yaml"}, cfg.PreCommitConfigPaths)
+	assert.Equal(t, BumpMinor, cfg.Allow)
+	assert.Equal(t, []string{"https://github.com/psf/black"}, cfg.Ignore)
+	assert.Equal(t, []string{"pycqa"}, cfg.Only)
+	assert.True(t, cfg.BumpDeps)
+	assert.True(t, cfg.GitFallback)
+	assert.True(t, cfg.StableOnly)
+	assert.Equal(t, VersionSchemeCalVer, cfg.VersionScheme)
+	assert.Equal(t, map[string]string{"git.example.org": VendorGitea}, cfg.VendorHosts)
+	assert.Equal(t, "https://github.example.org/api/v3", cfg.GitHubAPIURL)
+	assert.Equal(t, "https://gitlab.example.org/api/v4", cfg.GitLabAPIURL)
+	assert.Equal(t, "github-token", cfg.GitHubToken)
+	assert.Equal(t, "gitlab-token", cfg.GitLabToken)
+	assert.Equal(t, 5, cfg.MaxAttempts)
+	assert.Equal(t, "/tmp/cache", cfg.CacheDir)
+	assert.Equal(t, 2*time.Hour, cfg.CacheExpiry)
+	assert.Equal(t, 45*time.Second, cfg.HTTPTimeout)
+	assert.Equal(t, "http://proxy.example.org:3128", cfg.Proxy)
+	assert.Equal(t, "my-bot/1.0", cfg.UserAgent)
+	assert.Equal(t, 2.5, cfg.RateLimit)
+	assert.Equal(t, 4, cfg.MaxConcurrency)
+	assert.True(t, cfg.NoSummary)
+	assert.True(t, cfg.DryRun)
+	assert.True(t, cfg.ContinueOnError)
+	assert.True(t, cfg.Freeze)
+	assert.True(t, cfg.Verify)
+	assert.Equal(t, FormatJUnit, cfg.Format)
+	assert.Equal(t, "report.xml", cfg.ReportFile)
+	assert.True(t, cfg.Quiet)
+	assert.Equal(t, zapcore.ErrorLevel, cfg.LogLevel)
+	assert.NotNil(t, cfg.Logger)
+}
+
+func TestFromViper_InvalidAllow(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	viper.Set(FlagAllow, "foo")
+
+	cfg, err := FromViper()
+
+	assert.EqualError(t, err, `invalid value for allow: "foo". Allowed values are: [major minor patch none]`)
+	assert.Nil(t, cfg)
+}
+
+func TestFromViper_InvalidValues(t *testing.T) {
+	tests := []struct {
+		name          string
+		key           string
+		value         any
+		expectedError string
+	}{
+		{
+			name:          "output",
+			key:           FlagOutput,
+			value:         "bogus",
+			expectedError: `invalid value for output: "bogus". Allowed values are: [text junit github json sarif]`,
+		},
+		{
+			name:          "version scheme",
+			key:           FlagVersionScheme,
+			value:         "nope",
+			expectedError: `invalid value for version-scheme: "nope". Allowed values are: [auto semver calver]`,
+		},
+		{
+			name:          "GitHub tags endpoint",
+			key:           FlagGitHubTagsEndpoint,
+			value:         "branches",
+			expectedError: `invalid value for github-tags-endpoint: "branches". Allowed values are: [refs tags]`,
+		},
+		{
+			name:          "vendor host",
+			key:           FlagVendorHost,
+			value:         map[string]string{"git.example.org": "bitbucket"},
+			expectedError: `invalid vendor for vendor-host git.example.org: "bitbucket". Allowed values are: [github gitlab gitea]`,
+		},
+		{
+			name:          "max attempts",
+			key:           FlagMaxAttempts,
+			value:         0,
+			expectedError: "invalid value for max-attempts: 0. Must be at least 1",
+		},
+		{
+			name:          "max concurrency",
+			key:           FlagMaxConcurrency,
+			value:         -1,
+			expectedError: "invalid value for max-concurrency: -1. Must be at least 1",
+		},
+		{
+			name:          "max bumps",
+			key:           FlagMaxBumps,
+			value:         -1,
+			expectedError: "invalid value for max-bumps: -1. Must not be negative",
+		},
+		{
+			name:          "rate limit",
+			key:           FlagRateLimit,
+			value:         -0.5,
+			expectedError: "invalid value for rate-limit: -0.5. Must not be negative",
+		},
+		{
+			name:          "cache expiry",
+			key:           FlagCacheExpiry,
+			value:         "-1h",
+			expectedError: "invalid value for cache-expiry: -1h0m0s. Must not be negative",
+		},
+		{
+			name:          "API URL",
+			key:           FlagGitHubAPIURL,
+			value:         "github.example.org",
+			expectedError: `invalid value for github-api-url: "github.example.org". Expected an absolute http(s) URL`,
+		},
+		{
+			name:          "proxy",
+			key:           FlagProxy,
+			value:         "ftp://proxy.example.org",
+			expectedError: `invalid value for proxy: "ftp://proxy.example.org". Expected an absolute URL with one of the schemes [http https socks5]`,
+		},
+		{
+			name:          "proxy without scheme",
+			key:           FlagProxy,
+			value:         "proxy.example.org:3128",
+			expectedError: `invalid value for proxy: "proxy.example.org:3128". Expected an absolute URL with one of the schemes [http https socks5]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(viper.Reset)
+			viper.Set(FlagAllow, BumpMajor)
+			viper.Set(tt.key, tt.value)
+
+			cfg, err := FromViper()
+
+			assert.EqualError(t, err, tt.expectedError)
+			assert.Nil(t, cfg)
+		})
+	}
+}
+
+func TestReadToolConfig(t *testing.T) {
+	tests := []struct {
+		name                string
+		content             string
+		flagArgs            []string
+		env                 map[string]string
+		expectedAllow       string
+		expectedGitHubToken string
+		expectedError       string
+		expectedConfigError string
+	}{
+		{
+			name:          "file value applied when no flag is set",
+			content:       "allow: minor\nmax-concurrency: 2\n",
+			expectedAllow: BumpMinor,
+		},
+		{
+			name:          "flag takes precedence over the file",
+			content:       "allow: minor\n",
+			flagArgs:      []string{"--allow", BumpPatch},
+			expectedAllow: BumpPatch,
+		},
+		{
+			name:          "missing file keeps the flag default",
+			expectedAllow: BumpMajor,
+		},
+		{
+			name:          "invalid file",
+			content:       "allow: [minor\n",
+			expectedError: "failed to read .pre-commit-bump configuration file",
+		},
+		{
+			name:                "invalid output in the file",
+			content:             "output: bogus\n",
+			expectedConfigError: `invalid value for output: "bogus"`,
+		},
+		{
+			name:                "invalid max attempts in the file",
+			content:             "max-attempts: 0\n",
+			expectedConfigError: "invalid value for max-attempts: 0",
+		},
+		{
+			name:                "invalid vendor host in the file",
+			content:             "vendor-host:\n  git.example.org: bitbucket\n",
+			expectedConfigError: `invalid vendor for vendor-host git.example.org: "bitbucket"`,
+		},
+		{
+			name:                "invalid allow in the file",
+			content:             "allow: everything\n",
+			expectedConfigError: `invalid value for allow: "everything"`,
+		},
+		{
+			name:                "token in the file is rejected",
+			content:             "github-token: secret\n",
+			expectedConfigError: "github-token can not be set in the .pre-commit-bump configuration file",
+		},
+		{
+			name:                "token from the environment variable named in the file",
+			content:             "github-token-env: CI_GITHUB_TOKEN\n",
+			env:                 map[string]string{"CI_GITHUB_TOKEN": "named-secret", EnvGitHubToken: "default-secret"},
+			expectedAllow:       BumpMajor,
+			expectedGitHubToken: "named-secret",
+		},
+		{
+			name:                "default environment variable when the named one is not set",
+			content:             "github-token-env: CI_GITHUB_TOKEN\n",
+			env:                 map[string]string{EnvGitHubToken: "default-secret"},
+			expectedAllow:       BumpMajor,
+			expectedGitHubToken: "default-secret",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(viper.Reset)
+			dir := t.TempDir()
+			if tt.content != "" {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, ToolConfigName+".yaml"), []byte(tt.content), 0644))
+			}
+
+			flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			flagSet.String(FlagAllow, BumpMajor, "")
+			require.NoError(t, flagSet.Parse(tt.flagArgs))
+			BindFlag(flagSet, FlagAllow)
+			t.Setenv(EnvGitHubToken, "")
+			t.Setenv(EnvGitHubTokenFallback, "")
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+			BindEnv(KeyGitHubToken, EnvGitHubToken, EnvGitHubTokenFallback)
+
+			err := ReadToolConfig(dir)
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			cfg, err := FromViper()
+			if tt.expectedConfigError != "" {
+				assert.ErrorContains(t, err, tt.expectedConfigError, "values from the file should be validated like flags")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedAllow, cfg.Allow)
+			assert.Equal(t, tt.expectedGitHubToken, cfg.GitHubToken)
+		})
+	}
+}
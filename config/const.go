@@ -4,19 +4,171 @@ import "time"
 
 // Flags for the pre-commit bumper tool
 const (
-	FlagConfig    = "config"
-	FlagVerbose   = "verbose"
-	FlagAllow     = "allow"
-	FlagNoSummary = "no-summary"
-	FlagDryRun    = "dry-run"
+	FlagConfig              = "config"
+	FlagVerbose             = "verbose"
+	FlagQuiet               = "quiet"
+	FlagJSONLogsFile        = "json-logs-file"
+	FlagAllow               = "allow"
+	FlagNoSummary           = "no-summary"
+	FlagAlwaysSummary       = "always-summary"
+	FlagSummaryTemplate     = "summary-template"
+	FlagDryRun              = "dry-run"
+	FlagConfigOut           = "config-out"
+	FlagOutput              = "output"
+	FlagReportFile          = "report-file"
+	FlagVerify              = "verify"
+	FlagStableOnly          = "stable-only"
+	FlagVersionScheme       = "version-scheme"
+	FlagTagPrefix           = "tag-prefix"
+	FlagStrictVersions      = "strict-versions"
+	FlagZeroMinorBreaking   = "zero-minor-breaking"
+	FlagVendorHost          = "vendor-host"
+	FlagGitHubHosts         = "github-hosts"
+	FlagGitLabHosts         = "gitlab-hosts"
+	FlagGitLabReleases      = "gitlab-releases"
+	FlagGitHubReleases      = "github-releases"
+	FlagGitHubTagsEndpoint  = "github-tags-endpoint"
+	FlagGitHubAPIURL        = "github-api-url"
+	FlagGitLabAPIURL        = "gitlab-api-url"
+	FlagMaxAttempts         = "max-attempts"
+	FlagCacheDir            = "cache-dir"
+	FlagCacheExpiry         = "cache-expiry"
+	FlagNegativeCacheExpiry = "negative-cache-expiry"
+	FlagHTTPTimeout         = "http-timeout"
+	FlagProxy               = "proxy"
+	FlagUserAgent           = "user-agent"
+	FlagRateLimit           = "rate-limit"
+	FlagMaxConcurrency      = "max-concurrency"
+	FlagIgnore              = "ignore"
+	FlagOnly                = "only"
+	FlagHookID              = "hook-id"
+	FlagBumpDeps            = "bump-deps"
+	FlagBumpNpmDeps         = "bump-npm-deps"
+	FlagLocalVersionPattern = "local-version-pattern"
+	FlagContinueOnError     = "continue-on-error"
+	FlagFailIfEmpty         = "fail-if-empty"
+	FlagStrict              = "strict"
+	FlagDisallowMutableRefs = "disallow-mutable-refs"
+	FlagFreeze              = "freeze"
+	FlagMaxBumps            = "max-bumps"
+	FlagInteractive         = "interactive"
+	FlagGitFallback         = "enable-git-fallback"
+	FlagNoColor             = "no-color"
 )
 
+// Environment variables that can be used instead of flags
+const (
+	EnvGitHubAPIURL = "PCB_GITHUB_API_URL"
+	EnvGitLabAPIURL = "PCB_GITLAB_API_URL"
+	EnvCacheDir     = "PCB_CACHE_DIR"
+	EnvHTTPTimeout  = "PCB_HTTP_TIMEOUT"
+	EnvUserAgent    = "PCB_USER_AGENT"
+)
+
+// EnvNoColor disables colored output when set to a non-empty value, see https://no-color.org
+const EnvNoColor = "NO_COLOR"
+
+// EnvGitHubStepSummary is set by GitHub Actions to a file that is rendered as markdown on the job page
+const EnvGitHubStepSummary = "GITHUB_STEP_SUMMARY"
+
+// Secrets are only read from the environment, the PCB_ prefixed variable takes precedence
+const (
+	KeyGitHubToken         = "github-token"
+	EnvGitHubToken         = "PCB_GITHUB_TOKEN"
+	EnvGitHubTokenFallback = "GITHUB_TOKEN"
+	KeyGitLabToken         = "gitlab-token"
+	EnvGitLabToken         = "PCB_GITLAB_TOKEN"
+	EnvGitLabTokenFallback = "GITLAB_TOKEN"
+)
+
+// Keys of the tool configuration file that name the environment variable a token is read from
+const (
+	KeyGitHubTokenEnv = "github-token-env"
+	KeyGitLabTokenEnv = "gitlab-token-env"
+)
+
+// Bump types supported by the --allow flag and the allow annotation
+const (
+	BumpMajor = "major"
+	BumpMinor = "minor"
+	BumpPatch = "patch"
+	// BumpNone allows no bump at all, available updates are only reported
+	BumpNone = "none"
+)
+
+// AllowValues are the valid values of the --allow flag and the allow annotation
+var AllowValues = []string{BumpMajor, BumpMinor, BumpPatch, BumpNone}
+
+// DefaultConfigFiles are the names of the pre-commit configuration file that are discovered when --config is not set, in order of preference
+var DefaultConfigFiles = []string{DefaultConfigFile, DefaultConfigFileYml}
+
+// Inline annotations that can be added as a comment to a repo in the pre-commit configuration file, e.g. "# pcb:allow=patch"
+const (
+	AnnotationPrefix = "pcb:"
+	AnnotationAllow  = "allow"
+	// AnnotationConstraint caps the versions a repo is bumped to, e.g. "# pcb:constraint=>=1.0, <2.0"
+	AnnotationConstraint = "constraint"
+	// AnnotationTagPrefix only selects tags that start with the prefix, e.g. "# pcb:tag-prefix=release/"
+	AnnotationTagPrefix = "tag-prefix"
+)
+
+// Version schemes supported by the --version-scheme flag
+const (
+	VersionSchemeAuto   = "auto"
+	VersionSchemeSemVer = "semver"
+	VersionSchemeCalVer = "calver"
+)
+
+// VersionSchemeValues are the valid values of the --version-scheme flag
+var VersionSchemeValues = []string{VersionSchemeAuto, VersionSchemeSemVer, VersionSchemeCalVer}
+
+// GitHub API endpoints the tags are listed from, supported by the --github-tags-endpoint flag
+const (
+	// GitHubTagsEndpointRefs lists the tags as git refs from "/repos/{owner}/{repo}/git/refs/tags"
+	GitHubTagsEndpointRefs = "refs"
+	// GitHubTagsEndpointTags lists the tags with the commit they point to from "/repos/{owner}/{repo}/tags"
+	GitHubTagsEndpointTags = "tags"
+)
+
+// GitHubTagsEndpointValues are the valid values of the --github-tags-endpoint flag
+var GitHubTagsEndpointValues = []string{GitHubTagsEndpointRefs, GitHubTagsEndpointTags}
+
+// Output formats supported by the --output flag
+const (
+	FormatText   = "text"
+	FormatJUnit  = "junit"
+	FormatGitHub = "github"
+	FormatJSON   = "json"
+	FormatSARIF  = "sarif"
+)
+
+// OutputValues are the valid values of the --output flag
+var OutputValues = []string{FormatText, FormatJUnit, FormatGitHub, FormatJSON, FormatSARIF}
+
+// StdoutFormats are the output formats that are written to stdout, where update writes the configuration read from stdin
+var StdoutFormats = []string{FormatGitHub, FormatJSON, FormatSARIF}
+
+// VendorHostValues are the vendors that can be assigned to a host with the --vendor-host flag
+var VendorHostValues = []string{VendorGitHub, VendorGitLab, VendorGitea}
+
+// ProxySchemes are the URL schemes supported by the --proxy flag
+var ProxySchemes = []string{"http", "https", "socks5"}
+
+// FlagFormatAlias is the former name of the --output flag, which is still accepted together with its -f shorthand
+const FlagFormatAlias = "format"
+
 // Sentinel values for hooks
 const (
 	SentinelLocal = "local"
 	SentinelMeta  = "meta"
 )
 
+// Reasons a repo in the pre-commit configuration file is not checked for updates
+const (
+	SkipReasonSentinel  = "local or meta hooks"
+	SkipReasonNoVersion = "revision is not a version"
+)
+
 // Supported vendors for pre-commit hooks
 const (
 	VendorGitHub     = "github"
@@ -25,12 +177,90 @@ const (
 	VendorGitLab     = "gitlab"
 	ReGitLabRepoName = `gitlab\.com[:/](?<repo_name>[^?#\n\s/]+(?:/[^?#\n\s/.]+)*)`
 	VendorGitLabHost = "gitlab.com"
+	VendorGitea      = "gitea"
+	// VendorGit is the fallback for repositories on unknown hosts, their tags are listed with git ls-remote
+	VendorGit       = "git"
+	VendorGiteaHost = "gitea.com"
+	// ReRepoHost matches the host (and optional port) of a repository URL in HTTPS, SSH or scp-like form
+	ReRepoHost = `^(?:[a-z+]+://)?(?:[^@/]+@)?(?<host>[^/:?#\s]+(?::\d+)?)`
+	// ReRepoURL matches the host (without port) and the path of a repository URL in HTTPS, SSH, git+ or scp-like form
+	ReRepoURL = `^(?:git\+)?(?:[A-Za-z+]+://)?(?:[^@/]+@)?(?<host>[^/:?#\s]+)(?::\d+)?[:/](?<path>[^?#\s]*)`
+	// ReHostedRepoName matches the host and the owner and repository name of a repository URL on any host
+	ReHostedRepoName = ReRepoHost + `[:/](?<repo_name>[^/?#\s]+/[^/?#\s.]+)`
+	// DefaultGitHubAPIURL is the base URL of the public GitHub API
+	DefaultGitHubAPIURL = "https://api." + VendorGitHubHost
+	// DefaultGitLabAPIURL is the base URL of the public GitLab API
+	DefaultGitLabAPIURL = "https://" + VendorGitLabHost + "/api/v4"
 )
 
 // Regex patterns and other constants used within the pre-commit bumper tool
 const (
 	// ReSemanticVersion is a regex pattern for validating semantic versioning
 	// Regex is used from https://semver.org/, added support for leading or trailing characters like 'v' or 'V'
-	ReSemanticVersion  = `(?P<major>0|[1-9]\d*)\.(?P<minor>0|[1-9]\d*)\.(?P<patch>0|[1-9]\d*)(?:-(?P<prerelease>(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+(?P<buildmetadata>[0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?`
+	ReSemanticVersion = `(?P<major>0|[1-9]\d*)\.(?P<minor>0|[1-9]\d*)\.(?P<patch>0|[1-9]\d*)(?:-(?P<prerelease>(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+(?P<buildmetadata>[0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?`
+	// DefaultHTTPTimeout is the default timeout of a single API request, including reading the response body
 	DefaultHTTPTimeout = 30 * time.Second
+	// DefaultConfigFile is the name of the pre-commit configuration file that is used when --config is not set
+	DefaultConfigFile = ".pre-commit-config.yaml"
+	// DefaultConfigFileYml is the alternative name of the pre-commit configuration file with the ".yml" extension
+	DefaultConfigFileYml = ".pre-commit-config.yml"
+	// ToolConfigName is the name of the pre-commit-bump configuration file without extension, e.g. ".pre-commit-bump.yaml"
+	ToolConfigName = ".pre-commit-bump"
+	// StdinPath is the --config value that reads the pre-commit configuration from stdin and writes updates to stdout
+	StdinPath = "-"
+	// ExitCodeUpToDate is the exit code of the check command when all hooks are up-to-date
+	ExitCodeUpToDate = 0
+	// ExitCodeUpdatesAvailable is the exit code of the check command when updates are available
+	ExitCodeUpdatesAvailable = 1
+	// ExitCodeError is the exit code of the check command when the check itself failed, e.g. due to an API error
+	ExitCodeError = 2
+	// ExitCodePartialUpdate is the exit code of the update command when the successful updates were written
+	// but some repositories failed to be checked, only used with --continue-on-error
+	ExitCodePartialUpdate = 3
+	// ExitCodeNothingToCheck is the exit code of the check command when no repository could be checked,
+	// only used with --fail-if-empty
+	ExitCodeNothingToCheck = 4
+	// DefaultMaxConcurrency is the default number of repositories that are checked concurrently
+	DefaultMaxConcurrency = 8
+	// DefaultMaxAttempts is the default number of attempts for API requests that fail transiently
+	DefaultMaxAttempts = 3
+	// DefaultRetryBaseDelay is the delay before the first retry of a failed API request
+	DefaultRetryBaseDelay = 500 * time.Millisecond
+	// DefaultRetryMaxDelay caps the delay between retries of a failed API request
+	DefaultRetryMaxDelay = 30 * time.Second
+	// DefaultCacheExpiry is the default age after which cached API responses are no longer used
+	DefaultCacheExpiry = 24 * time.Hour
+	// DefaultNegativeCacheExpiry is the default age after which repositories without version tags are fetched again
+	DefaultNegativeCacheExpiry = time.Hour
+	// TagsPerPage is the number of tags requested per page from paginated APIs
+	TagsPerPage = 100
+	// MaxTagPages caps the number of pages followed when fetching tags to avoid runaway pagination
+	MaxTagPages = 50
+	// ReLinkNext matches the URL of the "next" relation in an RFC 8288 Link header
+	ReLinkNext = `<(?P<url>[^>]+)>\s*;\s*rel="next"`
+	// DependencySourcePyPI is the name of the package index the additional_dependencies of hooks are resolved from
+	DependencySourcePyPI = "pypi"
+	// DefaultPyPIURL is the base URL of the PyPI JSON API used to resolve additional_dependencies
+	DefaultPyPIURL = "https://pypi.org/pypi"
+	// DependencySourceNpm is the name of the package registry the additional_dependencies of node hooks are resolved from
+	DependencySourceNpm = "npm"
+	// DefaultNpmRegistryURL is the base URL of the npm registry used to resolve additional_dependencies of node hooks
+	DefaultNpmRegistryURL = "https://registry.npmjs.org"
+	// RePinnedDependency matches a dependency pinned to an exact version like "flake8-bugbear==22.1.11" or "black[jupyter]==24.1.0"
+	RePinnedDependency = `^(?P<name>[A-Za-z0-9][A-Za-z0-9._-]*)(?:\[[^\]]*\])?\s*==\s*(?P<version>[^\s;]+)\s*(?:;.*)?$`
+	// RePinnedNpmDependency matches an npm package pinned to an exact version like "eslint@8.56.0" or "@types/node@20.11.5"
+	RePinnedNpmDependency = `^(?P<name>(?:@[a-z0-9~][a-z0-9._~-]*/)?[a-z0-9~][a-z0-9._~-]*)@(?P<version>\S+)$`
+	// ReReposKey matches the repos key of a pre-commit configuration, in block style YAML as well as in flow style YAML or JSON
+	ReReposKey = `(?:^|[\s{,])["']?repos["']?\s*:`
+	// ReFrozenComment matches the "frozen: <tag>" comment that pre-commit autoupdate --freeze adds to revisions pinned to a commit SHA
+	ReFrozenComment = `(?:^|\s)frozen:\s*(?P<tag>\S+)`
+	// ReCommitSHA matches a full 40 character commit SHA, which unlike a branch name always points to the same commit
+	ReCommitSHA = `^[0-9a-fA-F]{40}$`
+	// ReConstraintClause matches a single comparison of a version constraint like ">=1.0" or "<2.0.0", the operator defaults to "=="
+	ReConstraintClause = `^(?P<operator>>=|<=|!=|==|=|>|<)?\s*v?(?P<version>\S+)$`
+	// ReReleaseVersion matches a final release version of up to three numeric segments like "6.0" or "22.1.11"
+	ReReleaseVersion = `^(?P<major>\d+)(?:\.(?P<minor>\d+))?(?:\.(?P<patch>\d+))?$`
+	// ReCalendarVersion is a regex pattern for calendar versioning like YYYY.MM.PATCH or YY.MINOR.MICRO
+	// Unlike ReSemanticVersion it accepts zero-padded segments, the patch segment is optional
+	ReCalendarVersion = `(?:^|[^0-9])(?P<version>(?P<major>\d{2,4})\.(?P<minor>\d{1,2})(?:\.(?P<patch>\d+))?(?:-(?P<prerelease>[0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?)`
 )
@@ -1,21 +1,52 @@
 package bumper
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
 	"sync"
 
 	"github.com/ramonvermeulen/pre-commit-bump/core/types"
+	"github.com/ramonvermeulen/pre-commit-bump/core/utils"
 
 	"github.com/ramonvermeulen/pre-commit-bump/config"
 	"github.com/ramonvermeulen/pre-commit-bump/core/io"
 	"github.com/ramonvermeulen/pre-commit-bump/core/parser"
+
+	"go.uber.org/zap"
 )
 
+// ErrUpdatesAvailable is returned by Check when updates are available for any of the hooks, wrapped in an
+// UpdatesAvailableError that carries the results.
+var ErrUpdatesAvailable = errors.New("updates are available")
+
+// ErrPartialUpdate is returned by Update with --continue-on-error when the successful updates were written,
+// but some repositories failed to be checked.
+var ErrPartialUpdate = errors.New("some repositories failed to be checked")
+
+// ErrMutableRef is reported with --disallow-mutable-refs for repositories pinned to a ref that can move, like a branch or "HEAD".
+var ErrMutableRef = errors.New("pinned to a mutable ref, pin it to a tag or a full commit SHA instead")
+
+// ErrNothingToCheck is returned by Check when every repository is skipped or ignored, e.g. a configuration with only
+// local and meta hooks, so "nothing to check" can be told apart from "everything up-to-date".
+var ErrNothingToCheck = errors.New("nothing to check, all repositories are local, meta, ignored or not pinned to a version")
+
 // RepoBumper defines the interface for updating repositories.
 // To support different repository types, implement this interface (e.g., GitHub, GitLab).
 type RepoBumper interface {
-	GetLatestVersion(repo *types.Repo) (*types.SemanticVersion, error)
+	GetVersions(ctx context.Context, repo *types.Repo) ([]*types.SemanticVersion, error)
+}
+
+// Approver decides whether an available update is applied, e.g. by prompting the user.
+// Updates that are not approved are marked as declined and not written.
+type Approver interface {
+	Approve(result types.UpdateResult) (bool, error)
 }
 
 // TagProvider defines an interface for types that can provide a tag name.
@@ -26,75 +57,496 @@ type TagProvider interface {
 
 // Bumper coordinates the pre-commit hook bumping process.
 type Bumper struct {
-	parser     *parser.Parser
-	cfg        *config.Config
-	fileWriter *io.ResultWriter
-	httpClient *http.Client
+	parser      *parser.Parser
+	cfg         *config.Config
+	fileWriter  *io.ResultWriter
+	httpClient  *http.Client
+	verifier    io.ConfigVerifier
+	cache       *versionCache
+	etags       *io.ETagCache
+	negatives   *io.NegativeCache
+	repoBumpers map[string]RepoBumper
+	bumperHosts map[string]string
+	approver    Approver
 }
 
 // NewBumper creates a new Bumper instance with dependency injection
 func NewBumper(parser *parser.Parser, cfg *config.Config, fileWriter *io.ResultWriter, httpClient *http.Client) *Bumper {
+	var etags *io.ETagCache
+	var negatives *io.NegativeCache
+	if cfg.CacheDir != "" {
+		etags = io.NewETagCache(io.NewOSFileSystem(), cfg.CacheDir, cfg.CacheExpiry)
+		if cfg.NegativeCacheExpiry > 0 {
+			negatives = io.NewNegativeCache(io.NewOSFileSystem(), cfg.CacheDir, cfg.NegativeCacheExpiry)
+		}
+	}
+
 	return &Bumper{
-		parser:     parser,
-		cfg:        cfg,
-		fileWriter: fileWriter,
-		httpClient: httpClient,
+		parser:      parser,
+		cfg:         cfg,
+		fileWriter:  fileWriter,
+		httpClient:  httpClient,
+		verifier:    io.NewPreCommitCLI(),
+		cache:       newVersionCache(),
+		etags:       etags,
+		negatives:   negatives,
+		repoBumpers: map[string]RepoBumper{},
+		bumperHosts: map[string]string{},
+	}
+}
+
+// RegisterRepoBumper registers the RepoBumper that checks the repositories of the vendor, e.g. for an in-house git host.
+// It takes precedence over the built-in RepoBumper of a vendor with the same name. Repositories on the given hosts are
+// mapped to the vendor, in the same way as with --vendor-host, which takes precedence for hosts configured in both.
+func (b *Bumper) RegisterRepoBumper(vendor string, repoBumper RepoBumper, hosts ...string) {
+	b.repoBumpers[vendor] = repoBumper
+	for _, host := range hosts {
+		b.bumperHosts[host] = vendor
+	}
+}
+
+// SetApprover sets the Approver that is asked for every available update before the update command writes it.
+// Without an Approver every available update is written.
+func (b *Bumper) SetApprover(approver Approver) {
+	b.approver = approver
+}
+
+// SetSummaryTemplate loads the Go text/template at templatePath, which the summary is rendered with instead of the default format.
+func (b *Bumper) SetSummaryTemplate(templatePath string) error {
+	return b.fileWriter.LoadSummaryTemplate(templatePath)
+}
+
+// configPaths expands the configured paths and globs into the pre-commit configuration files to process.
+// Globs are expanded in lexical order, and files matched more than once are only processed once.
+// It returns an error if a glob matches no files.
+func (b *Bumper) configPaths() ([]string, error) {
+	var paths []string
+	seen := map[string]bool{}
+
+	for _, pattern := range b.cfg.PreCommitConfigPaths {
+		matches := []string{pattern}
+		if pattern != config.StdinPath && config.IsGlob(pattern) {
+			var err error
+			matches, err = filepath.Glob(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid config glob %s: %w", pattern, err)
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("no pre-commit configuration files match %s", pattern)
+			}
+		}
+
+		for _, match := range matches {
+			if match != config.StdinPath {
+				match = filepath.Clean(match)
+			}
+			if seen[match] {
+				continue
+			}
+			seen[match] = true
+			paths = append(paths, match)
+		}
+	}
+
+	return paths, nil
+}
+
+// ParsedConfig is a parsed pre-commit configuration file together with the path it was read from.
+type ParsedConfig struct {
+	Path   string
+	Config *types.PreCommitConfig
+}
+
+// ParseConfigs parses every pre-commit configuration file, with the configured vendor hosts and version scheme applied.
+// It does not check for updates, so it can be used to inspect how the configuration files are interpreted.
+func (b *Bumper) ParseConfigs() ([]ParsedConfig, error) {
+	paths, err := b.configPaths()
+	if err != nil {
+		return nil, err
+	}
+
+	parsedConfigs := make([]ParsedConfig, 0, len(paths))
+	for _, path := range paths {
+		pCfg, err := b.parsePreCommitConfig(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse pre-commit configuration %s: %w", path, err)
+		}
+		parsedConfigs = append(parsedConfigs, ParsedConfig{Path: path, Config: pCfg})
+	}
+
+	return parsedConfigs, nil
+}
+
+// CheckRepos checks every pre-commit configuration file for updates and returns the results of all files.
+// Each result records the configuration file it belongs to. The results are returned as is, nothing is logged, written
+// or reported, so programs embedding pre-commit-bump can render them however they like. Failures to check a single
+// repository are recorded on its result, the error is only set when the configuration files could not be read or the
+// context is cancelled, outstanding lookups are then aborted and no results are returned.
+func (b *Bumper) CheckRepos(ctx context.Context) ([]types.UpdateResult, error) {
+	parsedConfigs, err := b.ParseConfigs()
+	if err != nil {
+		return nil, err
+	}
+
+	localPatterns, err := b.localVersionPatterns()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []types.UpdateResult
+	for _, parsed := range parsedConfigs {
+		configResults := b.checkReposForUpdates(ctx, parsed.Config.Repos)
+		if b.cfg.BumpDeps || b.cfg.BumpNpmDeps || len(localPatterns) > 0 {
+			configResults = append(configResults, b.checkDependenciesForUpdates(ctx, parsed.Config.Repos, localPatterns)...)
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("checking for updates was aborted: %w", err)
+		}
+
+		for i := range configResults {
+			configResults[i].ConfigPath = parsed.Path
+		}
+		results = append(results, configResults...)
 	}
+
+	return results, nil
 }
 
 // parsePreCommitConfig parses the pre-commit configuration file and logs the action.
-func (b *Bumper) parsePreCommitConfig() (*types.PreCommitConfig, error) {
-	b.cfg.Logger.Sugar().Debugf("Parsing configuration file: %s", b.cfg.PreCommitConfigPath)
+func (b *Bumper) parsePreCommitConfig(path string) (*types.PreCommitConfig, error) {
+	b.cfg.Logger.Sugar().Debugf("Parsing configuration file: %s", path)
 
-	pCfg, err := b.parser.ParseConfig(b.cfg.PreCommitConfigPath)
+	pCfg, err := b.parser.ParseConfig(path)
 	if err != nil {
 		return nil, err
 	}
 
+	if vendorHosts := b.vendorHosts(); len(vendorHosts) > 0 {
+		pCfg.SetVendorHosts(vendorHosts)
+	}
+
+	if b.cfg.VersionScheme != "" && b.cfg.VersionScheme != config.VersionSchemeAuto {
+		pCfg.SetVersionScheme(types.VersionScheme(b.cfg.VersionScheme))
+	}
+
+	if b.cfg.TagPrefix != "" {
+		pCfg.SetTagPrefix(b.cfg.TagPrefix)
+	}
+
+	if b.cfg.StrictVersions {
+		pCfg.SetStrictVersions()
+	}
+
 	return pCfg, nil
 }
 
-// Check verifies if the pre-commit configuration file is valid and up-to-date.
+// vendorHosts returns the configured host to vendor mapping, including the hosts of registered RepoBumpers.
+// The --github-hosts and --gitlab-hosts lists are mapped to their vendor, an explicit --vendor-host mapping takes precedence.
+// When a custom GitHub or GitLab API URL is configured, its host is mapped to the vendor so GitHub Enterprise and
+// self-hosted GitLab repos are recognized.
+func (b *Bumper) vendorHosts() map[string]string {
+	vendorHosts := make(map[string]string, len(b.bumperHosts)+len(b.cfg.GitHubHosts)+len(b.cfg.GitLabHosts)+len(b.cfg.VendorHosts)+2)
+	for host, vendor := range b.bumperHosts {
+		vendorHosts[host] = vendor
+	}
+	for _, host := range b.cfg.GitHubHosts {
+		vendorHosts[host] = config.VendorGitHub
+	}
+	for _, host := range b.cfg.GitLabHosts {
+		vendorHosts[host] = config.VendorGitLab
+	}
+	for host, vendor := range b.cfg.VendorHosts {
+		vendorHosts[host] = vendor
+	}
+
+	customAPIURLs := []struct {
+		apiURL, defaultURL, vendor string
+	}{
+		{apiURL: b.cfg.GitHubAPIURL, defaultURL: config.DefaultGitHubAPIURL, vendor: config.VendorGitHub},
+		{apiURL: b.cfg.GitLabAPIURL, defaultURL: config.DefaultGitLabAPIURL, vendor: config.VendorGitLab},
+	}
+	for _, custom := range customAPIURLs {
+		if custom.apiURL == "" || custom.apiURL == custom.defaultURL {
+			continue
+		}
+		if apiURL, err := url.Parse(custom.apiURL); err == nil && apiURL.Host != "" {
+			if _, ok := vendorHosts[apiURL.Host]; !ok {
+				vendorHosts[apiURL.Host] = custom.vendor
+			}
+		}
+	}
+
+	return vendorHosts
+}
+
+// Check verifies if the pre-commit configuration files are valid and up-to-date.
 // If the configuration is valid, it returns nil.
-// If there are updates available, it returns an error.
-func (b *Bumper) Check() error {
-	pCfg, err := b.parsePreCommitConfig()
+// If there are updates available, it returns ErrUpdatesAvailable, any other error means the check itself failed.
+func (b *Bumper) Check(ctx context.Context) error {
+	results, err := b.CheckRepos(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to parse pre-commit configuration: %w", err)
+		return err
 	}
 
-	results := b.checkReposForUpdates(pCfg.ValidRepos())
+	if err := b.writeReport(results); err != nil {
+		return err
+	}
 
 	return b.processCheckResults(results)
 }
 
-// Update checks for available updates and modifies the pre-commit configuration file.
-func (b *Bumper) Update() error {
-	pCfg, err := b.parsePreCommitConfig()
+// Update checks for available updates and modifies the pre-commit configuration files.
+func (b *Bumper) Update(ctx context.Context) error {
+	results, err := b.CheckRepos(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to parse pre-commit configuration: %w", err)
+		return err
 	}
 
-	results := b.checkReposForUpdates(pCfg.ValidRepos())
+	if err := b.writeReport(results); err != nil {
+		return err
+	}
 
 	return b.processUpdateResults(results)
 }
 
 // checkReposForUpdates iterates through the repositories in the pre-commit configuration
 // and checks for updates using the appropriate RepoBumper based on the vendor.
-// it uses a goroutine for each repository to perform the check concurrently.
-func (b *Bumper) checkReposForUpdates(repos []types.Repo) []types.UpdateResult {
+func (b *Bumper) checkReposForUpdates(ctx context.Context, repos []types.Repo) []types.UpdateResult {
+	retry := NewRetryPolicy(b.cfg.MaxAttempts)
 	repositoryUpdaters := map[string]RepoBumper{
-		config.VendorGitHub: NewGithubBumper(b.httpClient),
-		config.VendorGitLab: NewGitLabBumper(b.httpClient),
+		config.VendorGitHub: NewGithubBumper(b.cfg.Logger, b.httpClient, retry, GithubOptions{
+			APIURL:       b.cfg.GitHubAPIURL,
+			Hosts:        b.configuredHosts(config.VendorGitHub),
+			Token:        b.cfg.GitHubToken,
+			ETags:        b.etags,
+			Releases:     b.cfg.GitHubReleases,
+			TagsEndpoint: b.cfg.GitHubTagsEndpoint,
+		}),
+		config.VendorGitLab: NewGitLabBumper(b.cfg.Logger, b.httpClient, retry, GitLabOptions{
+			APIURL:   b.cfg.GitLabAPIURL,
+			Hosts:    b.configuredHosts(config.VendorGitLab),
+			Token:    b.cfg.GitLabToken,
+			ETags:    b.etags,
+			Releases: b.cfg.GitLabReleases,
+		}),
+		config.VendorGitea: NewGiteaBumper(b.cfg.Logger, b.httpClient, retry, b.etags),
+	}
+	if b.cfg.GitFallback {
+		repositoryUpdaters[config.VendorGit] = NewGitBumper()
+	}
+	for vendor, repoBumper := range b.repoBumpers {
+		repositoryUpdaters[vendor] = repoBumper
+	}
+
+	return b.checkReposWithUpdaters(ctx, repos, repositoryUpdaters)
+}
+
+// localVersionPatterns parses the --local-version-pattern values.
+func (b *Bumper) localVersionPatterns() ([]*types.LocalVersionPattern, error) {
+	patterns := make([]*types.LocalVersionPattern, 0, len(b.cfg.LocalVersionPatterns))
+	for _, value := range b.cfg.LocalVersionPatterns {
+		pattern, err := types.ParseLocalVersionPattern(value)
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
+
+// checkDependenciesForUpdates checks the pinned additional_dependencies of the hooks for updates.
+// Python packages are checked on PyPI with --bump-deps, node packages are checked on the npm registry with --bump-npm-deps.
+// Versions pinned in the entry or args of local hooks are checked when they match one of the local patterns, on the
+// registry of the pattern.
+func (b *Bumper) checkDependenciesForUpdates(ctx context.Context, repos []types.Repo, localPatterns []*types.LocalVersionPattern) []types.UpdateResult {
+	retry := NewRetryPolicy(b.cfg.MaxAttempts)
+	resolvers := map[string]DependencyResolver{}
+	if b.cfg.BumpDeps {
+		resolvers[config.DependencySourcePyPI] = NewPyPIClient(b.httpClient, retry)
+	}
+	if b.cfg.BumpNpmDeps {
+		resolvers[config.DependencySourceNpm] = NewNpmClient(b.httpClient, retry)
+	}
+
+	localResolvers := map[string]DependencyResolver{}
+	for _, pattern := range localPatterns {
+		switch pattern.Source {
+		case config.DependencySourcePyPI:
+			localResolvers[pattern.Source] = NewPyPIClient(b.httpClient, retry)
+		case config.DependencySourceNpm:
+			localResolvers[pattern.Source] = NewNpmClient(b.httpClient, retry)
+		}
+	}
+
+	results := b.checkDependenciesWithResolvers(ctx, repos, resolvers)
+	return append(results, b.checkLocalVersionsWithResolvers(ctx, repos, localPatterns, localResolvers)...)
+}
+
+// checkDependenciesWithResolvers checks the pinned additional_dependencies of the hooks of all repositories, local hooks included,
+// with the DependencyResolver of their source. Dependencies of a source without a resolver are not checked, neither are
+// dependencies of repositories excluded by the --only and --ignore filters.
+func (b *Bumper) checkDependenciesWithResolvers(ctx context.Context, repos []types.Repo, resolvers map[string]DependencyResolver) []types.UpdateResult {
+	var updateResults []types.UpdateResult
+	for _, currentRepo := range repos {
+		if b.isSkipped(currentRepo) {
+			continue
+		}
+		for _, dependency := range currentRepo.PinnedDependencies() {
+			if _, ok := resolvers[dependency.Source]; !ok {
+				continue
+			}
+			updateResults = append(updateResults, types.UpdateResult{
+				Repo:       currentRepo,
+				Dependency: dependency,
+			})
+		}
+	}
+
+	return b.checkDependencies(ctx, updateResults, resolvers)
+}
+
+// checkLocalVersionsWithResolvers checks the versions pinned in the entry or args of local hooks that match one of the
+// patterns, with the DependencyResolver of the registry of the pattern. Local repositories excluded by the --only and
+// --ignore filters are not checked.
+func (b *Bumper) checkLocalVersionsWithResolvers(ctx context.Context, repos []types.Repo, patterns []*types.LocalVersionPattern, resolvers map[string]DependencyResolver) []types.UpdateResult {
+	var updateResults []types.UpdateResult
+	for _, currentRepo := range repos {
+		if b.isSkipped(currentRepo) {
+			continue
+		}
+		for _, dependency := range currentRepo.LocalPinnedVersions(patterns) {
+			if _, ok := resolvers[dependency.Source]; !ok {
+				continue
+			}
+			updateResults = append(updateResults, types.UpdateResult{
+				Repo:       currentRepo,
+				Dependency: dependency,
+			})
+		}
+	}
+
+	return b.checkDependencies(ctx, updateResults, resolvers)
+}
+
+// checkDependencies checks the dependencies of the results concurrently, bounded by the configured max concurrency.
+// The results keep their order.
+func (b *Bumper) checkDependencies(ctx context.Context, updateResults []types.UpdateResult, resolvers map[string]DependencyResolver) []types.UpdateResult {
+	semaphore := make(chan struct{}, max(b.cfg.MaxConcurrency, 1))
+	var waitGroup sync.WaitGroup
+
+	for resultIndex := range updateResults {
+		waitGroup.Add(1)
+		go func(result *types.UpdateResult) {
+			defer waitGroup.Done()
+
+			if !acquire(ctx, semaphore) {
+				result.Error = ctx.Err()
+				return
+			}
+			defer func() { <-semaphore }()
+
+			*result = b.checkSingleDependency(ctx, result.Repo, result.Dependency, resolvers[result.Dependency.Source])
+		}(&updateResults[resultIndex])
+	}
+
+	waitGroup.Wait()
+
+	return updateResults
+}
+
+// checkSingleDependency checks a single pinned dependency of a hook for updates.
+// The versions are cached by package name, so dependencies shared between hooks are only fetched once per run.
+// The resolver is queried with the normalized package name, so the cached versions do not depend on which spelling
+// of a shared package is checked first.
+func (b *Bumper) checkSingleDependency(ctx context.Context, repo types.Repo, dependency *types.Dependency, resolver DependencyResolver) types.UpdateResult {
+	b.cfg.Logger.Sugar().Debugf("Checking dependency %s of hook %s, current version: %s", dependency.Name, dependency.HookID, dependency.Version)
+
+	versions, err := b.cache.getDependencyVersions(dependency, func() ([]*types.SemanticVersion, error) {
+		return resolver.GetVersions(ctx, normalizedPackageName(dependency))
+	})
+	if err != nil {
+		return types.UpdateResult{
+			Repo:       repo,
+			Dependency: dependency,
+			Error:      fmt.Errorf("failed to get latest version for %s: %w", dependency.Name, err),
+		}
 	}
 
+	latestVersion := findLatestVersion(versions, b.cfg.StableOnly)
+	if latestVersion == nil {
+		return types.UpdateResult{
+			Repo:       repo,
+			Dependency: dependency,
+		}
+	}
+
+	candidates := sortCandidates(versions, b.cfg.StableOnly)
+	allowedVersion := findAllowedVersion(candidates, dependency.SemVer, b.allowFor(b.cfg.Allow, dependency.SemVer))
+
+	return types.UpdateResult{
+		Repo:           repo,
+		Dependency:     dependency,
+		LatestVersion:  latestVersion,
+		AllowedVersion: allowedVersion,
+		Candidates:     candidates,
+		UpdateRequired: allowedVersion != nil,
+	}
+}
+
+// checkReposWithUpdaters checks the repositories for updates with the RepoBumper registered for their vendor.
+// Repositories of an unknown vendor fall back to the git RepoBumper when it is registered.
+// Repositories excluded by the --only and --ignore filters are not checked but reported as ignored, and repositories
+// that cannot be checked, like local hooks or revisions that are not a version, are reported with the reason they are skipped.
+// it uses a goroutine for each repository to perform the check concurrently, bounded by the configured max concurrency.
+// The results are in the same order as the repositories. A repository that is listed more than once, e.g. with different
+// hook sets, is only checked for its first occurrence, the other occurrences get a copy of that result marked as Duplicate.
+func (b *Bumper) checkReposWithUpdaters(ctx context.Context, repos []types.Repo, repositoryUpdaters map[string]RepoBumper) []types.UpdateResult {
 	updateResults := make([]types.UpdateResult, len(repos))
+	semaphore := make(chan struct{}, max(b.cfg.MaxConcurrency, 1))
 	var waitGroup sync.WaitGroup
 
+	firstOccurrence := map[string]int{}
+	duplicateOf := map[int]int{}
+
 	for repoIndex, currentRepo := range repos {
+		key := repoCheckKey(&currentRepo)
+		if first, ok := firstOccurrence[key]; ok {
+			b.cfg.Logger.Sugar().Debugf("Repo %s is listed more than once, reusing the result of its first occurrence", currentRepo.Repo)
+			duplicateOf[repoIndex] = first
+			continue
+		}
+		firstOccurrence[key] = repoIndex
+
+		if b.cfg.DisallowMutableRefs && currentRepo.IsMutableRef() && !b.isSkipped(currentRepo) {
+			updateResults[repoIndex] = types.UpdateResult{
+				Repo:  currentRepo,
+				Error: fmt.Errorf("%s is at %s: %w", currentRepo.Repo, currentRepo.Rev, ErrMutableRef),
+			}
+			continue
+		}
+
+		if reason := currentRepo.SkipReason(); reason != "" {
+			updateResults[repoIndex] = types.UpdateResult{
+				Repo:       currentRepo,
+				SkipReason: reason,
+			}
+			continue
+		}
+
+		if b.isSkipped(currentRepo) {
+			b.cfg.Logger.Sugar().Debugf("Ignoring repo: %s", currentRepo.Repo)
+			updateResults[repoIndex] = types.UpdateResult{
+				Repo:    currentRepo,
+				Ignored: true,
+			}
+			continue
+		}
+
 		vendor := currentRepo.GetVendor()
 		updater, vendorSupported := repositoryUpdaters[vendor]
+		if !vendorSupported {
+			updater, vendorSupported = repositoryUpdaters[config.VendorGit]
+		}
 
 		if !vendorSupported {
 			b.cfg.Logger.Sugar().Warnf("No updater found for vendor: %s, skipping repo: %s", vendor, currentRepo.Repo)
@@ -106,26 +558,74 @@ func (b *Bumper) checkReposForUpdates(repos []types.Repo) []types.UpdateResult {
 		}
 
 		waitGroup.Add(1)
-		go b.checkRepoAsync(&waitGroup, updateResults, repoIndex, currentRepo, updater)
+		go b.checkRepoAsync(ctx, &waitGroup, semaphore, updateResults, repoIndex, currentRepo, updater)
 	}
 
 	waitGroup.Wait()
 
+	for repoIndex, first := range duplicateOf {
+		duplicate := updateResults[first]
+		duplicate.Repo = repos[repoIndex]
+		duplicate.Duplicate = true
+		updateResults[repoIndex] = duplicate
+	}
+
 	return updateResults
 }
 
+// isSkipped reports whether the repository is excluded by the --only, --hook-id and --ignore filters.
+// When --only is set only matching repositories are selected, --ignore then filters within that selection.
+func (b *Bumper) isSkipped(repo types.Repo) bool {
+	if len(b.cfg.Only) > 0 && !repo.MatchesAny(b.cfg.Only) {
+		return true
+	}
+	if len(b.cfg.HookIDs) > 0 && !repo.ProvidesHook(b.cfg.HookIDs) {
+		return true
+	}
+	return repo.MatchesAny(b.cfg.Ignore)
+}
+
 // checkRepoAsync checks a single repository for updates and is intended to be called concurrently as a goroutine.
-func (b *Bumper) checkRepoAsync(waitGroup *sync.WaitGroup, results []types.UpdateResult, index int, repo types.Repo, updater RepoBumper) {
+// It holds a slot of the semaphore while checking, which limits the number of in-flight checks.
+// Once the context is cancelled, repositories still waiting for a slot are not checked but get the context error.
+func (b *Bumper) checkRepoAsync(ctx context.Context, waitGroup *sync.WaitGroup, semaphore chan struct{}, results []types.UpdateResult, index int, repo types.Repo, updater RepoBumper) {
 	defer waitGroup.Done()
-	results[index] = b.checkSingleRepo(repo, updater)
+
+	if !acquire(ctx, semaphore) {
+		results[index] = types.UpdateResult{Repo: repo, Error: ctx.Err()}
+		return
+	}
+	defer func() { <-semaphore }()
+
+	results[index] = b.checkSingleRepo(ctx, repo, updater)
+}
+
+// acquire takes a slot of the semaphore, it returns false without a slot when the context is cancelled first.
+func acquire(ctx context.Context, semaphore chan struct{}) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	select {
+	case semaphore <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
 }
 
 // checkSingleRepo checks a single repository for updates.
-// It retrieves the latest version using the provided RepoBumper and compares it with the current version.
-func (b *Bumper) checkSingleRepo(repo types.Repo, updater RepoBumper) types.UpdateResult {
+// It retrieves the available versions using the provided RepoBumper and selects the highest version reachable under the
+// allowed bump type, so a newer patch release is still picked up when the latest version is a major bump.
+// Repositories on a stable release are only bumped to stable releases, the newest stable and pre-release versions are
+// reported on the result regardless.
+// The versions are cached, so repositories that appear multiple times are only fetched once per run.
+func (b *Bumper) checkSingleRepo(ctx context.Context, repo types.Repo, updater RepoBumper) types.UpdateResult {
 	b.cfg.Logger.Sugar().Debugf("Checking repo: %s, current version: %s", repo.Repo, repo.Rev)
 
-	latestVersion, err := updater.GetLatestVersion(&repo)
+	versions, err := b.cache.getVersions(&repo, func() ([]*types.SemanticVersion, error) {
+		return b.fetchVersions(ctx, &repo, updater)
+	})
 	if err != nil {
 		return types.UpdateResult{
 			Repo:  repo,
@@ -133,48 +633,151 @@ func (b *Bumper) checkSingleRepo(repo types.Repo, updater RepoBumper) types.Upda
 		}
 	}
 
-	updateRequired := latestVersion.IsAllowedBumpFrom(repo.SemVer, b.cfg.Allow)
+	unpublished := repo.SemVer != nil && !containsVersion(versions, repo.SemVer)
+	if unpublished {
+		b.cfg.Logger.Sugar().Debugf("Pinned version %s of %s is not among its %d published versions", repo.SemVer.String(), repo.Repo, len(versions))
+	}
+
+	if repo.Constraint != "" {
+		constraint, err := types.ParseConstraint(repo.Constraint)
+		if err != nil {
+			return types.UpdateResult{
+				Repo:  repo,
+				Error: fmt.Errorf("invalid constraint for %s: %w", repo.Repo, err),
+			}
+		}
+		b.cfg.Logger.Sugar().Debugf("Using constraint %s for %s", repo.Constraint, repo.Repo)
+		versions = constraint.Filter(versions)
+	}
+
+	latestStable := findLatestVersion(versions, true)
+	var latestPrerelease *types.SemanticVersion
+	if latest := findLatestVersion(versions, false); latest != nil && latest.PreRelease != "" {
+		latestPrerelease = latest
+	}
+
+	// pre-releases are only bump targets for repositories that are already on a pre-release, unless --stable-only is set
+	stableOnly := b.cfg.StableOnly || repo.SemVer.PreRelease == ""
+	latestVersion := preferredBuild(versions, findLatestVersion(versions, stableOnly), repo.SemVer)
+	if latestVersion == nil {
+		b.cfg.Logger.Sugar().Debugf("No version found for %s that is stable and satisfies its constraint", repo.Repo)
+		return types.UpdateResult{
+			Repo:             repo,
+			LatestPrerelease: latestPrerelease,
+			Unpublished:      unpublished,
+		}
+	}
+
+	allow := b.cfg.Allow
+	if repo.AllowOverride != "" {
+		b.cfg.Logger.Sugar().Debugf("Using allow override %s for %s instead of %s", repo.AllowOverride, repo.Repo, b.cfg.Allow)
+		allow = repo.AllowOverride
+	}
+
+	allow = b.allowFor(allow, repo.SemVer)
 
-	if latestVersion.IsNewerVersionThan(repo.SemVer) && !updateRequired {
+	candidates := sortCandidates(versions, stableOnly)
+	allowedVersion := findAllowedVersion(candidates, repo.SemVer, allow)
+
+	if latestVersion.IsNewerVersionThan(repo.SemVer) && latestVersion.Compare(allowedVersion) != 0 {
 		bumpType := latestVersion.GetBumpType(repo.SemVer)
 		b.cfg.Logger.Sugar().Debugf("Update available for %s (%s -> %s) but %s bump not allowed (only %s allowed)",
-			repo.Repo, repo.Rev, latestVersion.String(), bumpType, b.cfg.Allow)
+			repo.Repo, repo.Rev, latestVersion.String(), bumpType, allow)
+	}
+
+	var frozenRev string
+	if (repo.Frozen != "" || b.cfg.Freeze) && allowedVersion != nil {
+		frozenRev, err = resolveFrozenRev(ctx, &repo, allowedVersion, updater)
+		if err != nil {
+			return types.UpdateResult{
+				Repo:  repo,
+				Error: fmt.Errorf("failed to resolve frozen revision for %s: %w", repo.Repo, err),
+			}
+		}
+		b.cfg.Logger.Sugar().Debugf("Resolved %s of %s to %s", allowedVersion.String(), repo.Repo, frozenRev)
 	}
 
 	return types.UpdateResult{
-		Repo:           repo,
-		LatestVersion:  latestVersion,
-		UpdateRequired: updateRequired,
+		Repo:             repo,
+		LatestVersion:    latestVersion,
+		LatestStable:     latestStable,
+		LatestPrerelease: latestPrerelease,
+		AllowedVersion:   allowedVersion,
+		Candidates:       candidates,
+		UpdateRequired:   allowedVersion != nil,
+		Unpublished:      unpublished,
+		FrozenRev:        frozenRev,
+	}
+}
+
+// resolveFrozenRev resolves the tag of the version a frozen repository is bumped to, to the SHA of its commit.
+func resolveFrozenRev(ctx context.Context, repo *types.Repo, version *types.SemanticVersion, updater RepoBumper) (string, error) {
+	resolver, ok := updater.(TagResolver)
+	if !ok {
+		return "", fmt.Errorf("vendor %s does not support resolving tags to commits", repo.GetVendor())
 	}
+	return resolver.ResolveTag(ctx, repo, repo.FormatRevision(version))
 }
 
 // processResults handles common error checking and logging
-// returns a boolean indicating if updates are available in any of the hooks or an error if any occurred.
+// returns a boolean indicating if updates are available in any of the hooks and a CheckError grouping the failures if any occurred.
+// The boolean is also set when an error occurred, so successful updates can still be applied.
 func (b *Bumper) processResults(results []types.UpdateResult) (bool, error) {
 	var hasUpdates bool
-	var errs []error
+	var failed []types.UpdateResult
 
 	for _, result := range results {
+		if result.Duplicate {
+			continue
+		}
+
+		if result.SkipReason == config.SkipReasonNoVersion {
+			b.cfg.Logger.Sugar().Warnf("Skipped %s at %s: %s", result.Name(), result.CurrentVersion(), result.SkipReason)
+			continue
+		} else if result.SkipReason != "" {
+			b.cfg.Logger.Sugar().Infof("Skipped %s: %s", result.Name(), result.SkipReason)
+			continue
+		}
+
+		if result.Ignored {
+			b.cfg.Logger.Sugar().Infof("Ignored %s", result.Name())
+			continue
+		}
+
 		if result.Error != nil {
-			b.cfg.Logger.Sugar().Warnf("Error checking %s: %v", result.Repo.Repo, result.Error)
-			errs = append(errs, result.Error)
+			b.cfg.Logger.Sugar().Warnf("Error checking %s: %v", result.Name(), result.Error)
+			failed = append(failed, result)
 			continue
 		}
 
+		if result.Unpublished {
+			b.cfg.Logger.Sugar().Warnf("Pinned version %s of %s is no longer published", result.CurrentVersion(), result.Name())
+		}
+
 		if result.UpdateRequired {
 			hasUpdates = true
-			b.cfg.Logger.Sugar().Infof("Update available for %s: %s -> %s",
-				result.Repo.Repo, result.Repo.Rev, result.LatestVersion.String())
+			b.cfg.Logger.Sugar().Infof("Update available for %s: %s -> %s%s",
+				result.Name(), result.CurrentVersion(), result.BumpVersion().String(), bumpTypeSuffix(result))
 		}
 	}
 
-	if len(errs) > 0 {
-		return false, fmt.Errorf("errors occurred while checking repositories: %v", errs)
+	if len(failed) > 0 {
+		return hasUpdates, newCheckError(failed)
 	}
 
 	return hasUpdates, nil
 }
 
+// bumpTypeSuffix returns the bump type of the result for log messages, e.g. " (major)", or an empty string when
+// the versions can not be compared.
+func bumpTypeSuffix(result types.UpdateResult) string {
+	bumpType := result.BumpVersion().GetBumpType(result.CurrentSemVer())
+	if bumpType == "" {
+		return ""
+	}
+	return " (" + bumpType + ")"
+}
+
 // processCheckResults processes the results of the check for updates.
 // It checks if any updates are available and returns an error if so.
 func (b *Bumper) processCheckResults(results []types.UpdateResult) error {
@@ -184,61 +787,503 @@ func (b *Bumper) processCheckResults(results []types.UpdateResult) error {
 	}
 
 	if hasUpdates {
-		return fmt.Errorf("updates are available")
+		return newUpdatesAvailableError(results)
+	}
+	if !hasCheckedResults(results) {
+		return ErrNothingToCheck
 	}
 	return nil
 }
 
+// hasCheckedResults reports whether at least one repository or dependency was actually checked for updates.
+func hasCheckedResults(results []types.UpdateResult) bool {
+	for _, result := range results {
+		if result.SkipReason == "" && !result.Ignored {
+			return true
+		}
+	}
+	return false
+}
+
 // processUpdateResults processes the results of the update check.
 // It writes the changes to the pre-commit configuration file and generates a summary if requested.
+// By default any failed repository aborts the update, with --continue-on-error the successful updates are still
+// written and ErrPartialUpdate is returned afterwards.
 func (b *Bumper) processUpdateResults(results []types.UpdateResult) error {
-	hasUpdates, err := b.processResults(results)
-	if err != nil {
-		return err
+	hasUpdates, checkErr := b.processResults(results)
+	var partialErr error
+	if checkErr != nil {
+		if !b.cfg.ContinueOnError {
+			return checkErr
+		}
+		b.cfg.Logger.Sugar().Warnf("Continuing with the successful updates: %v", checkErr)
+		partialErr = fmt.Errorf("%w: %w", ErrPartialUpdate, checkErr)
 	}
 
-	if hasUpdates && !b.cfg.DryRun {
-		err := b.fileWriter.WritePreCommitChanges(b.cfg.PreCommitConfigPath, results)
-		if err != nil {
-			return fmt.Errorf("failed to write pre-commit changes: %w", err)
+	if hasUpdates && !b.cfg.DryRun && b.approver != nil {
+		if err := b.approveUpdates(results); err != nil {
+			return err
+		}
+	}
+
+	if b.cfg.MaxBumps > 0 {
+		b.deferUpdates(results)
+	}
+
+	// When reading from stdin the configuration is always written to stdout, so it can be piped on
+	fromStdin := slices.ContainsFunc(results, func(result types.UpdateResult) bool {
+		return result.ConfigPath == config.StdinPath
+	})
+	writeChanges := (hasUpdates || fromStdin) && !b.cfg.DryRun
+	if writeChanges {
+		if err := b.writeAllConfigChanges(results); err != nil {
+			return err
+		}
+	} else if b.cfg.DryRun {
+		b.cfg.Logger.Sugar().Info("Dry run mode enabled, will not modify the pre-commit-config.yaml file")
+	}
+
+	// The summary accompanies written changes, unless it is requested for every run with --always-summary.
+	// A dry run prints the summary the changes would come with instead of writing it.
+	previewSummary := b.cfg.DryRun && hasUpdates && !b.cfg.AlwaysSummary
+	if !writeChanges && !b.cfg.AlwaysSummary && !previewSummary {
+		return partialErr
+	}
+	if b.cfg.NoSummary {
+		b.cfg.Logger.Sugar().Info("No summary generation requested, skipping summary file creation")
+		return partialErr
+	}
+	if previewSummary {
+		return errors.Join(partialErr, b.previewSummary(results))
+	}
+	if err := b.fileWriter.WriteSummary(results, b.cfg.Allow); err != nil {
+		return fmt.Errorf("failed to write summary: %w", err)
+	}
+	b.cfg.Logger.Sugar().Info("Summary file created successfully")
+
+	return partialErr
+}
+
+// previewSummary prints the summary of a dry run to stdout.
+// The json and sarif output formats print a document to stdout that must stay parseable, so no summary is printed for them,
+// and neither is it with --quiet.
+func (b *Bumper) previewSummary(results []types.UpdateResult) error {
+	if b.cfg.Quiet {
+		b.cfg.Logger.Sugar().Debug("Skipping the summary preview, --quiet is set")
+		return nil
+	}
+	if b.cfg.Format == config.FormatJSON || b.cfg.Format == config.FormatSARIF {
+		b.cfg.Logger.Sugar().Debugf("Skipping the summary preview, stdout is used by the %s output", b.cfg.Format)
+		return nil
+	}
+	if err := b.fileWriter.PreviewSummary(results, b.cfg.Allow); err != nil {
+		return fmt.Errorf("failed to print summary: %w", err)
+	}
+	return nil
+}
+
+// writeAllConfigChanges writes the updates of every configuration file of the results.
+// The files are independent, so they are written concurrently, limited by --max-concurrency. Every file is written
+// exactly once by a single goroutine with all of its results, also when a repository appears in multiple files, so
+// writes to the same file never interleave. The errors of all files are returned together.
+func (b *Bumper) writeAllConfigChanges(results []types.UpdateResult) error {
+	configPaths := resultConfigPaths(results)
+	if b.cfg.ConfigOut != "" && len(configPaths) > 1 {
+		return fmt.Errorf("--%s requires a single configuration file, got %d", config.FlagConfigOut, len(configPaths))
+	}
+	errs := make([]error, len(configPaths))
+
+	semaphore := make(chan struct{}, max(b.cfg.MaxConcurrency, 1))
+	var waitGroup sync.WaitGroup
+
+	for pathIndex, configPath := range configPaths {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			errs[pathIndex] = b.writeConfigChanges(configPath, results)
+		}()
+	}
+
+	waitGroup.Wait()
+
+	return errors.Join(errs...)
+}
+
+// writeConfigChanges writes the updates of a single pre-commit configuration file and verifies it if requested.
+// Files without updates are left untouched, except for stdin which is always written to stdout.
+// With --config-out the updated configuration is written to that path instead, the configuration file is not modified.
+func (b *Bumper) writeConfigChanges(configPath string, results []types.UpdateResult) error {
+	var configResults []types.UpdateResult
+	hasUpdates := false
+	for _, result := range results {
+		if result.ConfigPath != configPath {
+			continue
+		}
+		configResults = append(configResults, result)
+		hasUpdates = hasUpdates || (result.UpdateRequired && result.Error == nil && !result.Declined && !result.Deferred)
+	}
+
+	fromStdin := configPath == config.StdinPath
+	if !hasUpdates && !fromStdin {
+		return nil
+	}
+
+	outPath := configPath
+	if b.cfg.ConfigOut != "" {
+		outPath = b.cfg.ConfigOut
+	}
+
+	if err := b.fileWriter.WritePreCommitChangesTo(configPath, outPath, configResults); err != nil {
+		return fmt.Errorf("failed to write pre-commit changes to %s: %w", outPath, err)
+	}
+	if outPath != configPath {
+		b.cfg.Logger.Sugar().Infof("Updated pre-commit configuration file %s written to %s", configPath, outPath)
+	} else {
+		b.cfg.Logger.Sugar().Infof("Pre-commit configuration file %s updated successfully", configPath)
+	}
+
+	if b.cfg.Verify && outPath == config.StdinPath {
+		b.cfg.Logger.Sugar().Warn("Skipping verification, the configuration was read from stdin")
+	} else if b.cfg.Verify {
+		return b.verifyConfig(outPath)
+	}
+
+	return nil
+}
+
+// approveUpdates asks the Approver for every available update and marks the updates it does not approve as declined.
+// Repeated occurrences of a repository follow the decision for its first occurrence, so it is only asked once.
+func (b *Bumper) approveUpdates(results []types.UpdateResult) error {
+	decisions := map[string]bool{}
+	for i := range results {
+		result := &results[i]
+		if !result.UpdateRequired || result.Error != nil {
+			continue
 		}
-		b.cfg.Logger.Sugar().Info("Pre-commit configuration file updated successfully")
 
-		if !b.cfg.NoSummary {
-			err = b.fileWriter.WriteSummary(results, b.cfg.Allow)
+		key := updateKey(result)
+		approved, ok := decisions[key]
+		if !ok {
+			var err error
+			approved, err = b.approver.Approve(*result)
 			if err != nil {
-				return fmt.Errorf("failed to write summary: %w", err)
+				return fmt.Errorf("failed to approve update of %s: %w", result.Name(), err)
 			}
-			b.cfg.Logger.Sugar().Info("Summary file created successfully")
-		} else {
-			b.cfg.Logger.Sugar().Info("No summary generation requested, skipping summary file creation")
+			decisions[key] = approved
+		}
+
+		if !approved {
+			b.cfg.Logger.Sugar().Infof("Declined update of %s: %s -> %s", result.Name(), result.CurrentVersion(), result.BumpVersion().String())
+			result.Declined = true
+		}
+	}
+	return nil
+}
+
+// deferUpdates keeps the first --max-bumps available updates in the order of the configuration files, and marks the
+// updates beyond the limit as deferred, so they are left for a later run. Declined updates do not count towards the limit,
+// and repeated occurrences of a repository share the outcome of its first occurrence.
+func (b *Bumper) deferUpdates(results []types.UpdateResult) {
+	decisions := map[string]bool{}
+	bumps := 0
+	for i := range results {
+		result := &results[i]
+		if !result.UpdateRequired || result.Error != nil || result.Declined {
+			continue
+		}
+
+		key := updateKey(result)
+		keep, ok := decisions[key]
+		if !ok {
+			keep = bumps < b.cfg.MaxBumps
+			if keep {
+				bumps++
+			}
+			decisions[key] = keep
+		}
+
+		if !keep {
+			b.cfg.Logger.Sugar().Infof("Deferred update of %s: %s -> %s, --%s %d reached",
+				result.Name(), result.CurrentVersion(), result.BumpVersion().String(), config.FlagMaxBumps, b.cfg.MaxBumps)
+			result.Deferred = true
 		}
-	} else if b.cfg.DryRun {
-		b.cfg.Logger.Sugar().Info("Dry run mode enabled, will not modify the pre-commit-config.yaml file or create a summary")
 	}
+}
+
+// updateKey identifies an available update in a configuration file, repeated occurrences of a repository or
+// dependency share the same key.
+func updateKey(result *types.UpdateResult) string {
+	key := result.ConfigPath + "\x00" + repoCheckKey(&result.Repo)
+	if result.Dependency != nil {
+		key += "\x00" + result.Dependency.HookID + "\x00" + result.Dependency.Spec
+	}
+	return key
+}
 
+// resultConfigPaths returns the distinct configuration files of the results, in the order they first appear.
+func resultConfigPaths(results []types.UpdateResult) []string {
+	var paths []string
+	for _, result := range results {
+		if !slices.Contains(paths, result.ConfigPath) {
+			paths = append(paths, result.ConfigPath)
+		}
+	}
+	return paths
+}
+
+// verifyConfig validates the rewritten pre-commit configuration file using pre-commit itself.
+// When pre-commit is not installed the verification is skipped with a warning instead of failing the run.
+func (b *Bumper) verifyConfig(configPath string) error {
+	err := b.verifier.VerifyConfig(configPath)
+	if errors.Is(err, io.ErrPreCommitNotInstalled) {
+		b.cfg.Logger.Sugar().Warnf("Skipping verification of %s: %v", configPath, err)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("verification of updated configuration %s failed: %w", configPath, err)
+	}
+
+	b.cfg.Logger.Sugar().Infof("Updated pre-commit configuration file %s verified successfully", configPath)
 	return nil
 }
 
-// findLatestVersion iterating through the Vendor tags to find the latest semantic version.
-// It returns the latest version found or an error if no valid semantic versions are present.
-func findLatestVersion[T TagProvider](tags []T, repo *types.Repo) (*types.SemanticVersion, error) {
-	var latest *types.SemanticVersion
+// writeReport writes the results in the configured output format.
+// The default text format only logs the results, so no report is written for it.
+func (b *Bumper) writeReport(results []types.UpdateResult) error {
+	switch b.cfg.Format {
+	case config.FormatJUnit:
+		err := b.fileWriter.WriteJUnitReport(b.cfg.ReportFile, results)
+		if err != nil {
+			return fmt.Errorf("failed to write junit report: %w", err)
+		}
+		b.cfg.Logger.Sugar().Infof("JUnit report written to %s", b.cfg.ReportFile)
+	case config.FormatGitHub:
+		if err := b.fileWriter.WriteGitHubAnnotations(results); err != nil {
+			return fmt.Errorf("failed to write github annotations: %w", err)
+		}
+	case config.FormatJSON:
+		if err := b.fileWriter.WriteJSONPlan(results); err != nil {
+			return fmt.Errorf("failed to write json plan: %w", err)
+		}
+	case config.FormatSARIF:
+		if err := b.fileWriter.WriteSARIFReport(results); err != nil {
+			return fmt.Errorf("failed to write sarif report: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// fetchVersions retrieves the versions of the repository with its RepoBumper.
+// Repositories without any version tag are recorded in the negative cache, and are not fetched again until it expires.
+func (b *Bumper) fetchVersions(ctx context.Context, repo *types.Repo, updater RepoBumper) ([]*types.SemanticVersion, error) {
+	key := versionCacheKey(repo)
+	if b.negatives.Has(key) {
+		b.cfg.Logger.Sugar().Debugf("Repo %s had no version tags when it was last checked, skipping it until the negative cache expires", repo.Repo)
+		return nil, newNoVersionsError(repo)
+	}
+
+	versions, err := updater.GetVersions(ctx, repo)
+	var noVersions *NoVersionsError
+	if errors.As(err, &noVersions) {
+		if cacheErr := b.negatives.Put(key); cacheErr != nil {
+			b.cfg.Logger.Sugar().Warnf("Failed to cache that %s has no version tags: %v", repo.Repo, cacheErr)
+		}
+	}
+
+	return versions, err
+}
+
+// parseTagVersions parses the Vendor tags into semantic versions, skipping tags that are not a valid semantic version.
+// When the repository has a tag prefix, only tags that start with the prefix directly followed by the version are parsed,
+// so e.g. "backend-1.2.3" tags are not mixed with "v1.2.3" tags. Strict repositories only accept tags that are the version
+// as a whole after the prefix, optionally preceded by "v". It returns an error if no valid semantic versions are present.
+func parseTagVersions[T TagProvider](tags []T, repo *types.Repo) ([]*types.SemanticVersion, error) {
+	var versions []*types.SemanticVersion
+
+	scheme := types.VersionSchemeSemVer
+	var tagPrefix string
+	var strict bool
+	if repo != nil {
+		if repo.Scheme != "" {
+			scheme = repo.Scheme
+		}
+		tagPrefix = repo.TagPrefix
+		strict = repo.Strict
+	}
 
 	for _, tag := range tags {
-		semVer, ok := types.GetSemanticVersion(tag.GetTagName())
+		name, ok := strings.CutPrefix(tag.GetTagName(), tagPrefix)
 		if !ok {
 			continue
 		}
 
+		var semVer *types.SemanticVersion
+		if strict {
+			semVer, ok = types.ParseStrictVersion(name, scheme)
+		} else {
+			semVer, ok = types.ParseVersion(name, scheme)
+		}
+		if !ok || (tagPrefix != "" && !strings.HasPrefix(name, semVer.Original)) {
+			continue
+		}
+		versions = append(versions, semVer)
+	}
+
+	if len(versions) == 0 {
+		return nil, newNoVersionsError(repo)
+	}
+
+	return versions, nil
+}
+
+// findLatestVersion iterating through the versions to find the latest semantic version.
+// When stableOnly is set, pre-release versions are skipped. It returns nil if no version qualifies.
+// Of versions that only differ in build metadata the first one is kept.
+func findLatestVersion(versions []*types.SemanticVersion, stableOnly bool) *types.SemanticVersion {
+	var latest *types.SemanticVersion
+
+	for _, semVer := range versions {
+		if stableOnly && semVer.PreRelease != "" {
+			continue
+		}
+
 		if latest == nil || semVer.IsNewerVersionThan(latest) {
 			latest = semVer
 		}
 	}
 
-	if latest == nil {
-		return nil, fmt.Errorf("no semantic version tags found for repo: %s with rev: %s", repo.Repo, repo.Rev)
+	return latest
+}
+
+// containsVersion reports whether the version is among the versions, regardless of build metadata.
+func containsVersion(versions []*types.SemanticVersion, version *types.SemanticVersion) bool {
+	for _, semVer := range versions {
+		if semVer.Compare(version) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// sortCandidates returns a sorted copy of the versions that are considered for a bump, in ascending order.
+// When stableOnly is set, pre-release versions are left out. The versions are copied since they may be shared through the cache.
+func sortCandidates(versions []*types.SemanticVersion, stableOnly bool) []*types.SemanticVersion {
+	candidates := make([]*types.SemanticVersion, 0, len(versions))
+	for _, semVer := range versions {
+		if stableOnly && semVer.PreRelease != "" {
+			continue
+		}
+		candidates = append(candidates, semVer)
+	}
+
+	types.SortVersions(candidates)
+	return candidates
+}
+
+// allowFor returns the bump type that is allowed for the current version under the allowed bump type.
+// With --zero-minor-breaking a minor bump of a 0.y.z version is treated as major, so "minor" only allows patch bumps for it.
+func (b *Bumper) allowFor(allow string, current *types.SemanticVersion) string {
+	if b.cfg.ZeroMinorBreaking && allow == config.BumpMinor && current.IsInitialDevelopment() {
+		return config.BumpPatch
+	}
+	return allow
+}
+
+// findAllowedVersion returns the highest of the sorted candidates that the current version may be bumped to under the allowed bump type.
+// It returns nil if none of the candidates is an allowed bump. Of candidates that only differ in build metadata, the one
+// selected by preferredBuild is returned.
+func findAllowedVersion(candidates []*types.SemanticVersion, current *types.SemanticVersion, allow string) *types.SemanticVersion {
+	for i := len(candidates) - 1; i >= 0; i-- {
+		if candidates[i].IsAllowedBumpFrom(current, allow) {
+			return preferredBuild(candidates, candidates[i], current)
+		}
+	}
+	return nil
+}
+
+// preferredBuild returns the version to use out of the versions that are equal to version except for their build metadata.
+// The build with the build metadata of the current version is preferred, otherwise the first one of the versions is used,
+// so the selection is deterministic for a given order of versions.
+func preferredBuild(versions []*types.SemanticVersion, version *types.SemanticVersion, current *types.SemanticVersion) *types.SemanticVersion {
+	var first *types.SemanticVersion
+	for _, semVer := range versions {
+		if semVer.Compare(version) != 0 {
+			continue
+		}
+		if current != nil && semVer.BuildMetaData == current.BuildMetaData {
+			return semVer
+		}
+		if first == nil {
+			first = semVer
+		}
+	}
+
+	if first == nil {
+		return version
 	}
+	return first
+}
+
+// nextPageURL returns the URL of the next page from the Link header of a paginated API response.
+// It returns an empty string when there is no next page.
+func nextPageURL(resp *http.Response) string {
+	re := regexp.MustCompile(config.ReLinkNext)
+	for _, link := range resp.Header.Values("Link") {
+		matches := re.FindStringSubmatch(link)
+		if next := utils.GetGroup(re, matches, "url"); next != "" {
+			return next
+		}
+	}
+	return ""
+}
 
-	return latest, nil
+// logRateLimitRemaining logs the remaining rate limit reported by the vendor API at debug level.
+// Nothing is logged when the API does not report a rate limit, e.g. for unlimited tokens.
+func logRateLimitRemaining(logger *zap.Logger, vendor string, remaining string) {
+	if remaining == "" {
+		return
+	}
+	logger.Sugar().Debugf("%s API rate limit remaining: %s", vendor, remaining)
+}
+
+// extractHostedRepo extracts the host and the owner and repository name from a repository URL on any host.
+// It is used for self-hosted instances, handles both HTTPS and SSH formats, and removes the ".git" suffix if present.
+func extractHostedRepo(repoURL string) (string, string) {
+	re := regexp.MustCompile(config.ReHostedRepoName)
+	matches := re.FindStringSubmatch(repoURL)
+	return utils.GetGroup(re, matches, "host"), utils.GetGroup(re, matches, "repo_name")
+}
+
+// urlHost returns the host of the URL, including the port if present, or an empty string if it can not be parsed.
+func urlHost(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return parsed.Host
+}
+
+// containsHost reports whether the host is one of the hosts, ignoring case.
+func containsHost(hosts []string, host string) bool {
+	return slices.ContainsFunc(hosts, func(candidate string) bool {
+		return strings.EqualFold(candidate, host)
+	})
+}
+
+// configuredHosts returns the hosts explicitly configured for the vendor with --github-hosts, --gitlab-hosts or
+// --vendor-host, sorted for a deterministic order.
+func (b *Bumper) configuredHosts(vendor string) []string {
+	var hosts []string
+	for host, hostVendor := range b.vendorHosts() {
+		if hostVendor == vendor {
+			hosts = append(hosts, host)
+		}
+	}
+	slices.Sort(hosts)
+	return hosts
 }
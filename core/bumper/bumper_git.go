@@ -0,0 +1,116 @@
+package bumper
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/ramonvermeulen/pre-commit-bump/core/types"
+)
+
+// GitBumper is a struct that implements the RepoBumper interface for repositories on any git host.
+// It lists the tags with git ls-remote, so it works without an API but requires git on PATH.
+type GitBumper struct {
+	gitPath string
+}
+
+// NewGitBumper creates a new instance of GitBumper that runs the git executable found on PATH.
+func NewGitBumper() *GitBumper {
+	return &GitBumper{
+		gitPath: "git",
+	}
+}
+
+// GitTag represents a tag listed by git ls-remote.
+type GitTag struct {
+	Name string
+}
+
+// GetTagName returns the tag name from the GitTag struct.
+func (gt GitTag) GetTagName() string {
+	return gt.Name
+}
+
+// GetVersions retrieves the semantic versions from a git repository.
+// It lists the tags of the remote with git ls-remote,
+// and returns the semantic versions found or an error if no valid semantic versions are present.
+func (g *GitBumper) GetVersions(ctx context.Context, repo *types.Repo) ([]*types.SemanticVersion, error) {
+	output, err := g.lsRemoteTags(ctx, repo.Repo)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseTagVersions(parseLsRemoteTags(output), repo)
+}
+
+// ResolveTag resolves the tag of a git repository to the SHA of the commit it points to.
+// Annotated tags are listed twice by git ls-remote, the peeled "^{}" entry holds the commit they point to.
+func (g *GitBumper) ResolveTag(ctx context.Context, repo *types.Repo, tag string) (string, error) {
+	ref := "refs/tags/" + tag
+	output, err := g.lsRemoteTags(ctx, repo.Repo, ref, ref+"^{}")
+	if err != nil {
+		return "", err
+	}
+
+	sha := ""
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		if fields[1] == ref+"^{}" {
+			return fields[0], nil
+		}
+		if fields[1] == ref {
+			sha = fields[0]
+		}
+	}
+
+	if sha == "" {
+		return "", fmt.Errorf("tag %s not found in %s", tag, repo.Repo)
+	}
+	return sha, nil
+}
+
+// lsRemoteTags runs git ls-remote --tags against the repository URL and returns its output, optionally limited to the given refs.
+// Terminal prompts are disabled, so a remote that requires credentials fails instead of hanging.
+func (g *GitBumper) lsRemoteTags(ctx context.Context, repoURL string, refs ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, g.gitPath, append([]string{"ls-remote", "--tags", "--", repoURL}, refs...)...)
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to list tags with git ls-remote: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// parseLsRemoteTags parses the "<sha>\trefs/tags/<name>" lines of git ls-remote into tags.
+// The peeled "^{}" entries of annotated tags are skipped, so every tag is listed once.
+func parseLsRemoteTags(output []byte) []GitTag {
+	var tags []GitTag
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+
+		name, ok := strings.CutPrefix(fields[1], "refs/tags/")
+		if !ok || strings.HasSuffix(name, "^{}") {
+			continue
+		}
+		tags = append(tags, GitTag{Name: name})
+	}
+
+	return tags
+}
@@ -0,0 +1,131 @@
+package bumper
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/ramonvermeulen/pre-commit-bump/config"
+	"github.com/ramonvermeulen/pre-commit-bump/core/types"
+)
+
+// newBareRepoFixture creates a local bare repository with the given tags, annotated tags included.
+func newBareRepoFixture(t *testing.T, tags []string, annotatedTags []string) string {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available on PATH")
+	}
+
+	workDir := filepath.Join(t.TempDir(), "work")
+	bareDir := filepath.Join(t.TempDir(), "repo.git")
+
+	runGit := func(dir string, args ...string) {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com", "-c", "commit.gpgsign=false", "-c", "tag.gpgsign=false"}, args...)...)
+		cmd.Dir = dir
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+
+	runGit(t.TempDir(), "init", "--quiet", workDir)
+	runGit(workDir, "commit", "--quiet", "--allow-empty", "-m", "initial")
+	for _, tag := range tags {
+		runGit(workDir, "tag", tag)
+	}
+	for _, tag := range annotatedTags {
+		runGit(workDir, "tag", "-a", tag, "-m", tag)
+	}
+	runGit(t.TempDir(), "clone", "--quiet", "--bare", workDir, bareDir)
+
+	return bareDir
+}
+
+func TestGitBumper_GetVersions(t *testing.T) {
+	repoURL := newBareRepoFixture(t, []string{"v1.0.0", "v1.2.0", "latest"}, []string{"v1.3.0", "v2.0.0-rc1"})
+
+	versions, err := NewGitBumper().GetVersions(t.Context(), &types.Repo{Repo: repoURL, Rev: "v1.0.0"})
+	require.NoError(t, err)
+
+	assert.Len(t, versions, 4)
+	assert.Equal(t, "2.0.0-rc1", findLatestVersion(versions, false).String())
+	assert.Equal(t, "1.3.0", findLatestVersion(versions, true).String())
+}
+
+func TestGitBumper_GetVersions_NoSemanticVersionTags(t *testing.T) {
+	repoURL := newBareRepoFixture(t, []string{"latest"}, nil)
+
+	_, err := NewGitBumper().GetVersions(t.Context(), &types.Repo{Repo: repoURL, Rev: "v1.0.0"})
+	assert.ErrorContains(t, err, "no semantic version tags found")
+}
+
+func TestGitBumper_GetVersions_UnknownRemote(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available on PATH")
+	}
+
+	_, err := NewGitBumper().GetVersions(t.Context(), &types.Repo{Repo: filepath.Join(t.TempDir(), "missing.git")})
+	assert.ErrorContains(t, err, "failed to list tags with git ls-remote")
+}
+
+func TestGitBumper_ResolveTag(t *testing.T) {
+	repoURL := newBareRepoFixture(t, []string{"v1.0.0"}, []string{"v1.1.0"})
+
+	revParse := func(rev string) string {
+		output, err := exec.Command("git", "--git-dir", repoURL, "rev-parse", rev).Output()
+		require.NoError(t, err)
+		return strings.TrimSpace(string(output))
+	}
+
+	gitBumper := NewGitBumper()
+	for _, tag := range []string{"v1.0.0", "v1.1.0"} {
+		sha, err := gitBumper.ResolveTag(t.Context(), &types.Repo{Repo: repoURL}, tag)
+		require.NoError(t, err)
+		assert.Equal(t, revParse(tag+"^{commit}"), sha, "the tag should resolve to its commit")
+	}
+
+	_, err := gitBumper.ResolveTag(t.Context(), &types.Repo{Repo: repoURL}, "v9.9.9")
+	assert.ErrorContains(t, err, "tag v9.9.9 not found")
+}
+
+func TestParseLsRemoteTags(t *testing.T) {
+	output := []byte("1111111111111111111111111111111111111111\trefs/tags/v1.0.0\n" +
+		"2222222222222222222222222222222222222222\trefs/tags/v1.1.0\n" +
+		"3333333333333333333333333333333333333333\trefs/tags/v1.1.0^{}\n" +
+		"4444444444444444444444444444444444444444\trefs/heads/main\n" +
+		"\n")
+
+	assert.Equal(t, []GitTag{{Name: "v1.0.0"}, {Name: "v1.1.0"}}, parseLsRemoteTags(output))
+}
+
+func TestBumper_checkReposWithUpdaters_GitFallback(t *testing.T) {
+	mockGitHub := new(MockRepoBumper)
+	mockGit := new(MockRepoBumper)
+	mockGit.On("GetVersions", mock.Anything).Return([]*types.SemanticVersion{{Major: 2}}, nil)
+
+	bumper := &Bumper{cfg: &config.Config{
+		Allow:          config.BumpMajor,
+		MaxConcurrency: 1,
+		Logger:         zap.NewNop(),
+	}}
+
+	repos := []types.Repo{
+		{Repo: "https://git.unknown.org/owner/repo", Rev: "v1.0.0", SemVer: &types.SemanticVersion{Major: 1}},
+	}
+
+	results := bumper.checkReposWithUpdaters(t.Context(), repos, map[string]RepoBumper{config.VendorGitHub: mockGitHub})
+	require.Len(t, results, 1)
+	assert.ErrorContains(t, results[0].Error, "no updater found for vendor")
+
+	results = bumper.checkReposWithUpdaters(t.Context(), repos, map[string]RepoBumper{config.VendorGitHub: mockGitHub, config.VendorGit: mockGit})
+	require.Len(t, results, 1)
+	assert.NoError(t, results[0].Error)
+	assert.True(t, results[0].UpdateRequired)
+	mockGit.AssertNumberOfCalls(t, "GetVersions", 1)
+	mockGitHub.AssertNotCalled(t, "GetVersions", mock.Anything)
+}
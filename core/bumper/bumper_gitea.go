@@ -0,0 +1,199 @@
+package bumper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+
+	"github.com/ramonvermeulen/pre-commit-bump/config"
+	"github.com/ramonvermeulen/pre-commit-bump/core/io"
+	"github.com/ramonvermeulen/pre-commit-bump/core/types"
+
+	"go.uber.org/zap"
+)
+
+// GiteaBumper is a struct that implements the RepoBumper interface for Gitea and Forgejo repositories.
+// Since most instances are self-hosted, the API is queried on the host of the repository URL.
+type GiteaBumper struct {
+	logger *zap.Logger
+	client *http.Client
+	retry  RetryPolicy
+	etags  *io.ETagCache
+}
+
+// NewGiteaBumper creates a new instance of GiteaBumper with the provided logger, HTTP client, retry policy and ETag cache.
+func NewGiteaBumper(logger *zap.Logger, client *http.Client, retry RetryPolicy, etags *io.ETagCache) *GiteaBumper {
+	return &GiteaBumper{
+		logger: logger,
+		client: client,
+		retry:  retry,
+		etags:  etags,
+	}
+}
+
+// GiteaTag represents a tag in a Gitea repository.
+type GiteaTag struct {
+	Name string `json:"name"`
+}
+
+// GetTagName returns the tag name from the GiteaTag struct.
+func (gt GiteaTag) GetTagName() string {
+	return gt.Name
+}
+
+// GetVersions retrieves the semantic versions from a Gitea repository.
+// It fetches the tags using the Gitea API of the host the repository lives on,
+// and returns the semantic versions found or an error if no valid semantic versions are present.
+func (g *GiteaBumper) GetVersions(ctx context.Context, repo *types.Repo) ([]*types.SemanticVersion, error) {
+	apiURL, repoPath, err := giteaRepoAPI(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := g.fetchTags(ctx, fmt.Sprintf("%s/repos/%s/tags?page=1&limit=%d", apiURL, repoPath, config.TagsPerPage))
+	if err != nil {
+		return nil, err
+	}
+
+	return parseTagVersions(tags, repo)
+}
+
+// GiteaTagCommit represents a single tag of a Gitea repository with the commit it points to.
+type GiteaTagCommit struct {
+	Commit struct {
+		SHA string `json:"sha"`
+	} `json:"commit"`
+}
+
+// ResolveTag resolves the tag of a Gitea repository to the SHA of the commit it points to.
+func (g *GiteaBumper) ResolveTag(ctx context.Context, repo *types.Repo, tag string) (string, error) {
+	apiURL, repoPath, err := giteaRepoAPI(repo)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/repos/%s/tags/%s", apiURL, repoPath, url.PathEscape(tag)), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create Gitea API request: %w", err)
+	}
+
+	body, err := resolveTagResponse(g.client, g.retry, req, "Gitea")
+	if err != nil {
+		return "", err
+	}
+
+	var tagCommit GiteaTagCommit
+	if err := json.Unmarshal(body, &tagCommit); err != nil {
+		return "", fmt.Errorf("failed to decode Gitea API response: %w", err)
+	}
+	if tagCommit.Commit.SHA == "" {
+		return "", fmt.Errorf("Gitea API returned no commit for tag %s", tag)
+	}
+	return tagCommit.Commit.SHA, nil
+}
+
+// giteaRepoAPI returns the API base URL of the host the repository lives on and the owner and repository name.
+// The API is served over http when the repository URL uses http, and over https otherwise, e.g. for SSH URLs.
+func giteaRepoAPI(repo *types.Repo) (string, string, error) {
+	host, repoPath := extractHostedRepo(repo.Repo)
+	if host == "" || repoPath == "" {
+		return "", "", fmt.Errorf("failed to extract owner and repository from Gitea URL: %s", repo.Repo)
+	}
+
+	scheme := "https"
+	if parsed, err := url.Parse(repo.Repo); err == nil && parsed.Scheme == "http" {
+		scheme = "http"
+	}
+	return fmt.Sprintf("%s://%s/api/v1", scheme, host), repoPath, nil
+}
+
+// fetchTags retrieves the tags from a Gitea repository using the Gitea API.
+// The endpoint is paginated, so the next pages are followed until all pages are fetched or the page cap is reached.
+func (g *GiteaBumper) fetchTags(ctx context.Context, endpoint string) ([]GiteaTag, error) {
+	var tags []GiteaTag
+	firstURL := endpoint
+
+	for page := 0; endpoint != ""; page++ {
+		if page >= config.MaxTagPages {
+			return nil, fmt.Errorf("Gitea API returned more than %d pages of tags", config.MaxTagPages)
+		}
+
+		pageTags, next, err := g.fetchTagPage(ctx, endpoint)
+		if err != nil {
+			return nil, err
+		}
+
+		tags = append(tags, pageTags...)
+		endpoint = next
+	}
+
+	g.logger.Sugar().Debugf("Fetched %d tags from %s", len(tags), firstURL)
+	return tags, nil
+}
+
+// fetchTagPage retrieves a single page of tags from the Gitea API.
+// It returns the tags on the page and the URL of the next page, which is empty on the last page.
+func (g *GiteaBumper) fetchTagPage(ctx context.Context, endpoint string) ([]GiteaTag, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create Gitea API request: %w", err)
+	}
+	entry := withETag(req, g.etags, endpoint)
+
+	resp, err := g.retry.Do(g.client, req)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to call Gitea API: %w", err)
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to close response body: %v\n", closeErr)
+		}
+	}()
+	g.logger.Sugar().Debugf("Gitea API returned status %d for %s", resp.StatusCode, endpoint)
+
+	if entry != nil && resp.StatusCode == http.StatusNotModified {
+		tags, err := decodeCachedTags[GiteaTag](entry)
+		return tags, entry.Next, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("Gitea API returned status %d", resp.StatusCode)
+	}
+
+	next := giteaNextPageURL(resp)
+	tags, err := decodeTags[GiteaTag](resp, g.etags, endpoint, next)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to decode Gitea API response: %w", err)
+	}
+
+	return tags, next, nil
+}
+
+// giteaNextPageURL returns the URL of the next page of a Gitea API response, or an empty string on the last page.
+// Gitea and Forgejo link the next page in the "Link" header. Without it, the next page is derived from the
+// "X-Total-Count" header and the page and limit of the request.
+func giteaNextPageURL(resp *http.Response) string {
+	if next := nextPageURL(resp); next != "" {
+		return next
+	}
+	if resp.Request == nil {
+		return ""
+	}
+
+	next := *resp.Request.URL
+	query := next.Query()
+	total, totalErr := strconv.Atoi(resp.Header.Get("X-Total-Count"))
+	page, pageErr := strconv.Atoi(query.Get("page"))
+	limit, limitErr := strconv.Atoi(query.Get("limit"))
+	if totalErr != nil || pageErr != nil || limitErr != nil || page*limit >= total {
+		return ""
+	}
+
+	query.Set("page", strconv.Itoa(page+1))
+	next.RawQuery = query.Encode()
+	return next.String()
+}
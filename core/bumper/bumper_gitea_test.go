@@ -0,0 +1,136 @@
+package bumper
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/ramonvermeulen/pre-commit-bump/core/types"
+)
+
+func TestGiteaBumper_GetVersions(t *testing.T) {
+	tests := []struct {
+		name        string
+		repoURL     string
+		expectedURL string
+	}{
+		{
+			name:        "gitea.com",
+			repoURL:     "https://gitea.com/owner/repo",
+			expectedURL: "https://gitea.com/api/v1/repos/owner/repo/tags?page=1&limit=100",
+		},
+		{
+			name:        "custom host",
+			repoURL:     "https://git.example.org/owner/repo.git",
+			expectedURL: "https://git.example.org/api/v1/repos/owner/repo/tags?page=1&limit=100",
+		},
+		{
+			name:        "custom host over http",
+			repoURL:     "http://git.example.org:3000/owner/repo",
+			expectedURL: "http://git.example.org:3000/api/v1/repos/owner/repo/tags?page=1&limit=100",
+		},
+		{
+			name:        "scp-like URL",
+			repoURL:     "git@git.example.org:owner/repo.git",
+			expectedURL: "https://git.example.org/api/v1/repos/owner/repo/tags?page=1&limit=100",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requestedURL string
+			client := &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					requestedURL = req.URL.String()
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(strings.NewReader(`[{"name": "v1.0.0"}, {"name": "v1.2.0"}, {"name": "latest"}]`)),
+						Header:     make(http.Header),
+					}, nil
+				}),
+			}
+
+			versions, err := NewGiteaBumper(zap.NewNop(), client, NewRetryPolicy(1), nil).GetVersions(t.Context(), &types.Repo{Repo: tt.repoURL})
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedURL, requestedURL)
+			assert.Equal(t, "1.2.0", findLatestVersion(versions, false).String())
+		})
+	}
+}
+
+func TestGiteaBumper_GetVersions_StatusError(t *testing.T) {
+	client := &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Body:       io.NopCloser(strings.NewReader("")),
+				Header:     make(http.Header),
+			}, nil
+		}),
+	}
+
+	_, err := NewGiteaBumper(zap.NewNop(), client, NewRetryPolicy(1), nil).GetVersions(t.Context(), &types.Repo{Repo: "https://git.example.org/owner/repo"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Gitea API returned status 404")
+}
+
+func TestGiteaBumper_GetVersions_Pagination(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers func(page string) http.Header
+	}{
+		{
+			name: "link header",
+			headers: func(page string) http.Header {
+				header := make(http.Header)
+				if page == "1" {
+					header.Set("Link", `<https://git.example.org/api/v1/repos/owner/repo/tags?page=2&limit=100>; rel="next"`)
+				}
+				return header
+			},
+		},
+		{
+			name: "total count without link header",
+			headers: func(string) http.Header {
+				header := make(http.Header)
+				header.Set("X-Total-Count", "101")
+				return header
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requestedPages []string
+			client := &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					page := req.URL.Query().Get("page")
+					requestedPages = append(requestedPages, page)
+
+					body := `[{"name": "v1.0.0"}]`
+					if page == "2" {
+						body = `[{"name": "v2.0.0"}]`
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(strings.NewReader(body)),
+						Header:     tt.headers(page),
+						Request:    req,
+					}, nil
+				}),
+			}
+
+			versions, err := NewGiteaBumper(zap.NewNop(), client, NewRetryPolicy(1), nil).GetVersions(t.Context(), &types.Repo{Repo: "https://git.example.org/owner/repo"})
+			require.NoError(t, err)
+
+			assert.Equal(t, []string{"1", "2"}, requestedPages)
+			assert.Equal(t, "2.0.0", findLatestVersion(versions, false).String(), "the latest tag is on the second page")
+		})
+	}
+}
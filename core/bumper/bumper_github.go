@@ -1,29 +1,81 @@
 package bumper
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
 	"regexp"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/ramonvermeulen/pre-commit-bump/core/utils"
 
 	"github.com/ramonvermeulen/pre-commit-bump/config"
+	"github.com/ramonvermeulen/pre-commit-bump/core/io"
 
 	"github.com/ramonvermeulen/pre-commit-bump/core/types"
+
+	"go.uber.org/zap"
 )
 
 // GithubBumper is a struct that implements the RepoBumper interface for GitHub repositories.
 type GithubBumper struct {
+	logger *zap.Logger
 	client *http.Client
+	apiURL string
+	// apiHost is the host of apiURL, the token is only sent to it and to the configured hosts
+	apiHost string
+	// hosts are the GitHub Enterprise Server hosts that are explicitly configured, their API is at "https://<host>/api/v3"
+	hosts    []string
+	token    string
+	retry    RetryPolicy
+	etags    *io.ETagCache
+	releases bool
+	// tagObjects lists the tags from the tags endpoint instead of the git refs
+	tagObjects bool
+	// tagCommits holds the commit SHA of the tags listed from the tags endpoint, keyed by tagCommitKey
+	tagCommits sync.Map
+}
+
+// GithubOptions configures the API, the authentication and the source of the versions of a GithubBumper.
+type GithubOptions struct {
+	// APIURL is the base URL of the GitHub API, the public GitHub API when empty
+	APIURL string
+	// Hosts are the GitHub Enterprise Server hosts, repositories on them use "https://<host>/api/v3"
+	Hosts []string
+	// Token authenticates the requests to the API URL and the hosts, requests are unauthenticated when empty, which
+	// are subject to a much lower rate limit
+	Token string
+	// ETags caches the responses between runs, nothing is cached when nil
+	ETags *io.ETagCache
+	// Releases reads the versions from the published releases of the repository instead of its tags
+	Releases bool
+	// TagsEndpoint selects the endpoint the tags are listed from, config.GitHubTagsEndpointRefs when empty
+	TagsEndpoint string
 }
 
-// NewGithubBumper creates a new instance of GithubBumper with the provided HTTP client.
-func NewGithubBumper(client *http.Client) *GithubBumper {
+// NewGithubBumper creates a new instance of GithubBumper with the provided logger, HTTP client, retry policy and options.
+func NewGithubBumper(logger *zap.Logger, client *http.Client, retry RetryPolicy, options GithubOptions) *GithubBumper {
+	apiURL := options.APIURL
+	if apiURL == "" {
+		apiURL = config.DefaultGitHubAPIURL
+	}
+
 	return &GithubBumper{
-		client: client,
+		logger:     logger,
+		client:     client,
+		apiURL:     strings.TrimSuffix(apiURL, "/"),
+		apiHost:    urlHost(apiURL),
+		hosts:      options.Hosts,
+		token:      options.Token,
+		retry:      retry,
+		etags:      options.ETags,
+		releases:   options.Releases,
+		tagObjects: options.TagsEndpoint == config.GitHubTagsEndpointTags,
 	}
 }
 
@@ -37,51 +89,290 @@ func (gt GitHubTag) GetTagName() string {
 	return strings.TrimPrefix(gt.Ref, "refs/tags/")
 }
 
-// GetLatestVersion retrieves the latest semantic version from a GitHub repository.
-// It takes a pointer to a types.Repo as input, fetches the tags using the GitHub API.
-// And returns the latest semantic version found or an error if no valid semantic versions are present.
-func (g *GithubBumper) GetLatestVersion(repo *types.Repo) (*types.SemanticVersion, error) {
-	repoPath := extractGitHubRepo(repo.Repo)
+// GitHubTagObject represents a tag as listed by the tags endpoint of a GitHub repository, including the commit it points to.
+type GitHubTagObject struct {
+	Name   string       `json:"name"`
+	Commit GitHubCommit `json:"commit"`
+}
+
+// GitHubCommit represents the commit a GitHub tag points to.
+type GitHubCommit struct {
+	SHA string `json:"sha"`
+	URL string `json:"url"`
+}
+
+// GetTagName returns the name of the tag.
+func (gt GitHubTagObject) GetTagName() string {
+	return gt.Name
+}
+
+// GitHubRelease represents a release of a GitHub repository.
+type GitHubRelease struct {
+	TagName    string `json:"tag_name"`
+	Draft      bool   `json:"draft"`
+	PreRelease bool   `json:"prerelease"`
+}
+
+// GetTagName returns the name of the tag the release was created from.
+func (gr GitHubRelease) GetTagName() string {
+	return gr.TagName
+}
 
-	tags, err := g.fetchTags(repoPath)
+// GetVersions retrieves the semantic versions from a GitHub repository.
+// It takes a pointer to a types.Repo as input, fetches the tags, or the releases when enabled, using the GitHub API.
+// And returns the semantic versions found or an error if no valid semantic versions are present.
+func (g *GithubBumper) GetVersions(ctx context.Context, repo *types.Repo) ([]*types.SemanticVersion, error) {
+	if g.releases {
+		return g.getReleaseVersions(ctx, repo)
+	}
+	if g.tagObjects {
+		return g.getTagObjectVersions(ctx, repo)
+	}
+
+	apiURL, err := g.repoAPIURL(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := g.fetchTags(ctx, apiURL, gitHubRepoPath(repo))
 	if err != nil {
 		return nil, err
 	}
 
-	return findLatestVersion(tags, repo)
+	return parseTagVersions(tags, repo)
+}
+
+// getReleaseVersions retrieves the semantic versions of the published releases of a GitHub repository.
+// Drafts and releases the maintainers marked as pre-release are skipped, even when their tag looks like a final version.
+func (g *GithubBumper) getReleaseVersions(ctx context.Context, repo *types.Repo) ([]*types.SemanticVersion, error) {
+	apiURL, err := g.repoAPIURL(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	repoPath := gitHubRepoPath(repo)
+	endpoint := fmt.Sprintf("%s/repos/%s/releases?per_page=%d", apiURL, repoPath, config.TagsPerPage)
+
+	releases, err := fetchGitHubPages[GitHubRelease](ctx, g, endpoint, repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	published := make([]GitHubRelease, 0, len(releases))
+	for _, release := range releases {
+		if !release.Draft && !release.PreRelease {
+			published = append(published, release)
+		}
+	}
+
+	return parseTagVersions(published, repo)
+}
+
+// getTagObjectVersions retrieves the semantic versions of a GitHub repository from the tags endpoint.
+// The commit each tag points to is remembered, so resolving the tag for --freeze needs no additional request.
+func (g *GithubBumper) getTagObjectVersions(ctx context.Context, repo *types.Repo) ([]*types.SemanticVersion, error) {
+	apiURL, err := g.repoAPIURL(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	repoPath := gitHubRepoPath(repo)
+	endpoint := fmt.Sprintf("%s/repos/%s/tags?per_page=%d", apiURL, repoPath, config.TagsPerPage)
+
+	tags, err := fetchGitHubPages[GitHubTagObject](ctx, g, endpoint, repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tag := range tags {
+		if tag.Commit.SHA != "" {
+			g.tagCommits.Store(tagCommitKey(apiURL, repoPath, tag.Name), tag.Commit.SHA)
+		}
+	}
+
+	return parseTagVersions(tags, repo)
+}
+
+// tagCommitKey identifies a tag of a repository on a GitHub API.
+func tagCommitKey(apiURL, repoPath, tag string) string {
+	return apiURL + "/" + repoPath + "@" + tag
+}
+
+// ResolveTag resolves the tag of a GitHub repository to the SHA of the commit it points to.
+// Tags listed from the tags endpoint are resolved from the commit listed with them.
+// Otherwise the commits endpoint is used, it dereferences annotated tags and returns only the SHA with the "application/vnd.github.sha" media type.
+func (g *GithubBumper) ResolveTag(ctx context.Context, repo *types.Repo, tag string) (string, error) {
+	apiURL, err := g.repoAPIURL(repo)
+	if err != nil {
+		return "", err
+	}
+
+	if sha, ok := g.tagCommits.Load(tagCommitKey(apiURL, gitHubRepoPath(repo), tag)); ok {
+		return sha.(string), nil
+	}
+
+	endpoint := fmt.Sprintf("%s/repos/%s/commits/%s", apiURL, gitHubRepoPath(repo), url.PathEscape(tag))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create GitHub API request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github.sha")
+	g.authorize(req)
+
+	body, err := resolveTagResponse(g.client, g.retry, req, "GitHub")
+	if err != nil {
+		return "", err
+	}
+
+	sha := strings.TrimSpace(string(body))
+	if sha == "" {
+		return "", fmt.Errorf("GitHub API returned no commit for tag %s", tag)
+	}
+	return sha, nil
+}
+
+// repoAPIURL returns the base URL of the API serving the repository.
+// Repositories on the host of the configured API URL use the configured API URL and repositories on github.com use the
+// public GitHub API. Repositories on one of the configured GitHub Enterprise Server hosts use "https://<host>/api/v3",
+// unless a custom API URL is configured for all of them. The API URL is never derived from other hosts, as the
+// repository URL is not trusted to name the API the requests are sent to.
+func (g *GithubBumper) repoAPIURL(repo *types.Repo) (string, error) {
+	host := repo.Host()
+	switch {
+	case host == "" || strings.EqualFold(host, g.apiHost):
+		return g.apiURL, nil
+	case strings.EqualFold(host, config.VendorGitHubHost):
+		return config.DefaultGitHubAPIURL, nil
+	case !containsHost(g.hosts, host):
+		return "", fmt.Errorf("%s is not a configured GitHub host, list it with --%s to check it with the API at https://%s/api/v3", host, config.FlagGitHubHosts, host)
+	case g.apiURL != config.DefaultGitHubAPIURL:
+		return g.apiURL, nil
+	default:
+		return "https://" + host + "/api/v3", nil
+	}
+}
+
+// authorize sets the token on a request to the configured API or to one of the explicitly configured hosts, the
+// token is not sent to any other host.
+func (g *GithubBumper) authorize(req *http.Request) {
+	if g.token != "" && (strings.EqualFold(req.URL.Host, g.apiHost) || containsHost(g.hosts, req.URL.Host)) {
+		req.Header.Set("Authorization", "Bearer "+g.token)
+	}
+}
+
+// gitHubRepoPath returns the owner and repository name of a repository on GitHub or GitHub Enterprise Server.
+func gitHubRepoPath(repo *types.Repo) string {
+	if repoPath := extractGitHubRepo(repo.Repo); repoPath != "" {
+		return repoPath
+	}
+	_, repoPath := extractHostedRepo(repo.Repo)
+	return repoPath
 }
 
 // fetchTags retrieves the tags from a GitHub repository using the GitHub API.
-// It returns a slice of GitHubTag or an error if the API call fails.
-func (g *GithubBumper) fetchTags(repoPath string) ([]GitHubTag, error) {
-	url := fmt.Sprintf("https://api.%s/repos/%s/git/refs/tags", config.VendorGitHubHost, repoPath)
+// It returns a slice of GitHubTag or an error if any API call fails.
+func (g *GithubBumper) fetchTags(ctx context.Context, apiURL, repoPath string) ([]GitHubTag, error) {
+	endpoint := fmt.Sprintf("%s/repos/%s/git/refs/tags?per_page=%d", apiURL, repoPath, config.TagsPerPage)
+	return fetchGitHubPages[GitHubTag](ctx, g, endpoint, repoPath)
+}
+
+// fetchGitHubPages retrieves the tags or releases of a GitHub repository from a GitHub API endpoint.
+// The endpoints are paginated, so the "next" links are followed until all pages are fetched or the page cap is reached.
+func fetchGitHubPages[T TagProvider](ctx context.Context, g *GithubBumper, endpoint, repoPath string) ([]T, error) {
+	var tags []T
+
+	for page := 0; endpoint != ""; page++ {
+		if page >= config.MaxTagPages {
+			return nil, fmt.Errorf("GitHub API returned more than %d pages of tags for %s", config.MaxTagPages, repoPath)
+		}
 
-	resp, err := g.client.Get(url)
+		pageTags, next, err := fetchGitHubPage[T](ctx, g, endpoint)
+		if err != nil {
+			return nil, err
+		}
+
+		tags = append(tags, pageTags...)
+		endpoint = next
+	}
+
+	g.logger.Sugar().Debugf("Fetched %d tags for %s from the GitHub API", len(tags), repoPath)
+	return tags, nil
+}
+
+// fetchGitHubPage retrieves a single page of tags or releases from the GitHub API.
+// It returns the entries on the page and the URL of the next page, which is empty on the last page.
+func fetchGitHubPage[T TagProvider](ctx context.Context, g *GithubBumper, endpoint string) ([]T, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to call GitHub API: %w", err)
+		return nil, "", fmt.Errorf("failed to create GitHub API request: %w", err)
+	}
+	g.authorize(req)
+	entry := withETag(req, g.etags, endpoint)
+
+	resp, err := g.retry.Do(g.client, req)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to call GitHub API: %w", err)
 	}
 	defer func() {
 		if closeErr := resp.Body.Close(); closeErr != nil {
 			fmt.Fprintf(os.Stderr, "Warning: failed to close response body: %v\n", closeErr)
 		}
 	}()
+	g.logger.Sugar().Debugf("GitHub API returned status %d for %s", resp.StatusCode, endpoint)
+	logRateLimitRemaining(g.logger, "GitHub", resp.Header.Get("X-RateLimit-Remaining"))
+
+	if entry != nil && resp.StatusCode == http.StatusNotModified {
+		tags, err := decodeCachedTags[T](entry)
+		return tags, entry.Next, err
+	}
+
+	if isGitHubRateLimited(resp) {
+		return nil, "", fmt.Errorf("GitHub API rate limit exceeded, resets at %s%s", gitHubRateLimitReset(resp), g.tokenHint())
+	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("GitHub API returned status %d", resp.StatusCode)
+		return nil, "", fmt.Errorf("GitHub API returned status %d", resp.StatusCode)
 	}
 
-	var tags []GitHubTag
-	if err := json.NewDecoder(resp.Body).Decode(&tags); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	next := nextPageURL(resp)
+	tags, err := decodeTags[T](resp, g.etags, endpoint, next)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	return tags, nil
+	return tags, next, nil
+}
+
+// tokenHint returns a hint to configure a token when requests are unauthenticated.
+func (g *GithubBumper) tokenHint() string {
+	if g.token != "" {
+		return ""
+	}
+	return fmt.Sprintf(", set %s or %s to raise the limit", config.EnvGitHubToken, config.EnvGitHubTokenFallback)
+}
+
+// isGitHubRateLimited reports whether the response was rejected because the rate limit is exhausted.
+func isGitHubRateLimited(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return false
+	}
+	return resp.Header.Get("X-RateLimit-Remaining") == "0"
+}
+
+// gitHubRateLimitReset formats the reset time of the rate limit from the X-RateLimit-Reset header.
+func gitHubRateLimitReset(resp *http.Response) string {
+	reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return "an unknown time"
+	}
+	return time.Unix(reset, 0).UTC().Format(time.RFC3339)
 }
 
 // extractGitHubRepo extracts the owner and repository name from a GitHub repository URL.
 // It handles both HTTPS and SSH formats, and removes the ".git" suffix if present.
 func extractGitHubRepo(repoURL string) string {
 	re := regexp.MustCompile(config.ReGitHubRepoName)
-	matches := re.FindStringSubmatch(repoURL)
+	matches := re.FindStringSubmatch(types.NormalizeRepoURL(repoURL))
 	return utils.GetGroup(re, matches, "repo_name")
 }
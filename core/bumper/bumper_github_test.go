@@ -1,9 +1,22 @@
 package bumper
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/ramonvermeulen/pre-commit-bump/config"
+	"github.com/ramonvermeulen/pre-commit-bump/core/types"
 )
 
 func TestExtractGitHubRepo(t *testing.T) {
@@ -27,6 +40,31 @@ func TestExtractGitHubRepo(t *testing.T) {
 			repoURL:  "git@github.com:owner/repo.git",
 			expected: "owner/repo",
 		},
+		{
+			name:     "git+https URL",
+			repoURL:  "git+https://github.com/owner/repo",
+			expected: "owner/repo",
+		},
+		{
+			name:     "ssh scheme URL",
+			repoURL:  "ssh://git@github.com/owner/repo.git",
+			expected: "owner/repo",
+		},
+		{
+			name:     "ssh scheme URL with port",
+			repoURL:  "ssh://git@github.com:22/owner/repo.git",
+			expected: "owner/repo",
+		},
+		{
+			name:     "git+ssh URL",
+			repoURL:  "git+ssh://git@github.com/owner/repo.git",
+			expected: "owner/repo",
+		},
+		{
+			name:     "uppercase host",
+			repoURL:  "https://GitHub.com/owner/repo",
+			expected: "owner/repo",
+		},
 		{
 			name:     "URL with trailing slash",
 			repoURL:  "https://github.com/owner/repo/",
@@ -51,3 +89,324 @@ func TestExtractGitHubRepo(t *testing.T) {
 		})
 	}
 }
+
+func TestGithubBumper_GetVersions(t *testing.T) {
+	tests := []struct {
+		name          string
+		apiURL        string
+		hosts         []string
+		repoURL       string
+		expectedURL   string
+		expectedToken bool
+		expectedError string
+	}{
+		{
+			name:          "public GitHub by default",
+			apiURL:        "",
+			repoURL:       "https://github.com/owner/repo",
+			expectedURL:   "https://api.github.com/repos/owner/repo/git/refs/tags?per_page=100",
+			expectedToken: true,
+		},
+		{
+			name:          "GitHub Enterprise Server",
+			apiURL:        "https://github.mycorp.com/api/v3/",
+			repoURL:       "git@github.mycorp.com:owner/repo.git",
+			expectedURL:   "https://github.mycorp.com/api/v3/repos/owner/repo/git/refs/tags?per_page=100",
+			expectedToken: true,
+		},
+		{
+			name:        "public GitHub with a GitHub Enterprise Server API URL",
+			apiURL:      "https://github.mycorp.com/api/v3",
+			repoURL:     "https://github.com/owner/repo",
+			expectedURL: "https://api.github.com/repos/owner/repo/git/refs/tags?per_page=100",
+		},
+		{
+			name:          "configured GitHub Enterprise Server host without API URL",
+			apiURL:        "",
+			hosts:         []string{"GHE.corp.com"},
+			repoURL:       "https://ghe.corp.com/owner/repo",
+			expectedURL:   "https://ghe.corp.com/api/v3/repos/owner/repo/git/refs/tags?per_page=100",
+			expectedToken: true,
+		},
+		{
+			name:          "host that is not configured",
+			apiURL:        "",
+			repoURL:       "https://ghe.corp.com/owner/repo",
+			expectedError: "ghe.corp.com is not a configured GitHub host, list it with --github-hosts",
+		},
+		{
+			name:          "public host in the path of another host",
+			apiURL:        "",
+			repoURL:       "https://attacker.example/github.com/owner/repo",
+			expectedError: "attacker.example is not a configured GitHub host",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requestedURL, authorization string
+			client := &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					requestedURL = req.URL.String()
+					authorization = req.Header.Get("Authorization")
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(strings.NewReader(`[{"ref": "refs/tags/v1.0.0"}, {"ref": "refs/tags/v1.1.0"}]`)),
+						Header:     make(http.Header),
+					}, nil
+				}),
+			}
+
+			versions, err := NewGithubBumper(zap.NewNop(), client, NewRetryPolicy(1), GithubOptions{APIURL: tt.apiURL, Hosts: tt.hosts, Token: "secret"}).GetVersions(t.Context(), &types.Repo{Repo: tt.repoURL})
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				assert.Empty(t, requestedURL, "no request should be sent")
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedURL, requestedURL)
+			assert.Len(t, versions, 2)
+			if tt.expectedToken {
+				assert.Equal(t, "Bearer secret", authorization)
+			} else {
+				assert.Empty(t, authorization, "the token should only be sent to the configured API and hosts")
+			}
+		})
+	}
+}
+
+func TestGithubBumper_fetchTags_Authentication(t *testing.T) {
+	tests := []struct {
+		name           string
+		token          string
+		expectedHeader string
+	}{
+		{
+			name:           "token is sent as bearer token",
+			token:          "secret",
+			expectedHeader: "Bearer secret",
+		},
+		{
+			name:           "no header without token",
+			token:          "",
+			expectedHeader: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var authorization string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				authorization = r.Header.Get("Authorization")
+				_, _ = w.Write([]byte(`[{"ref": "refs/tags/v1.0.0"}]`))
+			}))
+			defer server.Close()
+
+			tags, err := NewGithubBumper(zap.NewNop(), server.Client(), NewRetryPolicy(1), GithubOptions{APIURL: server.URL, Token: tt.token}).fetchTags(t.Context(), server.URL, "owner/repo")
+			require.NoError(t, err)
+
+			assert.Len(t, tags, 1)
+			assert.Equal(t, tt.expectedHeader, authorization)
+		})
+	}
+}
+
+func TestGithubBumper_fetchTags_RateLimited(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", "1700000000")
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	_, err := NewGithubBumper(zap.NewNop(), server.Client(), NewRetryPolicy(1), GithubOptions{APIURL: server.URL}).fetchTags(t.Context(), server.URL, "owner/repo")
+	require.Error(t, err)
+
+	assert.Contains(t, err.Error(), "rate limit exceeded, resets at 2023-11-14T22:13:20Z")
+	assert.Contains(t, err.Error(), "PCB_GITHUB_TOKEN")
+}
+
+func TestGithubBumper_fetchTags_Logging(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+		_, _ = w.Write([]byte(`[{"ref": "refs/tags/v1.0.0"}, {"ref": "refs/tags/v1.1.0"}]`))
+	}))
+	defer server.Close()
+
+	t.Run("no-op logger", func(t *testing.T) {
+		tags, err := NewGithubBumper(zap.NewNop(), server.Client(), NewRetryPolicy(1), GithubOptions{APIURL: server.URL}).fetchTags(t.Context(), server.URL, "owner/repo")
+		require.NoError(t, err)
+
+		assert.Len(t, tags, 2)
+	})
+
+	t.Run("recording logger", func(t *testing.T) {
+		core, logs := observer.New(zapcore.DebugLevel)
+		_, err := NewGithubBumper(zap.New(core), server.Client(), NewRetryPolicy(1), GithubOptions{APIURL: server.URL}).fetchTags(t.Context(), server.URL, "owner/repo")
+		require.NoError(t, err)
+
+		url := server.URL + "/repos/owner/repo/git/refs/tags?per_page=100"
+		assert.Equal(t, 1, logs.FilterMessage("GitHub API returned status 200 for "+url).Len())
+		assert.Equal(t, 1, logs.FilterMessage("GitHub API rate limit remaining: 4999").Len())
+		assert.Equal(t, 1, logs.FilterMessage("Fetched 2 tags for owner/repo from the GitHub API").Len())
+	})
+}
+
+func TestGithubBumper_GetVersions_MixedTagStyles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"ref": "refs/tags/1.0.0"}, {"ref": "refs/tags/v1.1.0"}]`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		rev      string
+		expected string
+	}{
+		{name: "bare revision stays bare", rev: "1.0.0", expected: "1.1.0"},
+		{name: "v prefixed revision stays v prefixed", rev: "v1.0.0", expected: "v1.1.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			semVer, ok := types.GetSemanticVersion(tt.rev)
+			require.True(t, ok)
+			repo := &types.Repo{Repo: "https://github.com/owner/repo", Rev: tt.rev, SemVer: semVer}
+
+			versions, err := NewGithubBumper(zap.NewNop(), publicAPIClient(server, config.DefaultGitHubAPIURL), NewRetryPolicy(1), GithubOptions{}).GetVersions(t.Context(), repo)
+			require.NoError(t, err)
+
+			latest := findLatestVersion(versions, true)
+			require.NotNil(t, latest)
+			assert.Equal(t, tt.expected, repo.FormatRevision(latest))
+		})
+	}
+}
+
+func TestGithubBumper_fetchTags_Pagination(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "":
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos/owner/repo/git/refs/tags?page=2>; rel="next", <%s/repos/owner/repo/git/refs/tags?page=2>; rel="last"`, server.URL, server.URL))
+			_, _ = w.Write([]byte(`[{"ref": "refs/tags/v1.0.0"}, {"ref": "refs/tags/v1.1.0"}]`))
+		case "2":
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos/owner/repo/git/refs/tags>; rel="prev"`, server.URL))
+			_, _ = w.Write([]byte(`[{"ref": "refs/tags/v2.0.0"}]`))
+		}
+	}))
+	defer server.Close()
+
+	versions, err := NewGithubBumper(zap.NewNop(), publicAPIClient(server, config.DefaultGitHubAPIURL), NewRetryPolicy(1), GithubOptions{}).GetVersions(t.Context(), &types.Repo{Repo: "https://github.com/owner/repo"})
+	require.NoError(t, err)
+
+	assert.Len(t, versions, 3)
+	assert.Equal(t, "2.0.0", findLatestVersion(versions, false).String())
+}
+
+func TestGithubBumper_GetVersions_Releases(t *testing.T) {
+	var requestedPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPath = r.URL.Path
+		_, _ = w.Write([]byte(`[
+			{"tag_name": "v3.0.0", "draft": true, "prerelease": false},
+			{"tag_name": "v2.1.0", "draft": false, "prerelease": true},
+			{"tag_name": "v2.0.0", "draft": false, "prerelease": false},
+			{"tag_name": "v1.9.0", "draft": false, "prerelease": false}
+		]`))
+	}))
+	defer server.Close()
+
+	versions, err := NewGithubBumper(zap.NewNop(), publicAPIClient(server, config.DefaultGitHubAPIURL), NewRetryPolicy(1), GithubOptions{Releases: true}).
+		GetVersions(t.Context(), &types.Repo{Repo: "https://github.com/owner/repo"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "/repos/owner/repo/releases", requestedPath)
+	assert.Len(t, versions, 2, "drafts and pre-releases should be skipped")
+	assert.Equal(t, "2.0.0", findLatestVersion(versions, false).String())
+}
+
+func TestGitHubTagObject_Decode(t *testing.T) {
+	payload := `[{
+		"name": "v1.2.0",
+		"zipball_url": "https://api.github.com/repos/owner/repo/zipball/v1.2.0",
+		"tarball_url": "https://api.github.com/repos/owner/repo/tarball/v1.2.0",
+		"commit": {
+			"sha": "1111111111111111111111111111111111111111",
+			"url": "https://api.github.com/repos/owner/repo/commits/1111111111111111111111111111111111111111"
+		},
+		"node_id": "MDM6UmVmMTI="
+	}]`
+
+	var tags []GitHubTagObject
+	require.NoError(t, json.Unmarshal([]byte(payload), &tags))
+
+	require.Len(t, tags, 1)
+	assert.Equal(t, "v1.2.0", tags[0].GetTagName())
+	assert.Equal(t, "1111111111111111111111111111111111111111", tags[0].Commit.SHA)
+	assert.Equal(t, "https://api.github.com/repos/owner/repo/commits/1111111111111111111111111111111111111111", tags[0].Commit.URL)
+}
+
+func TestGithubBumper_GetVersions_TagsEndpoint(t *testing.T) {
+	var requestedPaths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPaths = append(requestedPaths, r.URL.Path)
+		_, _ = w.Write([]byte(`[
+			{"name": "v1.1.0", "commit": {"sha": "1111111111111111111111111111111111111111", "url": ""}},
+			{"name": "v1.0.0", "commit": {"sha": "0000000000000000000000000000000000000000", "url": ""}},
+			{"name": "nightly", "commit": {"sha": "2222222222222222222222222222222222222222", "url": ""}}
+		]`))
+	}))
+	defer server.Close()
+
+	bumper := NewGithubBumper(zap.NewNop(), publicAPIClient(server, config.DefaultGitHubAPIURL), NewRetryPolicy(1), GithubOptions{TagsEndpoint: config.GitHubTagsEndpointTags})
+	repo := &types.Repo{Repo: "https://github.com/owner/repo"}
+
+	versions, err := bumper.GetVersions(t.Context(), repo)
+	require.NoError(t, err)
+
+	assert.Len(t, versions, 2)
+	assert.Equal(t, "1.1.0", findLatestVersion(versions, false).String())
+
+	sha, err := bumper.ResolveTag(t.Context(), repo, "v1.1.0")
+	require.NoError(t, err)
+
+	assert.Equal(t, "1111111111111111111111111111111111111111", sha)
+	assert.Equal(t, []string{"/repos/owner/repo/tags"}, requestedPaths, "the tag should be resolved from the listed commit")
+}
+
+func TestGithubBumper_fetchTags_PageCap(t *testing.T) {
+	requests := 0
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Link", fmt.Sprintf(`<%s/repos/owner/repo/git/refs/tags?page=%d>; rel="next"`, server.URL, requests+1))
+		_, _ = w.Write([]byte(`[{"ref": "refs/tags/v1.0.0"}]`))
+	}))
+	defer server.Close()
+
+	_, err := NewGithubBumper(zap.NewNop(), server.Client(), NewRetryPolicy(1), GithubOptions{APIURL: server.URL}).fetchTags(t.Context(), server.URL, "owner/repo")
+	require.Error(t, err)
+
+	assert.Contains(t, err.Error(), "more than 50 pages")
+	assert.Equal(t, config.MaxTagPages, requests)
+}
+
+func TestGithubBumper_ResolveTag(t *testing.T) {
+	var requestedPath, accept string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPath = r.URL.EscapedPath()
+		accept = r.Header.Get("Accept")
+		_, _ = w.Write([]byte("2222222222222222222222222222222222222222"))
+	}))
+	defer server.Close()
+
+	sha, err := NewGithubBumper(zap.NewNop(), publicAPIClient(server, config.DefaultGitHubAPIURL), NewRetryPolicy(1), GithubOptions{}).
+		ResolveTag(t.Context(), &types.Repo{Repo: "https://github.com/owner/repo"}, "v1.3.0")
+	require.NoError(t, err)
+
+	assert.Equal(t, "2222222222222222222222222222222222222222", sha)
+	assert.Equal(t, "/repos/owner/repo/commits/v1.3.0", requestedPath)
+	assert.Equal(t, "application/vnd.github.sha", accept)
+}
@@ -1,28 +1,71 @@
 package bumper
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
-	url2 "net/url"
+	"net/url"
 	"os"
 	"regexp"
+	"strings"
 
 	"github.com/ramonvermeulen/pre-commit-bump/config"
+	"github.com/ramonvermeulen/pre-commit-bump/core/io"
 	"github.com/ramonvermeulen/pre-commit-bump/core/utils"
 
 	"github.com/ramonvermeulen/pre-commit-bump/core/types"
+
+	"go.uber.org/zap"
 )
 
 // GitLabBumper is a struct that implements the RepoBumper interface for GitLab repositories.
 type GitLabBumper struct {
+	logger *zap.Logger
 	client *http.Client
+	apiURL string
+	// apiHost is the host of apiURL, the token is only sent to it and to the configured hosts
+	apiHost string
+	// hosts are the self-hosted GitLab hosts that are explicitly configured, their API is at "https://<host>/api/v4"
+	hosts    []string
+	token    string
+	retry    RetryPolicy
+	etags    *io.ETagCache
+	releases bool
+}
+
+// GitLabOptions configures the API, the authentication and the source of the versions of a GitLabBumper.
+type GitLabOptions struct {
+	// APIURL is the base URL of the GitLab API, the public GitLab API when empty
+	APIURL string
+	// Hosts are the self-hosted GitLab hosts, repositories on them use "https://<host>/api/v4"
+	Hosts []string
+	// Token authenticates the requests to the API URL and the hosts, requests are unauthenticated when empty, which
+	// can not access private projects
+	Token string
+	// ETags caches the responses between runs, nothing is cached when nil
+	ETags *io.ETagCache
+	// Releases reads the versions from the releases of the project instead of its tags
+	Releases bool
 }
 
-// NewGitLabBumper creates a new instance of GitLabBumper with the provided HTTP client.
-func NewGitLabBumper(client *http.Client) *GitLabBumper {
+// NewGitLabBumper creates a new instance of GitLabBumper with the provided logger, HTTP client, retry policy and options.
+func NewGitLabBumper(logger *zap.Logger, client *http.Client, retry RetryPolicy, options GitLabOptions) *GitLabBumper {
+	apiURL := options.APIURL
+	if apiURL == "" {
+		apiURL = config.DefaultGitLabAPIURL
+	}
+
 	return &GitLabBumper{
-		client: client,
+		logger:   logger,
+		client:   client,
+		apiURL:   strings.TrimSuffix(apiURL, "/"),
+		apiHost:  urlHost(apiURL),
+		hosts:    options.Hosts,
+		token:    options.Token,
+		retry:    retry,
+		etags:    options.ETags,
+		releases: options.Releases,
 	}
 }
 
@@ -36,49 +79,244 @@ func (gt GitLabTag) GetTagName() string {
 	return gt.Ref
 }
 
-// GetLatestVersion retrieves the latest semantic version from a GitLab repository.
-// It takes the repository URL as input, fetches the tags using the GitLab API,
-// and returns the latest semantic version found or an error if no valid semantic versions are present.
-func (g *GitLabBumper) GetLatestVersion(repo *types.Repo) (*types.SemanticVersion, error) {
-	gitlabRepo := extractGitLabRepo(repo.Repo)
-	url := fmt.Sprintf("https://%s/api/v4/projects/%s/repository/tags", config.VendorGitLabHost, url2.PathEscape(gitlabRepo))
+// GitLabRelease represents a release of a GitLab project.
+type GitLabRelease struct {
+	TagName  string `json:"tag_name"`
+	Upcoming bool   `json:"upcoming_release"`
+}
+
+// GetTagName returns the name of the tag the release was created from.
+func (gr GitLabRelease) GetTagName() string {
+	return gr.TagName
+}
+
+// GetVersions retrieves the semantic versions from a GitLab repository.
+// It takes the repository URL as input, fetches the tags, or the releases when enabled, using the GitLab API,
+// and returns the semantic versions found or an error if no valid semantic versions are present.
+func (g *GitLabBumper) GetVersions(ctx context.Context, repo *types.Repo) ([]*types.SemanticVersion, error) {
+	if g.releases {
+		return g.getReleaseVersions(ctx, repo)
+	}
+
+	apiURL, err := g.repoAPIURL(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("%s/projects/%s/repository/tags?per_page=%d", apiURL, url.PathEscape(gitLabRepoPath(repo)), config.TagsPerPage)
+
+	tags, err := g.fetchTags(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseTagVersions(tags, repo)
+}
+
+// getReleaseVersions retrieves the semantic versions of the releases of a GitLab project.
+// Upcoming releases, which have a release date in the future, are skipped.
+func (g *GitLabBumper) getReleaseVersions(ctx context.Context, repo *types.Repo) ([]*types.SemanticVersion, error) {
+	apiURL, err := g.repoAPIURL(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("%s/projects/%s/releases?per_page=%d", apiURL, url.PathEscape(gitLabRepoPath(repo)), config.TagsPerPage)
 
-	tags, err := g.fetchTags(url)
+	releases, err := fetchGitLabPages[GitLabRelease](ctx, g, endpoint)
 	if err != nil {
 		return nil, err
 	}
 
-	return findLatestVersion(tags, repo)
+	published := make([]GitLabRelease, 0, len(releases))
+	for _, release := range releases {
+		if !release.Upcoming {
+			published = append(published, release)
+		}
+	}
+
+	return parseTagVersions(published, repo)
+}
+
+// GitLabTagCommit represents a single tag of a GitLab repository with the commit it points to.
+type GitLabTagCommit struct {
+	Commit struct {
+		ID string `json:"id"`
+	} `json:"commit"`
+}
+
+// ResolveTag resolves the tag of a GitLab repository to the SHA of the commit it points to.
+func (g *GitLabBumper) ResolveTag(ctx context.Context, repo *types.Repo, tag string) (string, error) {
+	apiURL, err := g.repoAPIURL(repo)
+	if err != nil {
+		return "", err
+	}
+
+	endpoint := fmt.Sprintf("%s/projects/%s/repository/tags/%s", apiURL, url.PathEscape(gitLabRepoPath(repo)), url.PathEscape(tag))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create GitLab API request: %w", err)
+	}
+	g.authorize(req)
+
+	body, err := resolveTagResponse(g.client, g.retry, req, "GitLab")
+	if err != nil {
+		return "", err
+	}
+
+	var tagCommit GitLabTagCommit
+	if err := json.Unmarshal(body, &tagCommit); err != nil {
+		return "", fmt.Errorf("failed to decode GitLab API response: %w", err)
+	}
+	if tagCommit.Commit.ID == "" {
+		return "", fmt.Errorf("GitLab API returned no commit for tag %s", tag)
+	}
+	return tagCommit.Commit.ID, nil
 }
 
 // fetchTags retrieves the tags from a GitLab repository using the GitLab API.
-// It returns a slice of GitLabTag or an error if the API call fails.
-func (g *GitLabBumper) fetchTags(url string) ([]GitLabTag, error) {
-	resp, err := g.client.Get(url)
+// It returns a slice of GitLabTag or an error if any API call fails.
+func (g *GitLabBumper) fetchTags(ctx context.Context, endpoint string) ([]GitLabTag, error) {
+	return fetchGitLabPages[GitLabTag](ctx, g, endpoint)
+}
+
+// fetchGitLabPages retrieves the tags or releases from a GitLab API endpoint.
+// The endpoints are paginated, so the next pages are followed until all pages are fetched or the page cap is reached.
+func fetchGitLabPages[T TagProvider](ctx context.Context, g *GitLabBumper, endpoint string) ([]T, error) {
+	var tags []T
+	firstURL := endpoint
+
+	for page := 0; endpoint != ""; page++ {
+		if page >= config.MaxTagPages {
+			return nil, fmt.Errorf("GitLab API returned more than %d pages of tags", config.MaxTagPages)
+		}
+
+		pageTags, next, err := fetchGitLabPage[T](ctx, g, endpoint)
+		if err != nil {
+			return nil, err
+		}
+
+		tags = append(tags, pageTags...)
+		endpoint = next
+	}
+
+	g.logger.Sugar().Debugf("Fetched %d tags from %s", len(tags), firstURL)
+	return tags, nil
+}
+
+// fetchGitLabPage retrieves a single page of tags or releases from the GitLab API.
+// It returns the entries on the page and the URL of the next page, which is empty on the last page.
+func fetchGitLabPage[T TagProvider](ctx context.Context, g *GitLabBumper, endpoint string) ([]T, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create GitLab API request: %w", err)
+	}
+	g.authorize(req)
+	entry := withETag(req, g.etags, endpoint)
+
+	resp, err := g.retry.Do(g.client, req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to call GitLab API: %w", err)
+		return nil, "", fmt.Errorf("failed to call GitLab API: %w", err)
 	}
 	defer func() {
 		if closeErr := resp.Body.Close(); closeErr != nil {
 			fmt.Fprintf(os.Stderr, "Warning: failed to close response body: %v\n", closeErr)
 		}
 	}()
+	g.logger.Sugar().Debugf("GitLab API returned status %d for %s", resp.StatusCode, endpoint)
+	logRateLimitRemaining(g.logger, "GitLab", resp.Header.Get("RateLimit-Remaining"))
+
+	if entry != nil && resp.StatusCode == http.StatusNotModified {
+		tags, err := decodeCachedTags[T](entry)
+		return tags, entry.Next, err
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return nil, "", fmt.Errorf("GitLab API returned status %d%s", resp.StatusCode, g.tokenHint())
+	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("GitLab API returned status %d", resp.StatusCode)
+		return nil, "", fmt.Errorf("GitLab API returned status %d", resp.StatusCode)
 	}
 
-	var tags []GitLabTag
-	if err := json.NewDecoder(resp.Body).Decode(&tags); err != nil {
-		return nil, fmt.Errorf("failed to decode GitLab API response: %w", err)
+	next := gitLabNextPageURL(resp)
+	tags, err := decodeTags[T](resp, g.etags, endpoint, next)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to decode GitLab API response: %w", err)
 	}
 
-	return tags, nil
+	return tags, next, nil
+}
+
+// gitLabNextPageURL returns the URL of the next page of a GitLab API response.
+// It prefers the Link header and falls back to the X-Next-Page header, it returns an empty string on the last page.
+func gitLabNextPageURL(resp *http.Response) string {
+	if next := nextPageURL(resp); next != "" {
+		return next
+	}
+
+	nextPage := resp.Header.Get("X-Next-Page")
+	if nextPage == "" || resp.Request == nil {
+		return ""
+	}
+
+	next := *resp.Request.URL
+	query := next.Query()
+	query.Set("page", nextPage)
+	next.RawQuery = query.Encode()
+	return next.String()
+}
+
+// tokenHint returns a hint about the token when the GitLab API denies access.
+func (g *GitLabBumper) tokenHint() string {
+	if g.token != "" {
+		return ", the configured token may be invalid or lack the read_api scope"
+	}
+	return fmt.Sprintf(", the project may be private, set %s or %s to authenticate", config.EnvGitLabToken, config.EnvGitLabTokenFallback)
+}
+
+// repoAPIURL returns the base URL of the API serving the repository.
+// Repositories on the host of the configured API URL use the configured API URL and repositories on gitlab.com use the
+// public GitLab API. Repositories on one of the configured self-hosted GitLab hosts use "https://<host>/api/v4", unless
+// a custom API URL is configured for all of them. The API URL is never derived from other hosts, as the repository URL
+// is not trusted to name the API the requests are sent to.
+func (g *GitLabBumper) repoAPIURL(repo *types.Repo) (string, error) {
+	host := repo.Host()
+	switch {
+	case host == "" || strings.EqualFold(host, g.apiHost):
+		return g.apiURL, nil
+	case strings.EqualFold(host, config.VendorGitLabHost):
+		return config.DefaultGitLabAPIURL, nil
+	case !containsHost(g.hosts, host):
+		return "", fmt.Errorf("%s is not a configured GitLab host, list it with --%s to check it with the API at https://%s/api/v4", host, config.FlagGitLabHosts, host)
+	case g.apiURL != config.DefaultGitLabAPIURL:
+		return g.apiURL, nil
+	default:
+		return "https://" + host + "/api/v4", nil
+	}
+}
+
+// authorize sets the token on a request to the configured API or to one of the explicitly configured hosts, the
+// token is not sent to any other host.
+func (g *GitLabBumper) authorize(req *http.Request) {
+	if g.token != "" && (strings.EqualFold(req.URL.Host, g.apiHost) || containsHost(g.hosts, req.URL.Host)) {
+		req.Header.Set("PRIVATE-TOKEN", g.token)
+	}
+}
+
+// gitLabRepoPath returns the path of the project, including nested groups, on gitlab.com or a self-hosted GitLab instance.
+func gitLabRepoPath(repo *types.Repo) string {
+	if repoPath := extractGitLabRepo(repo.Repo); repoPath != "" {
+		return repoPath
+	}
+	_, repoPath, _ := strings.Cut(types.NormalizeRepoURL(repo.Repo), "/")
+	return repoPath
 }
 
 // extractGitLabRepo extracts the owner and repository name from a GitLab repository URL.
 func extractGitLabRepo(repoURL string) string {
 	re := regexp.MustCompile(config.ReGitLabRepoName)
-	matches := re.FindStringSubmatch(repoURL)
+	matches := re.FindStringSubmatch(types.NormalizeRepoURL(repoURL))
 	return utils.GetGroup(re, matches, "repo_name")
 }
@@ -1,9 +1,21 @@
 package bumper
 
 import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/ramonvermeulen/pre-commit-bump/config"
+	"github.com/ramonvermeulen/pre-commit-bump/core/types"
 )
 
 func TestExtractGitLabRepo(t *testing.T) {
@@ -32,6 +44,31 @@ func TestExtractGitLabRepo(t *testing.T) {
 			repoURL:  "git@gitlab.com:owner/repo.git",
 			expected: "owner/repo",
 		},
+		{
+			name:     "git+https URL",
+			repoURL:  "git+https://gitlab.com/owner/repo",
+			expected: "owner/repo",
+		},
+		{
+			name:     "ssh scheme URL",
+			repoURL:  "ssh://git@gitlab.com/owner/repo.git",
+			expected: "owner/repo",
+		},
+		{
+			name:     "ssh scheme URL with port",
+			repoURL:  "ssh://git@gitlab.com:22/owner/repo.git",
+			expected: "owner/repo",
+		},
+		{
+			name:     "git+ssh URL",
+			repoURL:  "git+ssh://git@gitlab.com/owner/repo.git",
+			expected: "owner/repo",
+		},
+		{
+			name:     "uppercase host",
+			repoURL:  "https://GitLab.com/owner/repo",
+			expected: "owner/repo",
+		},
 		{
 			name:     "URL with trailing slash",
 			repoURL:  "https://gitlab.com/owner/repo/",
@@ -42,6 +79,16 @@ func TestExtractGitLabRepo(t *testing.T) {
 			repoURL:  "https://gitlab.com/owner/repo?ref=main",
 			expected: "owner/repo",
 		},
+		{
+			name:     "host prefix of a different length",
+			repoURL:  "https://mirror.gitlab.com/owner/repo",
+			expected: "owner/repo",
+		},
+		{
+			name:     "self-hosted host of a different length is not sliced",
+			repoURL:  "https://gitlab.corp.io/group/repo",
+			expected: "",
+		},
 		{
 			name:     "Wrong vendor URL",
 			repoURL:  "https://bitbucket.org/owner/repo",
@@ -56,3 +103,265 @@ func TestExtractGitLabRepo(t *testing.T) {
 		})
 	}
 }
+
+func TestGitLabBumper_GetVersions_SelfHosted(t *testing.T) {
+	tests := []struct {
+		name          string
+		apiURL        string
+		hosts         []string
+		repoURL       string
+		expectedURL   string
+		expectedToken bool
+		expectedError string
+	}{
+		{
+			name:          "gitlab.com uses the public API",
+			repoURL:       "https://gitlab.com/group/project",
+			expectedURL:   "https://gitlab.com/api/v4/projects/group%2Fproject/repository/tags?per_page=100",
+			expectedToken: true,
+		},
+		{
+			name:          "configured self-hosted instance uses the API of its host",
+			hosts:         []string{"gl.corp.com"},
+			repoURL:       "https://gl.corp.com/group/subgroup/project.git",
+			expectedURL:   "https://gl.corp.com/api/v4/projects/group%2Fsubgroup%2Fproject/repository/tags?per_page=100",
+			expectedToken: true,
+		},
+		{
+			name:          "scp-like URL of a configured self-hosted instance",
+			hosts:         []string{"gl.corp.com"},
+			repoURL:       "git@gl.corp.com:group/project.git",
+			expectedURL:   "https://gl.corp.com/api/v4/projects/group%2Fproject/repository/tags?per_page=100",
+			expectedToken: true,
+		},
+		{
+			name:          "self-hosted instance of the API URL",
+			apiURL:        "https://gl.corp.com/api/v4",
+			repoURL:       "https://gl.corp.com/group/project",
+			expectedURL:   "https://gl.corp.com/api/v4/projects/group%2Fproject/repository/tags?per_page=100",
+			expectedToken: true,
+		},
+		{
+			name:        "gitlab.com with a self-hosted API URL",
+			apiURL:      "https://gl.corp.com/api/v4",
+			repoURL:     "https://gitlab.com/group/project",
+			expectedURL: "https://gitlab.com/api/v4/projects/group%2Fproject/repository/tags?per_page=100",
+		},
+		{
+			name:          "host that is not configured",
+			repoURL:       "https://gl.corp.com/group/project",
+			expectedError: "gl.corp.com is not a configured GitLab host, list it with --gitlab-hosts",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requestedURL, privateToken string
+			client := &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					requestedURL = req.URL.String()
+					privateToken = req.Header.Get("PRIVATE-TOKEN")
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(strings.NewReader(`[{"name": "v1.0.0"}, {"name": "v1.1.0"}]`)),
+						Header:     make(http.Header),
+						Request:    req,
+					}, nil
+				}),
+			}
+
+			versions, err := NewGitLabBumper(zap.NewNop(), client, NewRetryPolicy(1), GitLabOptions{APIURL: tt.apiURL, Hosts: tt.hosts, Token: "secret"}).GetVersions(t.Context(), &types.Repo{Repo: tt.repoURL})
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				assert.Empty(t, requestedURL, "no request should be sent")
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedURL, requestedURL)
+			assert.Len(t, versions, 2)
+			if tt.expectedToken {
+				assert.Equal(t, "secret", privateToken)
+			} else {
+				assert.Empty(t, privateToken, "the token should only be sent to the configured API and hosts")
+			}
+		})
+	}
+}
+
+func TestGitLabBumper_GetVersions_Releases(t *testing.T) {
+	var requestedPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPath = r.URL.EscapedPath()
+		_, _ = w.Write([]byte(`[
+			{"tag_name": "v2.1.0", "upcoming_release": true},
+			{"tag_name": "v2.0.0", "upcoming_release": false},
+			{"tag_name": "v1.5.0", "upcoming_release": false},
+			{"tag_name": "nightly", "upcoming_release": false}
+		]`))
+	}))
+	defer server.Close()
+
+	gitlabBumper := NewGitLabBumper(zap.NewNop(), publicAPIClient(server, config.DefaultGitLabAPIURL), NewRetryPolicy(1), GitLabOptions{Releases: true})
+
+	versions, err := gitlabBumper.GetVersions(t.Context(), &types.Repo{Repo: "https://gitlab.com/group/project"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "/projects/group%2Fproject/releases", requestedPath)
+	assert.Len(t, versions, 2, "upcoming releases and releases without a version should be skipped")
+	assert.Equal(t, "2.0.0", findLatestVersion(versions, false).String())
+}
+
+func TestGitLabBumper_GetVersions_PrivateProject(t *testing.T) {
+	tests := []struct {
+		name          string
+		token         string
+		expectedError string
+	}{
+		{
+			name:  "private project resolves with token",
+			token: "secret",
+		},
+		{
+			name:          "private project without token hints at token",
+			token:         "",
+			expectedError: "GitLab API returned status 401, the project may be private, set PCB_GITLAB_TOKEN",
+		},
+		{
+			name:          "private project with wrong token",
+			token:         "wrong",
+			expectedError: "GitLab API returned status 401, the configured token may be invalid",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requestedPath string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requestedPath = r.URL.EscapedPath()
+				if r.Header.Get("PRIVATE-TOKEN") != "secret" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				_, _ = w.Write([]byte(`[{"name": "v1.0.0"}, {"name": "v1.3.0"}]`))
+			}))
+			defer server.Close()
+
+			gitlabBumper := NewGitLabBumper(zap.NewNop(), publicAPIClient(server, config.DefaultGitLabAPIURL), NewRetryPolicy(1), GitLabOptions{Token: tt.token})
+
+			versions, err := gitlabBumper.GetVersions(t.Context(), &types.Repo{Repo: "https://gitlab.com/group/private"})
+
+			assert.Equal(t, "/projects/group%2Fprivate/repository/tags", requestedPath)
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "1.3.0", findLatestVersion(versions, false).String())
+		})
+	}
+}
+
+func TestGitLabBumper_fetchTags_Pagination(t *testing.T) {
+	tests := []struct {
+		name      string
+		paginator func(w http.ResponseWriter, serverURL string)
+	}{
+		{
+			name: "Link header",
+			paginator: func(w http.ResponseWriter, serverURL string) {
+				w.Header().Set("Link", fmt.Sprintf(`<%s/projects/owner%%2Frepo/repository/tags?page=2&per_page=100>; rel="next"`, serverURL))
+			},
+		},
+		{
+			name: "X-Next-Page header",
+			paginator: func(w http.ResponseWriter, serverURL string) {
+				w.Header().Set("X-Next-Page", "2")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var server *httptest.Server
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Query().Get("page") {
+				case "":
+					tt.paginator(w, server.URL)
+					_, _ = w.Write([]byte(`[{"name": "v1.0.0"}, {"name": "v1.1.0"}]`))
+				case "2":
+					_, _ = w.Write([]byte(`[{"name": "v2.0.0"}]`))
+				}
+			}))
+			defer server.Close()
+
+			gitlabBumper := NewGitLabBumper(zap.NewNop(), publicAPIClient(server, config.DefaultGitLabAPIURL), NewRetryPolicy(1), GitLabOptions{})
+
+			versions, err := gitlabBumper.GetVersions(t.Context(), &types.Repo{Repo: "https://gitlab.com/owner/repo"})
+			require.NoError(t, err)
+
+			assert.Len(t, versions, 3)
+			assert.Equal(t, "2.0.0", findLatestVersion(versions, false).String())
+		})
+	}
+}
+
+func TestGitLabBumper_fetchTags_PageCap(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("X-Next-Page", fmt.Sprint(requests+1))
+		_, _ = w.Write([]byte(`[{"name": "v1.0.0"}]`))
+	}))
+	defer server.Close()
+
+	_, err := NewGitLabBumper(zap.NewNop(), server.Client(), NewRetryPolicy(1), GitLabOptions{}).fetchTags(t.Context(), server.URL+"/projects/owner%2Frepo/repository/tags")
+	require.Error(t, err)
+
+	assert.Contains(t, err.Error(), "more than 50 pages")
+	assert.Equal(t, config.MaxTagPages, requests)
+}
+
+func TestGitLabBumper_fetchTags_Logging(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("RateLimit-Remaining", "1999")
+		_, _ = w.Write([]byte(`[{"name": "v1.0.0"}]`))
+	}))
+	defer server.Close()
+	url := server.URL + "/projects/owner%2Frepo/repository/tags"
+
+	t.Run("no-op logger", func(t *testing.T) {
+		tags, err := NewGitLabBumper(zap.NewNop(), server.Client(), NewRetryPolicy(1), GitLabOptions{}).fetchTags(t.Context(), url)
+		require.NoError(t, err)
+
+		assert.Len(t, tags, 1)
+	})
+
+	t.Run("recording logger", func(t *testing.T) {
+		core, logs := observer.New(zapcore.DebugLevel)
+		_, err := NewGitLabBumper(zap.New(core), server.Client(), NewRetryPolicy(1), GitLabOptions{}).fetchTags(t.Context(), url)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, logs.FilterMessage("GitLab API returned status 200 for "+url).Len())
+		assert.Equal(t, 1, logs.FilterMessage("GitLab API rate limit remaining: 1999").Len())
+		assert.Equal(t, 1, logs.FilterMessage("Fetched 1 tags from "+url).Len())
+	})
+}
+
+func TestGitLabBumper_ResolveTag(t *testing.T) {
+	var requestedPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPath = r.URL.EscapedPath()
+		_, _ = w.Write([]byte(`{"name": "v1.3.0", "commit": {"id": "2222222222222222222222222222222222222222"}}`))
+	}))
+	defer server.Close()
+
+	gitlabBumper := NewGitLabBumper(zap.NewNop(), publicAPIClient(server, config.DefaultGitLabAPIURL), NewRetryPolicy(1), GitLabOptions{})
+
+	sha, err := gitlabBumper.ResolveTag(t.Context(), &types.Repo{Repo: "https://gitlab.com/group/project"}, "v1.3.0")
+	require.NoError(t, err)
+
+	assert.Equal(t, "2222222222222222222222222222222222222222", sha)
+	assert.Equal(t, "/projects/group%2Fproject/repository/tags/v1.3.0", requestedPath)
+}
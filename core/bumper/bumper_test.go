@@ -1,14 +1,31 @@
 package bumper
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 
 	"github.com/ramonvermeulen/pre-commit-bump/config"
+	"github.com/ramonvermeulen/pre-commit-bump/core/io"
+	"github.com/ramonvermeulen/pre-commit-bump/core/parser"
 	"github.com/ramonvermeulen/pre-commit-bump/core/types"
 )
 
@@ -17,18 +34,123 @@ type MockRepoBumper struct {
 	mock.Mock
 }
 
-func (m *MockRepoBumper) GetLatestVersion(repo *types.Repo) (*types.SemanticVersion, error) {
+func (m *MockRepoBumper) GetVersions(ctx context.Context, repo *types.Repo) ([]*types.SemanticVersion, error) {
 	args := m.Called(repo)
-	return args.Get(0).(*types.SemanticVersion), args.Error(1)
+	return args.Get(0).([]*types.SemanticVersion), args.Error(1)
+}
+
+// MockTagResolvingBumper is a testify mock for a RepoBumper that also implements the TagResolver interface
+type MockTagResolvingBumper struct {
+	MockRepoBumper
+}
+
+func (m *MockTagResolvingBumper) ResolveTag(ctx context.Context, repo *types.Repo, tag string) (string, error) {
+	args := m.Called(repo, tag)
+	return args.String(0), args.Error(1)
+}
+
+// MockDependencyResolver is a testify mock for the DependencyResolver interface
+type MockDependencyResolver struct {
+	mock.Mock
+}
+
+func (m *MockDependencyResolver) GetVersions(ctx context.Context, name string) ([]*types.SemanticVersion, error) {
+	args := m.Called(name)
+	return args.Get(0).([]*types.SemanticVersion), args.Error(1)
+}
+
+// MockConfigVerifier is a testify mock for the io.ConfigVerifier interface
+type MockConfigVerifier struct {
+	mock.Mock
+}
+
+func (m *MockConfigVerifier) VerifyConfig(configPath string) error {
+	args := m.Called(configPath)
+	return args.Error(0)
+}
+
+// concurrencyTrackingBumper is a RepoBumper that records the maximum number of concurrent GetVersions calls
+type concurrencyTrackingBumper struct {
+	inFlight    atomic.Int32
+	maxInFlight atomic.Int32
+}
+
+func (c *concurrencyTrackingBumper) GetVersions(ctx context.Context, repo *types.Repo) ([]*types.SemanticVersion, error) {
+	current := c.inFlight.Add(1)
+	defer c.inFlight.Add(-1)
+
+	for {
+		observed := c.maxInFlight.Load()
+		if current <= observed || c.maxInFlight.CompareAndSwap(observed, current) {
+			break
+		}
+	}
+
+	time.Sleep(5 * time.Millisecond)
+	return []*types.SemanticVersion{{Major: 1, Minor: 1}}, nil
+}
+
+// blockingBumper is a RepoBumper that blocks every GetVersions call until the context is cancelled
+type blockingBumper struct {
+	calls   atomic.Int32
+	started chan struct{}
+}
+
+func (b *blockingBumper) GetVersions(ctx context.Context, repo *types.Repo) ([]*types.SemanticVersion, error) {
+	b.calls.Add(1)
+	b.started <- struct{}{}
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+// roundTripFunc allows a function to be used as an http.RoundTripper in tests
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// publicAPIClient returns a client that sends the requests to the public API at apiURL to the test server instead
+func publicAPIClient(server *httptest.Server, apiURL string) *http.Client {
+	transport := server.Client().Transport
+	return &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if rest, ok := strings.CutPrefix(req.URL.String(), apiURL); ok {
+				redirected, err := url.Parse(server.URL + rest)
+				if err != nil {
+					return nil, err
+				}
+				req = req.Clone(req.Context())
+				req.URL, req.Host = redirected, redirected.Host
+			}
+			return transport.RoundTrip(req)
+		}),
+	}
+}
+
+// writeCountingFileSystem wraps the OS file system and counts the writes per file
+type writeCountingFileSystem struct {
+	*io.OSFileSystem
+	mu     sync.Mutex
+	writes map[string]int
+}
+
+func (f *writeCountingFileSystem) WriteFile(filename string, data []byte, perm int) error {
+	f.mu.Lock()
+	f.writes[filename]++
+	f.mu.Unlock()
+	return f.OSFileSystem.WriteFile(filename, data, perm)
 }
 
 func TestBumper_checkSingleRepo(t *testing.T) {
 	tests := []struct {
 		name           string
 		repo           types.Repo
+		versions       []*types.SemanticVersion
 		latestVersion  *types.SemanticVersion
 		updaterError   error
 		allowedBump    string
+		stableOnly     bool
 		expectedUpdate bool
 		expectedError  bool
 	}{
@@ -39,6 +161,10 @@ func TestBumper_checkSingleRepo(t *testing.T) {
 				Rev:    "1.0.0",
 				SemVer: &types.SemanticVersion{Major: 1, Minor: 0, Patch: 0},
 			},
+			versions: []*types.SemanticVersion{
+				{Major: 1, Minor: 0, Patch: 0},
+				{Major: 1, Minor: 1, Patch: 0},
+			},
 			latestVersion:  &types.SemanticVersion{Major: 1, Minor: 1, Patch: 0},
 			allowedBump:    "minor",
 			expectedUpdate: true,
@@ -51,11 +177,164 @@ func TestBumper_checkSingleRepo(t *testing.T) {
 				Rev:    "1.0.0",
 				SemVer: &types.SemanticVersion{Major: 1, Minor: 0, Patch: 0},
 			},
+			versions: []*types.SemanticVersion{
+				{Major: 2, Minor: 0, Patch: 0},
+			},
 			latestVersion:  &types.SemanticVersion{Major: 2, Minor: 0, Patch: 0},
 			allowedBump:    "minor",
 			expectedUpdate: false,
 			expectedError:  false,
 		},
+		{
+			name: "allow override restricts the global allow",
+			repo: types.Repo{
+				Repo:          "https://github.com/owner/repo",
+				Rev:           "1.0.0",
+				SemVer:        &types.SemanticVersion{Major: 1, Minor: 0, Patch: 0},
+				AllowOverride: "patch",
+			},
+			versions: []*types.SemanticVersion{
+				{Major: 1, Minor: 1, Patch: 0},
+			},
+			latestVersion:  &types.SemanticVersion{Major: 1, Minor: 1, Patch: 0},
+			allowedBump:    "major",
+			expectedUpdate: false,
+			expectedError:  false,
+		},
+		{
+			name: "allow override widens the global allow",
+			repo: types.Repo{
+				Repo:          "https://github.com/owner/repo",
+				Rev:           "1.0.0",
+				SemVer:        &types.SemanticVersion{Major: 1, Minor: 0, Patch: 0},
+				AllowOverride: "major",
+			},
+			versions: []*types.SemanticVersion{
+				{Major: 2, Minor: 0, Patch: 0},
+			},
+			latestVersion:  &types.SemanticVersion{Major: 2, Minor: 0, Patch: 0},
+			allowedBump:    "patch",
+			expectedUpdate: true,
+			expectedError:  false,
+		},
+		{
+			name: "constraint pins the repo under the latest major",
+			repo: types.Repo{
+				Repo:       "https://github.com/owner/repo",
+				Rev:        "1.0.0",
+				SemVer:     &types.SemanticVersion{Major: 1, Minor: 0, Patch: 0},
+				Constraint: "<2.0.0",
+			},
+			versions: []*types.SemanticVersion{
+				{Major: 1, Minor: 0, Patch: 0},
+				{Major: 1, Minor: 4, Patch: 2},
+				{Major: 2, Minor: 0, Patch: 0},
+			},
+			latestVersion:  &types.SemanticVersion{Major: 1, Minor: 4, Patch: 2},
+			allowedBump:    "major",
+			expectedUpdate: true,
+			expectedError:  false,
+		},
+		{
+			name: "constraint excludes every newer version",
+			repo: types.Repo{
+				Repo:       "https://github.com/owner/repo",
+				Rev:        "1.4.2",
+				SemVer:     &types.SemanticVersion{Major: 1, Minor: 4, Patch: 2},
+				Constraint: ">=1.0, <2",
+			},
+			versions: []*types.SemanticVersion{
+				{Major: 1, Minor: 4, Patch: 2},
+				{Major: 2, Minor: 0, Patch: 0},
+			},
+			latestVersion:  &types.SemanticVersion{Major: 1, Minor: 4, Patch: 2},
+			allowedBump:    "major",
+			expectedUpdate: false,
+			expectedError:  false,
+		},
+		{
+			name: "only newer version is a pre-release under stable-only",
+			repo: types.Repo{
+				Repo:   "https://github.com/owner/repo",
+				Rev:    "1.0.0",
+				SemVer: &types.SemanticVersion{Major: 1, Minor: 0, Patch: 0},
+			},
+			versions: []*types.SemanticVersion{
+				{Major: 1, Minor: 0, Patch: 0},
+				{Major: 1, Minor: 1, Patch: 0, PreRelease: "alpha.1"},
+			},
+			latestVersion:  &types.SemanticVersion{Major: 1, Minor: 0, Patch: 0},
+			allowedBump:    "major",
+			stableOnly:     true,
+			expectedUpdate: false,
+			expectedError:  false,
+		},
+		{
+			name: "stable release is not bumped to a pre-release",
+			repo: types.Repo{
+				Repo:   "https://github.com/owner/repo",
+				Rev:    "1.0.0",
+				SemVer: &types.SemanticVersion{Major: 1, Minor: 0, Patch: 0},
+			},
+			versions: []*types.SemanticVersion{
+				{Major: 1, Minor: 0, Patch: 0},
+				{Major: 1, Minor: 1, Patch: 0, PreRelease: "alpha.1"},
+			},
+			latestVersion:  &types.SemanticVersion{Major: 1, Minor: 0, Patch: 0},
+			allowedBump:    "major",
+			expectedUpdate: false,
+			expectedError:  false,
+		},
+		{
+			name: "pinned pre-release is bumped to a newer pre-release without stable-only",
+			repo: types.Repo{
+				Repo:   "https://github.com/owner/repo",
+				Rev:    "1.1.0-alpha.1",
+				SemVer: &types.SemanticVersion{Major: 1, Minor: 1, Patch: 0, PreRelease: "alpha.1"},
+			},
+			versions: []*types.SemanticVersion{
+				{Major: 1, Minor: 0, Patch: 0},
+				{Major: 1, Minor: 1, Patch: 0, PreRelease: "alpha.1"},
+				{Major: 1, Minor: 1, Patch: 0, PreRelease: "beta.1"},
+			},
+			latestVersion:  &types.SemanticVersion{Major: 1, Minor: 1, Patch: 0, PreRelease: "beta.1"},
+			allowedBump:    "major",
+			expectedUpdate: true,
+			expectedError:  false,
+		},
+		{
+			name: "pinned pre-release moves to stable release under stable-only",
+			repo: types.Repo{
+				Repo:   "https://github.com/owner/repo",
+				Rev:    "1.0.0-rc.1",
+				SemVer: &types.SemanticVersion{Major: 1, Minor: 0, Patch: 0, PreRelease: "rc.1"},
+			},
+			versions: []*types.SemanticVersion{
+				{Major: 1, Minor: 0, Patch: 0, PreRelease: "rc.1"},
+				{Major: 1, Minor: 0, Patch: 0},
+			},
+			latestVersion:  &types.SemanticVersion{Major: 1, Minor: 0, Patch: 0},
+			allowedBump:    "patch",
+			stableOnly:     true,
+			expectedUpdate: true,
+			expectedError:  false,
+		},
+		{
+			name: "only pre-releases available under stable-only",
+			repo: types.Repo{
+				Repo:   "https://github.com/owner/repo",
+				Rev:    "0.1.0-alpha",
+				SemVer: &types.SemanticVersion{Major: 0, Minor: 1, Patch: 0, PreRelease: "alpha"},
+			},
+			versions: []*types.SemanticVersion{
+				{Major: 0, Minor: 1, Patch: 0, PreRelease: "alpha"},
+				{Major: 0, Minor: 1, Patch: 0, PreRelease: "beta"},
+			},
+			allowedBump:    "major",
+			stableOnly:     true,
+			expectedUpdate: false,
+			expectedError:  false,
+		},
 		{
 			name: "updater returns error",
 			repo: types.Repo{
@@ -74,18 +353,19 @@ func TestBumper_checkSingleRepo(t *testing.T) {
 			mockUpdater := new(MockRepoBumper)
 
 			if tt.updaterError != nil {
-				mockUpdater.On("GetLatestVersion", &tt.repo).Return((*types.SemanticVersion)(nil), tt.updaterError)
+				mockUpdater.On("GetVersions", &tt.repo).Return(([]*types.SemanticVersion)(nil), tt.updaterError)
 			} else {
-				mockUpdater.On("GetLatestVersion", &tt.repo).Return(tt.latestVersion, nil)
+				mockUpdater.On("GetVersions", &tt.repo).Return(tt.versions, nil)
 			}
 
 			cfg := &config.Config{
-				Allow:  tt.allowedBump,
-				Logger: zap.NewNop(),
+				Allow:      tt.allowedBump,
+				StableOnly: tt.stableOnly,
+				Logger:     zap.NewNop(),
 			}
 			bumper := &Bumper{cfg: cfg}
 
-			result := bumper.checkSingleRepo(tt.repo, mockUpdater)
+			result := bumper.checkSingleRepo(t.Context(), tt.repo, mockUpdater)
 
 			if tt.expectedError {
 				assert.Error(t, result.Error, "Expected error but got none")
@@ -96,7 +376,7 @@ func TestBumper_checkSingleRepo(t *testing.T) {
 			assert.Equal(t, tt.expectedUpdate, result.UpdateRequired, "UpdateRequired mismatch")
 			assert.Equal(t, tt.repo, result.Repo, "Repo should match")
 
-			if !tt.expectedError && tt.latestVersion != nil {
+			if !tt.expectedError {
 				assert.Equal(t, tt.latestVersion, result.LatestVersion, "LatestVersion should match")
 			}
 
@@ -105,156 +385,2299 @@ func TestBumper_checkSingleRepo(t *testing.T) {
 	}
 }
 
-func TestFindLatestVersionGitHub(t *testing.T) {
+func TestBumper_checkSingleRepo_LatestStableAndPrerelease(t *testing.T) {
 	tests := []struct {
-		name        string
-		tags        []GitHubTag
-		repo        *types.Repo
-		expectedVer *types.SemanticVersion
-		expectError bool
+		name             string
+		current          *types.SemanticVersion
+		versions         []*types.SemanticVersion
+		latestStable     *types.SemanticVersion
+		latestPrerelease *types.SemanticVersion
+		bumpVersion      *types.SemanticVersion
 	}{
 		{
-			name:        "empty tag list",
-			tags:        []GitHubTag{},
-			repo:        &types.Repo{Repo: "test/repo", Rev: "1.0.0"},
-			expectError: true,
-		},
-		{
-			name: "no valid semantic version tags",
-			tags: []GitHubTag{
-				{Ref: "refs/tags/invalid-tag"},
-				{Ref: "refs/tags/not-semver"},
+			name:    "pre-release newer than the latest stable release",
+			current: &types.SemanticVersion{Major: 1, Minor: 1},
+			versions: []*types.SemanticVersion{
+				{Major: 1, Minor: 1},
+				{Major: 1, Minor: 2},
+				{Major: 1, Minor: 3, PreRelease: "rc.1"},
+				{Major: 1, Minor: 2, PreRelease: "rc.1"},
 			},
-			repo:        &types.Repo{Repo: "test/repo", Rev: "1.0.0"},
-			expectError: true,
+			latestStable:     &types.SemanticVersion{Major: 1, Minor: 2},
+			latestPrerelease: &types.SemanticVersion{Major: 1, Minor: 3, PreRelease: "rc.1"},
+			bumpVersion:      &types.SemanticVersion{Major: 1, Minor: 2},
 		},
 		{
-			name: "finds latest semantic version",
-			tags: []GitHubTag{
-				{Ref: "refs/tags/v1.0.0"},
-				{Ref: "refs/tags/v2.1.0"},
-				{Ref: "refs/tags/v1.5.0"},
+			name:    "pre-release older than the latest stable release",
+			current: &types.SemanticVersion{Major: 1, Minor: 1},
+			versions: []*types.SemanticVersion{
+				{Major: 1, Minor: 1},
+				{Major: 1, Minor: 2, PreRelease: "rc.1"},
+				{Major: 1, Minor: 2},
 			},
-			expectedVer: &types.SemanticVersion{Major: 2, Minor: 1, Patch: 0},
-			expectError: false,
+			latestStable: &types.SemanticVersion{Major: 1, Minor: 2},
+			bumpVersion:  &types.SemanticVersion{Major: 1, Minor: 2},
 		},
 		{
-			name: "mixed valid and invalid tags",
-			tags: []GitHubTag{
-				{Ref: "refs/tags/invalid"},
-				{Ref: "refs/tags/v1.2.3"},
-				{Ref: "refs/tags/not-semver"},
-				{Ref: "refs/tags/v0.9.0"},
+			name:    "current pre-release is bumped to the newest pre-release",
+			current: &types.SemanticVersion{Major: 1, Minor: 3, PreRelease: "rc.1"},
+			versions: []*types.SemanticVersion{
+				{Major: 1, Minor: 2},
+				{Major: 1, Minor: 3, PreRelease: "rc.1"},
+				{Major: 1, Minor: 3, PreRelease: "rc.2"},
 			},
-			expectedVer: &types.SemanticVersion{Major: 1, Minor: 2, Patch: 3},
-			expectError: false,
+			latestStable:     &types.SemanticVersion{Major: 1, Minor: 2},
+			latestPrerelease: &types.SemanticVersion{Major: 1, Minor: 3, PreRelease: "rc.2"},
+			bumpVersion:      &types.SemanticVersion{Major: 1, Minor: 3, PreRelease: "rc.2"},
 		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := types.Repo{Repo: "https://github.com/owner/repo", Rev: tt.current.String(), SemVer: tt.current}
+			mockUpdater := new(MockRepoBumper)
+			mockUpdater.On("GetVersions", &repo).Return(tt.versions, nil)
+
+			bumper := &Bumper{cfg: &config.Config{Allow: config.BumpMajor, Logger: zap.NewNop()}}
+			result := bumper.checkSingleRepo(t.Context(), repo, mockUpdater)
+
+			require.NoError(t, result.Error)
+			assert.Equal(t, tt.latestStable, result.LatestStable)
+			assert.Equal(t, tt.latestPrerelease, result.LatestPrerelease)
+			assert.True(t, result.UpdateRequired)
+			assert.Equal(t, tt.bumpVersion, result.BumpVersion())
+		})
+	}
+}
+
+func TestBumper_checkSingleRepo_Candidates(t *testing.T) {
+	repo := types.Repo{
+		Repo:   "https://github.com/owner/repo",
+		Rev:    "1.0.0",
+		SemVer: &types.SemanticVersion{Major: 1},
+	}
+	versions := []*types.SemanticVersion{
+		{Major: 3},
+		{Major: 1, Patch: 4},
+		{Major: 1},
+		{Major: 1, Minor: 2},
+		{Major: 3, Minor: 1, PreRelease: "rc.1"},
+	}
+
+	mockUpdater := new(MockRepoBumper)
+	mockUpdater.On("GetVersions", &repo).Return(versions, nil)
+
+	bumper := &Bumper{cfg: &config.Config{
+		Allow:      config.BumpPatch,
+		StableOnly: true,
+		Logger:     zap.NewNop(),
+	}}
+
+	result := bumper.checkSingleRepo(t.Context(), repo, mockUpdater)
+
+	require.NoError(t, result.Error)
+	assert.True(t, result.UpdateRequired)
+	assert.Equal(t, &types.SemanticVersion{Major: 3}, result.LatestVersion)
+	assert.Equal(t, &types.SemanticVersion{Major: 1, Patch: 4}, result.AllowedVersion)
+	assert.Equal(t, []*types.SemanticVersion{
+		{Major: 1},
+		{Major: 1, Patch: 4},
+		{Major: 1, Minor: 2},
+		{Major: 3},
+	}, result.Candidates)
+	assert.Equal(t, &types.SemanticVersion{Major: 3}, versions[0], "the cached versions should not be reordered")
+}
+
+func TestBumper_checkSingleRepo_HighestAllowedBelowLatest(t *testing.T) {
+	repo := types.Repo{
+		Repo:   "https://github.com/owner/repo",
+		Rev:    "1.0.0",
+		SemVer: &types.SemanticVersion{Major: 1},
+	}
+
+	mockUpdater := new(MockRepoBumper)
+	mockUpdater.On("GetVersions", &repo).Return([]*types.SemanticVersion{{Major: 1, Patch: 1}, {Major: 2}}, nil)
+
+	bumper := &Bumper{cfg: &config.Config{
+		Allow:  config.BumpPatch,
+		Logger: zap.NewNop(),
+	}}
+
+	result := bumper.checkSingleRepo(t.Context(), repo, mockUpdater)
+
+	require.NoError(t, result.Error)
+	assert.True(t, result.UpdateRequired)
+	assert.Equal(t, &types.SemanticVersion{Major: 2}, result.LatestVersion)
+	assert.Equal(t, &types.SemanticVersion{Major: 1, Patch: 1}, result.BumpVersion())
+}
+
+func TestBumper_checkSingleRepo_Frozen(t *testing.T) {
+	repo := types.Repo{
+		Repo:   "https://github.com/owner/repo",
+		Rev:    "1111111111111111111111111111111111111111",
+		Frozen: "v1.0.0",
+		SemVer: &types.SemanticVersion{Major: 1, Original: "1.0.0"},
+	}
+	versions := []*types.SemanticVersion{{Major: 1, Minor: 1}}
+	bumper := &Bumper{cfg: &config.Config{Allow: config.BumpMajor, Logger: zap.NewNop()}}
+
+	t.Run("resolves the tag of the bumped version", func(t *testing.T) {
+		mockUpdater := new(MockTagResolvingBumper)
+		mockUpdater.On("GetVersions", &repo).Return(versions, nil)
+		mockUpdater.On("ResolveTag", &repo, "v1.1.0").Return("2222222222222222222222222222222222222222", nil)
+
+		result := bumper.checkSingleRepo(t.Context(), repo, mockUpdater)
+
+		require.NoError(t, result.Error)
+		assert.True(t, result.UpdateRequired)
+		assert.Equal(t, "2222222222222222222222222222222222222222", result.FrozenRev)
+		mockUpdater.AssertExpectations(t)
+	})
+
+	t.Run("fails when the tag cannot be resolved", func(t *testing.T) {
+		mockUpdater := new(MockTagResolvingBumper)
+		mockUpdater.On("GetVersions", &repo).Return(versions, nil)
+		mockUpdater.On("ResolveTag", &repo, "v1.1.0").Return("", errors.New("not found"))
+
+		result := bumper.checkSingleRepo(t.Context(), repo, mockUpdater)
+
+		assert.ErrorContains(t, result.Error, "failed to resolve frozen revision")
+		assert.False(t, result.UpdateRequired)
+	})
+
+	t.Run("fails when the vendor cannot resolve tags", func(t *testing.T) {
+		mockUpdater := new(MockRepoBumper)
+		mockUpdater.On("GetVersions", &repo).Return(versions, nil)
+
+		result := bumper.checkSingleRepo(t.Context(), repo, mockUpdater)
+
+		assert.ErrorContains(t, result.Error, "does not support resolving tags")
+	})
+}
+
+func TestBumper_checkSingleRepo_Freeze(t *testing.T) {
+	const sha = "2222222222222222222222222222222222222222"
+
+	tests := []struct {
+		name         string
+		repoURL      string
+		newUpdater   func(server *httptest.Server) RepoBumper
+		resolvePath  string
+		resolveReply string
+	}{
 		{
-			name: "pre-release versions",
-			tags: []GitHubTag{
-				{Ref: "refs/tags/v1.0.0"},
-				{Ref: "refs/tags/v1.1.0-alpha.1"},
-				{Ref: "refs/tags/v1.0.5"},
+			name:    "GitHub",
+			repoURL: "https://github.com/owner/repo",
+			newUpdater: func(server *httptest.Server) RepoBumper {
+				return NewGithubBumper(zap.NewNop(), publicAPIClient(server, config.DefaultGitHubAPIURL), NewRetryPolicy(1), GithubOptions{})
 			},
-			expectedVer: &types.SemanticVersion{Major: 1, Minor: 1, Patch: 0, PreRelease: "alpha.1"},
-			expectError: false,
+			resolvePath:  "/repos/owner/repo/commits/v1.1.0",
+			resolveReply: sha,
 		},
 		{
-			name: "tags without refs/tags prefix",
-			tags: []GitHubTag{
-				{Ref: "v1.0.0"},
-				{Ref: "v2.0.0"},
-				{Ref: "v1.5.0"},
+			name:    "GitLab",
+			repoURL: "https://gitlab.com/group/project",
+			newUpdater: func(server *httptest.Server) RepoBumper {
+				return NewGitLabBumper(zap.NewNop(), publicAPIClient(server, config.DefaultGitLabAPIURL), NewRetryPolicy(1), GitLabOptions{})
 			},
-			expectedVer: &types.SemanticVersion{Major: 2, Minor: 0, Patch: 0},
-			expectError: false,
+			resolvePath:  "/projects/group%2Fproject/repository/tags/v1.1.0",
+			resolveReply: `{"name": "v1.1.0", "commit": {"id": "` + sha + `"}}`,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := findLatestVersion(tt.tags, tt.repo)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.URL.EscapedPath() == tt.resolvePath:
+					_, _ = w.Write([]byte(tt.resolveReply))
+				case strings.HasSuffix(r.URL.Path, "/git/refs/tags"):
+					_, _ = w.Write([]byte(`[{"ref": "refs/tags/v1.0.0"}, {"ref": "refs/tags/v1.1.0"}]`))
+				case strings.HasSuffix(r.URL.Path, "/repository/tags"):
+					_, _ = w.Write([]byte(`[{"name": "v1.0.0"}, {"name": "v1.1.0"}]`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
 
-			assertFindLatestVersionResult(t, result, err, tt.expectedVer, tt.expectError)
+			repo := types.Repo{Repo: tt.repoURL, Rev: "v1.0.0", SemVer: &types.SemanticVersion{Major: 1, Original: "1.0.0"}}
+			bumper := &Bumper{cfg: &config.Config{Allow: config.BumpMajor, Freeze: true, Logger: zap.NewNop()}}
+
+			result := bumper.checkSingleRepo(t.Context(), repo, tt.newUpdater(server))
+
+			require.NoError(t, result.Error)
+			assert.True(t, result.UpdateRequired)
+			assert.Equal(t, sha, result.FrozenRev)
 		})
 	}
+
+	t.Run("not resolved without freeze", func(t *testing.T) {
+		repo := types.Repo{Repo: "https://github.com/owner/repo", Rev: "v1.0.0", SemVer: &types.SemanticVersion{Major: 1, Original: "1.0.0"}}
+		mockUpdater := new(MockTagResolvingBumper)
+		mockUpdater.On("GetVersions", &repo).Return([]*types.SemanticVersion{{Major: 1, Minor: 1}}, nil)
+		bumper := &Bumper{cfg: &config.Config{Allow: config.BumpMajor, Logger: zap.NewNop()}}
+
+		result := bumper.checkSingleRepo(t.Context(), repo, mockUpdater)
+
+		require.NoError(t, result.Error)
+		assert.Empty(t, result.FrozenRev)
+		mockUpdater.AssertNotCalled(t, "ResolveTag", mock.Anything, mock.Anything)
+	})
 }
 
-func TestFindLatestVersionGitLab(t *testing.T) {
+func TestBumper_checkSingleRepo_Unpublished(t *testing.T) {
 	tests := []struct {
-		name        string
-		tags        []GitLabTag
-		repo        *types.Repo
-		expectedVer *types.SemanticVersion
-		expectError bool
+		name                string
+		current             *types.SemanticVersion
+		versions            []*types.SemanticVersion
+		expectedUnpublished bool
+		expectedUpdate      bool
 	}{
 		{
-			name:        "empty tag list",
-			tags:        []GitLabTag{},
-			repo:        &types.Repo{Repo: "test/repo", Rev: "1.0.0"},
-			expectError: true,
-		},
-		{
-			name: "no valid semantic version tags",
-			tags: []GitLabTag{
-				{Ref: "invalid-tag"},
-				{Ref: "not-semver"},
-			},
-			repo:        &types.Repo{Repo: "test/repo", Rev: "1.0.0"},
-			expectError: true,
-		},
-		{
-			name: "finds latest semantic version",
-			tags: []GitLabTag{
-				{Ref: "v1.0.0"},
-				{Ref: "v2.1.0"},
-				{Ref: "v1.5.0"},
-			},
-			expectedVer: &types.SemanticVersion{Major: 2, Minor: 1, Patch: 0},
-			expectError: false,
+			name:                "current version newer than all tags",
+			current:             &types.SemanticVersion{Major: 2},
+			versions:            []*types.SemanticVersion{{Major: 1}, {Major: 1, Minor: 5}},
+			expectedUnpublished: true,
 		},
 		{
-			name: "mixed valid and invalid tags",
-			tags: []GitLabTag{
-				{Ref: "invalid"},
-				{Ref: "v1.2.3"},
-				{Ref: "not-semver"},
-				{Ref: "v0.9.0"},
-			},
-			expectedVer: &types.SemanticVersion{Major: 1, Minor: 2, Patch: 3},
-			expectError: false,
+			name:                "current version absent between tags",
+			current:             &types.SemanticVersion{Major: 1, Minor: 2},
+			versions:            []*types.SemanticVersion{{Major: 1, Minor: 1}, {Major: 1, Minor: 3}},
+			expectedUnpublished: true,
+			expectedUpdate:      true,
 		},
 		{
-			name: "pre-release versions",
-			tags: []GitLabTag{
-				{Ref: "v1.0.0"},
-				{Ref: "v1.1.0-alpha.1"},
-				{Ref: "v1.0.5"},
-			},
-			expectedVer: &types.SemanticVersion{Major: 1, Minor: 1, Patch: 0, PreRelease: "alpha.1"},
-			expectError: false,
+			name:           "current version published",
+			current:        &types.SemanticVersion{Major: 1, Minor: 2},
+			versions:       []*types.SemanticVersion{{Major: 1, Minor: 2}, {Major: 1, Minor: 3}},
+			expectedUpdate: true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := findLatestVersion(tt.tags, tt.repo)
+			repo := types.Repo{Repo: "https://github.com/owner/repo", Rev: "v" + tt.current.String(), SemVer: tt.current}
+			mockUpdater := new(MockRepoBumper)
+			mockUpdater.On("GetVersions", &repo).Return(tt.versions, nil)
+			bumper := &Bumper{cfg: &config.Config{Allow: config.BumpMajor, Logger: zap.NewNop()}}
 
-			assertFindLatestVersionResult(t, result, err, tt.expectedVer, tt.expectError)
+			result := bumper.checkSingleRepo(t.Context(), repo, mockUpdater)
+
+			require.NoError(t, result.Error)
+			assert.Equal(t, tt.expectedUnpublished, result.Unpublished)
+			assert.Equal(t, tt.expectedUpdate, result.UpdateRequired)
+		})
+	}
+}
+
+func TestFindAllowedVersion(t *testing.T) {
+	candidates := []*types.SemanticVersion{{Major: 1}, {Major: 1, Patch: 1}, {Major: 1, Minor: 1}, {Major: 2}}
+	current := &types.SemanticVersion{Major: 1}
+
+	assert.Equal(t, &types.SemanticVersion{Major: 2}, findAllowedVersion(candidates, current, config.BumpMajor))
+	assert.Equal(t, &types.SemanticVersion{Major: 1, Minor: 1}, findAllowedVersion(candidates, current, config.BumpMinor))
+	assert.Equal(t, &types.SemanticVersion{Major: 1, Patch: 1}, findAllowedVersion(candidates, current, config.BumpPatch))
+	assert.Nil(t, findAllowedVersion(candidates, current, config.BumpNone))
+	assert.Nil(t, findAllowedVersion(candidates, &types.SemanticVersion{Major: 2}, config.BumpMajor))
+}
+
+func TestBumper_checkSingleRepo_BuildMetadata(t *testing.T) {
+	tests := []struct {
+		name           string
+		current        string
+		tags           []string
+		expectedUpdate bool
+		expectedLatest string
+	}{
+		{
+			name:           "other build of the current version is not a bump",
+			current:        "1.0.0+a",
+			tags:           []string{"1.0.0+a", "1.0.0+b"},
+			expectedLatest: "1.0.0+a",
+		},
+		{
+			name:           "current build is preferred over an earlier build of the same version",
+			current:        "1.0.0+b",
+			tags:           []string{"1.0.0+a", "1.0.0+b"},
+			expectedLatest: "1.0.0+b",
+		},
+		{
+			name:           "bump prefers the build metadata of the current version",
+			current:        "1.0.0+a",
+			tags:           []string{"1.1.0+b", "1.0.0+a", "1.1.0+a"},
+			expectedUpdate: true,
+			expectedLatest: "1.1.0+a",
+		},
+		{
+			name:           "bump keeps the first build without a matching build",
+			current:        "1.0.0",
+			tags:           []string{"1.1.0+b", "1.0.0", "1.1.0+a"},
+			expectedUpdate: true,
+			expectedLatest: "1.1.0+b",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current, ok := types.GetSemanticVersion(tt.current)
+			require.True(t, ok)
+			var versions []*types.SemanticVersion
+			for _, tag := range tt.tags {
+				version, ok := types.GetSemanticVersion(tag)
+				require.True(t, ok)
+				versions = append(versions, version)
+			}
+
+			repo := types.Repo{Repo: "https://github.com/owner/repo", Rev: tt.current, SemVer: current}
+			mockUpdater := new(MockRepoBumper)
+			mockUpdater.On("GetVersions", &repo).Return(versions, nil)
+
+			bumper := &Bumper{cfg: &config.Config{Allow: config.BumpMajor, Logger: zap.NewNop()}}
+			result := bumper.checkSingleRepo(t.Context(), repo, mockUpdater)
+
+			require.NoError(t, result.Error)
+			assert.False(t, result.Unpublished)
+			assert.Equal(t, tt.expectedUpdate, result.UpdateRequired)
+			assert.Equal(t, tt.expectedLatest, result.LatestVersion.String())
+			if tt.expectedUpdate {
+				assert.Equal(t, tt.expectedLatest, result.BumpVersion().String())
+				assert.Equal(t, tt.expectedLatest, repo.FormatRevision(result.BumpVersion()))
+			}
+		})
+	}
+}
+
+func TestBumper_processUpdateResults_SummaryPolicy(t *testing.T) {
+	for _, allow := range []string{config.BumpMajor, config.BumpMinor, config.BumpPatch} {
+		t.Run(allow, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			t.Setenv(config.EnvGitHubStepSummary, "")
+			require.NoError(t, os.WriteFile(".pre-commit-config.yaml", []byte("repos:\n  - repo: https://github.com/owner/repo\n    rev: v1.0.0\n"), 0644))
+
+			cfg := &config.Config{
+				Allow:  allow,
+				Logger: zap.NewNop(),
+			}
+			filesystem := io.NewOSFileSystem()
+			bumper := &Bumper{cfg: cfg, fileWriter: io.NewResultWriter(filesystem, new(bytes.Buffer), cfg.Logger)}
+
+			results := []types.UpdateResult{{
+				ConfigPath:     ".pre-commit-config.yaml",
+				Repo:           types.Repo{Repo: "https://github.com/owner/repo", Rev: "v1.0.0", SemVer: &types.SemanticVersion{Major: 1, Original: "1.0.0"}},
+				LatestVersion:  &types.SemanticVersion{Major: 1, Patch: 1},
+				UpdateRequired: true,
+			}}
+			require.NoError(t, bumper.processUpdateResults(results))
+
+			summary, err := os.ReadFile("summary.md")
+			require.NoError(t, err)
+			assert.Contains(t, string(summary), fmt.Sprintf("**Update Policy**: Only %s version updates are allowed", allow))
+		})
+	}
+}
+
+func TestBumper_processUpdateResults_AlwaysSummary(t *testing.T) {
+	tests := []struct {
+		name          string
+		alwaysSummary bool
+		noSummary     bool
+		dryRun        bool
+		expected      bool
+	}{
+		{
+			name:     "no summary without updates by default",
+			expected: false,
+		},
+		{
+			name:          "always summary without updates",
+			alwaysSummary: true,
+			expected:      true,
+		},
+		{
+			name:          "always summary on a dry run",
+			alwaysSummary: true,
+			dryRun:        true,
+			expected:      true,
+		},
+		{
+			name:          "no summary takes precedence",
+			alwaysSummary: true,
+			noSummary:     true,
+			expected:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			t.Setenv(config.EnvGitHubStepSummary, "")
+			content := []byte("repos:\n  - repo: https://github.com/owner/repo\n    rev: v1.0.0\n")
+			require.NoError(t, os.WriteFile(".pre-commit-config.yaml", content, 0644))
+
+			cfg := &config.Config{
+				Allow:         config.BumpMajor,
+				AlwaysSummary: tt.alwaysSummary,
+				NoSummary:     tt.noSummary,
+				DryRun:        tt.dryRun,
+				Logger:        zap.NewNop(),
+			}
+			bumper := &Bumper{cfg: cfg, fileWriter: io.NewResultWriter(io.NewOSFileSystem(), new(bytes.Buffer), cfg.Logger)}
+
+			results := []types.UpdateResult{{
+				ConfigPath:    ".pre-commit-config.yaml",
+				Repo:          types.Repo{Repo: "https://github.com/owner/repo", Rev: "v1.0.0", SemVer: &types.SemanticVersion{Major: 1, Original: "1.0.0"}},
+				LatestVersion: &types.SemanticVersion{Major: 1, Original: "1.0.0"},
+			}}
+			require.NoError(t, bumper.processUpdateResults(results))
+
+			summary, err := os.ReadFile("summary.md")
+			if !tt.expected {
+				assert.ErrorIs(t, err, os.ErrNotExist)
+				return
+			}
+			require.NoError(t, err)
+			assert.Contains(t, string(summary), "up to date")
+
+			written, err := os.ReadFile(".pre-commit-config.yaml")
+			require.NoError(t, err)
+			assert.Equal(t, content, written, "the configuration should not be modified")
+		})
+	}
+}
+
+func TestBumper_processUpdateResults_DryRunSummaryPreview(t *testing.T) {
+	tests := []struct {
+		name      string
+		noSummary bool
+		quiet     bool
+		format    string
+		expected  bool
+	}{
+		{
+			name:     "summary is printed on a dry run",
+			expected: true,
+		},
+		{
+			name:      "no summary takes precedence",
+			noSummary: true,
+			expected:  false,
+		},
+		{
+			name:     "no summary preview with the json format",
+			format:   config.FormatJSON,
+			expected: false,
+		},
+		{
+			name:     "no summary preview with quiet",
+			quiet:    true,
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			t.Setenv(config.EnvGitHubStepSummary, "")
+			content := []byte("repos:\n  - repo: https://github.com/owner/repo\n    rev: v1.0.0\n")
+			require.NoError(t, os.WriteFile(".pre-commit-config.yaml", content, 0644))
+
+			cfg := &config.Config{
+				Allow:     config.BumpMajor,
+				NoSummary: tt.noSummary,
+				Quiet:     tt.quiet,
+				Format:    tt.format,
+				DryRun:    true,
+				Logger:    zap.NewNop(),
+			}
+			stdout := new(bytes.Buffer)
+			bumper := &Bumper{cfg: cfg, fileWriter: io.NewResultWriter(io.NewOSFileSystem(), stdout, cfg.Logger)}
+
+			results := []types.UpdateResult{{
+				ConfigPath:     ".pre-commit-config.yaml",
+				Repo:           types.Repo{Repo: "https://github.com/owner/repo", Rev: "v1.0.0", SemVer: &types.SemanticVersion{Major: 1, Original: "1.0.0"}},
+				LatestVersion:  &types.SemanticVersion{Major: 2, Original: "2.0.0"},
+				AllowedVersion: &types.SemanticVersion{Major: 2, Original: "2.0.0"},
+				UpdateRequired: true,
+			}}
+			require.NoError(t, bumper.processUpdateResults(results))
+
+			if tt.expected {
+				assert.Contains(t, stdout.String(), "https://github.com/owner/repo")
+				assert.Contains(t, stdout.String(), "2.0.0")
+			} else {
+				assert.Empty(t, stdout.String())
+			}
+
+			_, err := os.Stat("summary.md")
+			assert.ErrorIs(t, err, os.ErrNotExist, "a dry run should not write the summary")
+
+			written, err := os.ReadFile(".pre-commit-config.yaml")
+			require.NoError(t, err)
+			assert.Equal(t, content, written, "the configuration should not be modified")
+		})
+	}
+}
+
+func TestBumper_processUpdateResults_MultipleFiles(t *testing.T) {
+	t.Chdir(t.TempDir())
+	content := "repos:\n  - repo: https://github.com/owner/shared\n    rev: v1.0.0\n"
+	require.NoError(t, os.WriteFile("a.yaml", []byte(content), 0644))
+	require.NoError(t, os.WriteFile("b.yaml", []byte(content+"  - repo: https://github.com/owner/other\n    rev: v3.0.0\n"), 0644))
+
+	cfg := &config.Config{
+		Allow:          config.BumpMajor,
+		NoSummary:      true,
+		MaxConcurrency: 2,
+		Logger:         zap.NewNop(),
+	}
+	filesystem := &writeCountingFileSystem{OSFileSystem: io.NewOSFileSystem(), writes: map[string]int{}}
+	bumper := &Bumper{cfg: cfg, fileWriter: io.NewResultWriter(filesystem, new(bytes.Buffer), cfg.Logger)}
+
+	current := &types.SemanticVersion{Major: 1, Original: "1.0.0"}
+	results := []types.UpdateResult{
+		{
+			ConfigPath:     "a.yaml",
+			Repo:           types.Repo{Repo: "https://github.com/owner/shared", Rev: "v1.0.0", SemVer: current},
+			LatestVersion:  &types.SemanticVersion{Major: 2, Original: "2.0.0"},
+			UpdateRequired: true,
+		},
+		{
+			ConfigPath:     "b.yaml",
+			Repo:           types.Repo{Repo: "https://github.com/owner/shared", Rev: "v1.0.0", SemVer: current, AllowOverride: config.BumpMinor},
+			LatestVersion:  &types.SemanticVersion{Major: 2, Original: "2.0.0"},
+			AllowedVersion: &types.SemanticVersion{Major: 1, Minor: 1, Original: "1.1.0"},
+			UpdateRequired: true,
+		},
+		{
+			ConfigPath:     "b.yaml",
+			Repo:           types.Repo{Repo: "https://github.com/owner/other", Rev: "v3.0.0", SemVer: &types.SemanticVersion{Major: 3, Original: "3.0.0"}},
+			LatestVersion:  &types.SemanticVersion{Major: 3, Patch: 1, Original: "3.0.1"},
+			UpdateRequired: true,
+		},
+	}
+	require.NoError(t, bumper.processUpdateResults(results))
+
+	assert.Equal(t, map[string]int{"a.yaml": 1, "b.yaml": 1}, filesystem.writes, "every file should be written exactly once")
+
+	a, err := os.ReadFile("a.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "repos:\n  - repo: https://github.com/owner/shared\n    rev: v2.0.0\n", string(a))
+
+	b, err := os.ReadFile("b.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "repos:\n  - repo: https://github.com/owner/shared\n    rev: v1.1.0\n  - repo: https://github.com/owner/other\n    rev: v3.0.1\n", string(b))
+}
+
+func TestBumper_verifyConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		verifierError error
+		expectedError bool
+	}{
+		{
+			name:          "pre-commit accepts the config",
+			verifierError: nil,
+			expectedError: false,
+		},
+		{
+			name:          "pre-commit rejects the config",
+			verifierError: fmt.Errorf("pre-commit rejected .pre-commit-config.yaml"),
+			expectedError: true,
+		},
+		{
+			name:          "pre-commit not installed is skipped",
+			verifierError: io.ErrPreCommitNotInstalled,
+			expectedError: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockVerifier := new(MockConfigVerifier)
+			mockVerifier.On("VerifyConfig", ".pre-commit-config.yaml").Return(tt.verifierError)
+
+			cfg := &config.Config{
+				Logger: zap.NewNop(),
+			}
+			bumper := &Bumper{cfg: cfg, verifier: mockVerifier}
+
+			err := bumper.verifyConfig(".pre-commit-config.yaml")
+
+			if tt.expectedError {
+				assert.Error(t, err, "Expected error but got none")
+			} else {
+				assert.NoError(t, err, "Unexpected error: %v", err)
+			}
+			mockVerifier.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBumper_processUpdateResults_VerifySkippedOnDryRun(t *testing.T) {
+	mockVerifier := new(MockConfigVerifier)
+
+	cfg := &config.Config{
+		DryRun: true,
+		Verify: true,
+		Logger: zap.NewNop(),
+	}
+	bumper := &Bumper{cfg: cfg, verifier: mockVerifier, fileWriter: io.NewResultWriter(io.NewOSFileSystem(), new(bytes.Buffer), cfg.Logger)}
+
+	results := []types.UpdateResult{
+		{
+			Repo: types.Repo{
+				Repo:   "https://github.com/owner/repo",
+				Rev:    "1.0.0",
+				SemVer: &types.SemanticVersion{Major: 1, Minor: 0, Patch: 0},
+			},
+			LatestVersion:  &types.SemanticVersion{Major: 1, Minor: 1, Patch: 0},
+			UpdateRequired: true,
+		},
+	}
+
+	err := bumper.processUpdateResults(results)
+
+	assert.NoError(t, err)
+	mockVerifier.AssertNotCalled(t, "VerifyConfig", mock.Anything)
+}
+
+func TestFindLatestVersionGitHub(t *testing.T) {
+	tests := []struct {
+		name        string
+		tags        []GitHubTag
+		repo        *types.Repo
+		expectedVer *types.SemanticVersion
+		expectError bool
+	}{
+		{
+			name:        "empty tag list",
+			tags:        []GitHubTag{},
+			repo:        &types.Repo{Repo: "test/repo", Rev: "1.0.0"},
+			expectError: true,
+		},
+		{
+			name: "no valid semantic version tags",
+			tags: []GitHubTag{
+				{Ref: "refs/tags/invalid-tag"},
+				{Ref: "refs/tags/not-semver"},
+			},
+			repo:        &types.Repo{Repo: "test/repo", Rev: "1.0.0"},
+			expectError: true,
+		},
+		{
+			name: "finds latest semantic version",
+			tags: []GitHubTag{
+				{Ref: "refs/tags/v1.0.0"},
+				{Ref: "refs/tags/v2.1.0"},
+				{Ref: "refs/tags/v1.5.0"},
+			},
+			expectedVer: &types.SemanticVersion{Major: 2, Minor: 1, Patch: 0},
+			expectError: false,
+		},
+		{
+			name: "mixed valid and invalid tags",
+			tags: []GitHubTag{
+				{Ref: "refs/tags/invalid"},
+				{Ref: "refs/tags/v1.2.3"},
+				{Ref: "refs/tags/not-semver"},
+				{Ref: "refs/tags/v0.9.0"},
+			},
+			expectedVer: &types.SemanticVersion{Major: 1, Minor: 2, Patch: 3},
+			expectError: false,
+		},
+		{
+			name: "pre-release versions",
+			tags: []GitHubTag{
+				{Ref: "refs/tags/v1.0.0"},
+				{Ref: "refs/tags/v1.1.0-alpha.1"},
+				{Ref: "refs/tags/v1.0.5"},
+			},
+			expectedVer: &types.SemanticVersion{Major: 1, Minor: 1, Patch: 0, PreRelease: "alpha.1"},
+			expectError: false,
+		},
+		{
+			name: "tags without refs/tags prefix",
+			tags: []GitHubTag{
+				{Ref: "v1.0.0"},
+				{Ref: "v2.0.0"},
+				{Ref: "v1.5.0"},
+			},
+			expectedVer: &types.SemanticVersion{Major: 2, Minor: 0, Patch: 0},
+			expectError: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseAndFindLatestVersion(tt.tags, tt.repo)
+
+			assertFindLatestVersionResult(t, result, err, tt.expectedVer, tt.expectError)
+		})
+	}
+}
+
+func TestFindLatestVersionGitLab(t *testing.T) {
+	tests := []struct {
+		name        string
+		tags        []GitLabTag
+		repo        *types.Repo
+		expectedVer *types.SemanticVersion
+		expectError bool
+	}{
+		{
+			name:        "empty tag list",
+			tags:        []GitLabTag{},
+			repo:        &types.Repo{Repo: "test/repo", Rev: "1.0.0"},
+			expectError: true,
+		},
+		{
+			name: "no valid semantic version tags",
+			tags: []GitLabTag{
+				{Ref: "invalid-tag"},
+				{Ref: "not-semver"},
+			},
+			repo:        &types.Repo{Repo: "test/repo", Rev: "1.0.0"},
+			expectError: true,
+		},
+		{
+			name: "finds latest semantic version",
+			tags: []GitLabTag{
+				{Ref: "v1.0.0"},
+				{Ref: "v2.1.0"},
+				{Ref: "v1.5.0"},
+			},
+			expectedVer: &types.SemanticVersion{Major: 2, Minor: 1, Patch: 0},
+			expectError: false,
+		},
+		{
+			name: "mixed valid and invalid tags",
+			tags: []GitLabTag{
+				{Ref: "invalid"},
+				{Ref: "v1.2.3"},
+				{Ref: "not-semver"},
+				{Ref: "v0.9.0"},
+			},
+			expectedVer: &types.SemanticVersion{Major: 1, Minor: 2, Patch: 3},
+			expectError: false,
+		},
+		{
+			name: "pre-release versions",
+			tags: []GitLabTag{
+				{Ref: "v1.0.0"},
+				{Ref: "v1.1.0-alpha.1"},
+				{Ref: "v1.0.5"},
+			},
+			expectedVer: &types.SemanticVersion{Major: 1, Minor: 1, Patch: 0, PreRelease: "alpha.1"},
+			expectError: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseAndFindLatestVersion(tt.tags, tt.repo)
+
+			assertFindLatestVersionResult(t, result, err, tt.expectedVer, tt.expectError)
+		})
+	}
+}
+
+func parseAndFindLatestVersion[T TagProvider](tags []T, repo *types.Repo) (*types.SemanticVersion, error) {
+	versions, err := parseTagVersions(tags, repo)
+	if err != nil {
+		return nil, err
+	}
+	return findLatestVersion(versions, false), nil
+}
+
+func TestParseTagVersionsCalVer(t *testing.T) {
+	repo := &types.Repo{Repo: "https://github.com/owner/calver", Rev: "2024.03.1", Scheme: types.VersionSchemeCalVer}
+	tags := []GitHubTag{
+		{Ref: "refs/tags/2023.12.0"},
+		{Ref: "refs/tags/2024.03.1"},
+		{Ref: "refs/tags/2024.10.0"},
+		{Ref: "refs/tags/latest"},
+	}
+
+	versions, err := parseTagVersions(tags, repo)
+
+	assert.NoError(t, err)
+	assert.Len(t, versions, 3)
+	assert.Equal(t, "2024.10.0", findLatestVersion(versions, false).String())
+}
+
+func TestParseTagVersionsTagPrefix(t *testing.T) {
+	tags := []GitHubTag{
+		{Ref: "refs/tags/v2.0.0"},
+		{Ref: "refs/tags/backend-1.2.0"},
+		{Ref: "refs/tags/backend-1.3.0"},
+		{Ref: "refs/tags/backend-v1.4.0"},
+		{Ref: "refs/tags/frontend-3.0.0"},
+	}
+
+	t.Run("only tags with the prefix are selected", func(t *testing.T) {
+		repo := &types.Repo{Repo: "https://github.com/owner/monorepo", Rev: "backend-1.2.0", TagPrefix: "backend-"}
+
+		versions, err := parseTagVersions(tags, repo)
+
+		require.NoError(t, err)
+		assert.Len(t, versions, 2)
+		assert.Equal(t, "1.3.0", findLatestVersion(versions, false).String())
+	})
+
+	t.Run("without a prefix all tags are selected", func(t *testing.T) {
+		repo := &types.Repo{Repo: "https://github.com/owner/monorepo", Rev: "backend-1.2.0"}
+
+		versions, err := parseTagVersions(tags, repo)
+
+		require.NoError(t, err)
+		assert.Len(t, versions, 5)
+		assert.Equal(t, "3.0.0", findLatestVersion(versions, false).String())
+	})
+
+	t.Run("no tags with the prefix", func(t *testing.T) {
+		repo := &types.Repo{Repo: "https://github.com/owner/monorepo", Rev: "docs-1.0.0", TagPrefix: "docs-"}
+
+		_, err := parseTagVersions(tags, repo)
+
+		assert.ErrorContains(t, err, `no semantic version tags with prefix "docs-" found`)
+	})
+}
+
+func TestParseTagVersionsStrict(t *testing.T) {
+	tags := []GitHubTag{
+		{Ref: "refs/tags/v1.2.0"},
+		{Ref: "refs/tags/1.3.0"},
+		{Ref: "refs/tags/foo-1.4.0-bar-9.9.9"},
+		{Ref: "refs/tags/deadbeef2.0.0"},
+	}
+
+	repo := &types.Repo{Repo: "https://github.com/owner/repo", Rev: "v1.2.0", Strict: true}
+
+	versions, err := parseTagVersions(tags, repo)
+
+	require.NoError(t, err)
+	assert.Len(t, versions, 2, "tags with a version embedded in a longer name should be skipped")
+	assert.Equal(t, "1.3.0", findLatestVersion(versions, false).String())
+}
+
+func TestFindLatestVersionStableOnly(t *testing.T) {
+	versions := []*types.SemanticVersion{
+		{Major: 1, Minor: 0, Patch: 0},
+		{Major: 1, Minor: 2, Patch: 0, PreRelease: "rc.1"},
+		{Major: 1, Minor: 1, Patch: 0},
+	}
+
+	assert.Equal(t, &types.SemanticVersion{Major: 1, Minor: 2, Patch: 0, PreRelease: "rc.1"}, findLatestVersion(versions, false))
+	assert.Equal(t, &types.SemanticVersion{Major: 1, Minor: 1, Patch: 0}, findLatestVersion(versions, true))
+	assert.Nil(t, findLatestVersion([]*types.SemanticVersion{{Major: 1, PreRelease: "alpha"}}, true))
+}
+
+func assertFindLatestVersionResult(t *testing.T, result *types.SemanticVersion, err error, expectedVer *types.SemanticVersion, expectError bool) {
+	if expectError {
+		assert.Error(t, err, "Expected error but got none")
+		assert.Nil(t, result, "Result should be nil when error expected")
+	} else {
+		assert.NoError(t, err, "Unexpected error: %v", err)
+		assert.NotNil(t, result, "Result should not be nil")
+		assert.Equal(t, expectedVer.Major, result.Major, "Major version mismatch")
+		assert.Equal(t, expectedVer.Minor, result.Minor, "Minor version mismatch")
+		assert.Equal(t, expectedVer.Patch, result.Patch, "Patch version mismatch")
+		assert.Equal(t, expectedVer.PreRelease, result.PreRelease, "PreRelease mismatch")
+	}
+}
+
+func TestExtractHostedRepo(t *testing.T) {
+	tests := []struct {
+		name         string
+		repoURL      string
+		expectedHost string
+		expectedRepo string
+	}{
+		{
+			name:         "gitea.com https URL",
+			repoURL:      "https://gitea.com/owner/repo",
+			expectedHost: "gitea.com",
+			expectedRepo: "owner/repo",
+		},
+		{
+			name:         "custom host with .git suffix",
+			repoURL:      "https://git.example.org/owner/repo.git",
+			expectedHost: "git.example.org",
+			expectedRepo: "owner/repo",
+		},
+		{
+			name:         "custom host with port",
+			repoURL:      "https://git.example.org:3000/owner/repo",
+			expectedHost: "git.example.org:3000",
+			expectedRepo: "owner/repo",
+		},
+		{
+			name:         "ssh URL",
+			repoURL:      "git@git.example.org:owner/repo.git",
+			expectedHost: "git.example.org",
+			expectedRepo: "owner/repo",
+		},
+		{
+			name:         "URL without owner",
+			repoURL:      "https://git.example.org/repo",
+			expectedHost: "",
+			expectedRepo: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			host, repo := extractHostedRepo(tt.repoURL)
+			assert.Equal(t, tt.expectedHost, host)
+			assert.Equal(t, tt.expectedRepo, repo)
+		})
+	}
+}
+
+func TestBumper_vendorHosts(t *testing.T) {
+	tests := []struct {
+		name         string
+		vendorHosts  map[string]string
+		gitHubHosts  []string
+		gitLabHosts  []string
+		gitHubAPIURL string
+		gitLabAPIURL string
+		expected     map[string]string
+	}{
+		{
+			name:         "public GitHub API adds no host",
+			vendorHosts:  map[string]string{"git.example.org": "gitea"},
+			gitHubAPIURL: config.DefaultGitHubAPIURL,
+			expected:     map[string]string{"git.example.org": "gitea"},
+		},
+		{
+			name:         "GitHub Enterprise API host is mapped to GitHub",
+			gitHubAPIURL: "https://github.mycorp.com/api/v3",
+			expected:     map[string]string{"github.mycorp.com": "github"},
+		},
+		{
+			name:         "self-hosted GitLab API host is mapped to GitLab",
+			gitLabAPIURL: "https://gitlab.mycorp.com/api/v4",
+			expected:     map[string]string{"gitlab.mycorp.com": "gitlab"},
+		},
+		{
+			name:         "public GitLab API adds no host",
+			gitLabAPIURL: config.DefaultGitLabAPIURL,
+			expected:     map[string]string{},
+		},
+		{
+			name:         "explicit vendor host wins over the API host",
+			vendorHosts:  map[string]string{"github.mycorp.com": "gitea"},
+			gitHubAPIURL: "https://github.mycorp.com/api/v3",
+			expected:     map[string]string{"github.mycorp.com": "gitea"},
+		},
+		{
+			name:         "GitHub and GitLab host lists",
+			gitHubHosts:  []string{"ghe.corp.com", "ghe.other.com"},
+			gitLabHosts:  []string{"gl.corp.com"},
+			gitHubAPIURL: config.DefaultGitHubAPIURL,
+			expected:     map[string]string{"ghe.corp.com": "github", "ghe.other.com": "github", "gl.corp.com": "gitlab"},
+		},
+		{
+			name:         "explicit vendor host wins over the host lists",
+			vendorHosts:  map[string]string{"gl.corp.com": "gitea"},
+			gitLabHosts:  []string{"gl.corp.com"},
+			gitHubAPIURL: config.DefaultGitHubAPIURL,
+			expected:     map[string]string{"gl.corp.com": "gitea"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bumper := &Bumper{cfg: &config.Config{
+				VendorHosts:  tt.vendorHosts,
+				GitHubHosts:  tt.gitHubHosts,
+				GitLabHosts:  tt.gitLabHosts,
+				GitHubAPIURL: tt.gitHubAPIURL,
+				GitLabAPIURL: tt.gitLabAPIURL,
+			}}
+			assert.Equal(t, tt.expected, bumper.vendorHosts())
+		})
+	}
+}
+
+func TestBumper_configuredHosts(t *testing.T) {
+	bumper := &Bumper{cfg: &config.Config{
+		VendorHosts:  map[string]string{"ghe.vendor.com": "github", "gl.corp.com": "gitea"},
+		GitHubHosts:  []string{"ghe.other.com", "ghe.corp.com"},
+		GitLabHosts:  []string{"gl.corp.com"},
+		GitHubAPIURL: config.DefaultGitHubAPIURL,
+	}}
+
+	assert.Equal(t, []string{"ghe.corp.com", "ghe.other.com", "ghe.vendor.com"}, bumper.configuredHosts(config.VendorGitHub))
+	assert.Empty(t, bumper.configuredHosts(config.VendorGitLab), "an explicit vendor host wins over the host lists")
+}
+
+func TestBumper_checkReposWithUpdaters_MaxConcurrency(t *testing.T) {
+	tests := []struct {
+		name           string
+		maxConcurrency int
+		expectedMax    int32
+	}{
+		{
+			name:           "limit of one checks sequentially",
+			maxConcurrency: 1,
+			expectedMax:    1,
+		},
+		{
+			name:           "limit of three",
+			maxConcurrency: 3,
+			expectedMax:    3,
+		},
+		{
+			name:           "unset limit falls back to one",
+			maxConcurrency: 0,
+			expectedMax:    1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updater := &concurrencyTrackingBumper{}
+			bumper := &Bumper{cfg: &config.Config{
+				Allow:          config.BumpMajor,
+				MaxConcurrency: tt.maxConcurrency,
+				Logger:         zap.NewNop(),
+			}}
+
+			repos := make([]types.Repo, 12)
+			for i := range repos {
+				repos[i] = types.Repo{
+					Repo:   fmt.Sprintf("https://github.com/owner/repo-%d", i),
+					Rev:    "v1.0.0",
+					SemVer: &types.SemanticVersion{Major: 1},
+				}
+			}
+
+			results := bumper.checkReposWithUpdaters(t.Context(), repos, map[string]RepoBumper{config.VendorGitHub: updater})
+
+			assert.LessOrEqual(t, updater.maxInFlight.Load(), tt.expectedMax)
+			require.Len(t, results, len(repos))
+			for i, result := range results {
+				assert.Equal(t, repos[i].Repo, result.Repo.Repo, "results should keep the order of the repos")
+				assert.True(t, result.UpdateRequired)
+			}
+		})
+	}
+}
+
+func TestBumper_checkReposWithUpdaters_Ignore(t *testing.T) {
+	mockUpdater := new(MockRepoBumper)
+	mockUpdater.On("GetVersions", mock.Anything).Return([]*types.SemanticVersion{{Major: 2}}, nil)
+
+	bumper := &Bumper{cfg: &config.Config{
+		Allow:          config.BumpMajor,
+		Ignore:         []string{"https://github.com/owner/pinned", "https://github.com/other/*"},
+		MaxConcurrency: 1,
+		Logger:         zap.NewNop(),
+	}}
+
+	repos := []types.Repo{
+		{Repo: "https://github.com/owner/pinned", Rev: "v1.0.0", SemVer: &types.SemanticVersion{Major: 1}},
+		{Repo: "https://github.com/owner/repo", Rev: "v1.0.0", SemVer: &types.SemanticVersion{Major: 1}},
+		{Repo: "https://github.com/other/repo", Rev: "v1.0.0", SemVer: &types.SemanticVersion{Major: 1}},
+	}
+
+	results := bumper.checkReposWithUpdaters(t.Context(), repos, map[string]RepoBumper{config.VendorGitHub: mockUpdater})
+
+	require.Len(t, results, 3)
+	assert.True(t, results[0].Ignored)
+	assert.False(t, results[0].UpdateRequired)
+	assert.False(t, results[1].Ignored)
+	assert.True(t, results[1].UpdateRequired)
+	assert.True(t, results[2].Ignored)
+	mockUpdater.AssertNumberOfCalls(t, "GetVersions", 1)
+
+	hasUpdates, err := bumper.processResults(results)
+	assert.NoError(t, err)
+	assert.True(t, hasUpdates)
+}
+
+func TestBumper_checkReposWithUpdaters_ZeroMinorBreaking(t *testing.T) {
+	tests := []struct {
+		name              string
+		current           *types.SemanticVersion
+		versions          []*types.SemanticVersion
+		allow             string
+		zeroMinorBreaking bool
+		expectedAllowed   string
+	}{
+		{
+			name:              "0.x minor bump is blocked under minor policy",
+			current:           &types.SemanticVersion{Major: 0, Minor: 1},
+			versions:          []*types.SemanticVersion{{Major: 0, Minor: 1}, {Major: 0, Minor: 2}},
+			allow:             config.BumpMinor,
+			zeroMinorBreaking: true,
+		},
+		{
+			name:              "0.x patch bump is allowed under minor policy",
+			current:           &types.SemanticVersion{Major: 0, Minor: 1},
+			versions:          []*types.SemanticVersion{{Major: 0, Minor: 1}, {Major: 0, Minor: 1, Patch: 1}, {Major: 0, Minor: 2}},
+			allow:             config.BumpMinor,
+			zeroMinorBreaking: true,
+			expectedAllowed:   "0.1.1",
+		},
+		{
+			name:              "0.x minor bump is allowed under major policy",
+			current:           &types.SemanticVersion{Major: 0, Minor: 1},
+			versions:          []*types.SemanticVersion{{Major: 0, Minor: 1}, {Major: 0, Minor: 2}},
+			allow:             config.BumpMajor,
+			zeroMinorBreaking: true,
+			expectedAllowed:   "0.2.0",
+		},
+		{
+			name:              "minor bump of a stable version is allowed under minor policy",
+			current:           &types.SemanticVersion{Major: 1, Minor: 1},
+			versions:          []*types.SemanticVersion{{Major: 1, Minor: 1}, {Major: 1, Minor: 2}},
+			allow:             config.BumpMinor,
+			zeroMinorBreaking: true,
+			expectedAllowed:   "1.2.0",
+		},
+		{
+			name:            "0.x minor bump is allowed under minor policy by default",
+			current:         &types.SemanticVersion{Major: 0, Minor: 1},
+			versions:        []*types.SemanticVersion{{Major: 0, Minor: 1}, {Major: 0, Minor: 2}},
+			allow:           config.BumpMinor,
+			expectedAllowed: "0.2.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUpdater := new(MockRepoBumper)
+			mockUpdater.On("GetVersions", mock.Anything).Return(tt.versions, nil)
+
+			bumper := &Bumper{cfg: &config.Config{
+				Allow:             tt.allow,
+				ZeroMinorBreaking: tt.zeroMinorBreaking,
+				MaxConcurrency:    1,
+				Logger:            zap.NewNop(),
+			}}
+			repos := []types.Repo{{Repo: "https://github.com/owner/repo", Rev: "v" + tt.current.String(), SemVer: tt.current}}
+
+			results := bumper.checkReposWithUpdaters(t.Context(), repos, map[string]RepoBumper{config.VendorGitHub: mockUpdater})
+
+			require.Len(t, results, 1)
+			require.NoError(t, results[0].Error)
+			if tt.expectedAllowed == "" {
+				assert.False(t, results[0].UpdateRequired)
+				assert.Nil(t, results[0].AllowedVersion)
+				return
+			}
+			assert.True(t, results[0].UpdateRequired)
+			assert.Equal(t, tt.expectedAllowed, results[0].AllowedVersion.String())
+		})
+	}
+}
+
+func TestBumper_checkReposWithUpdaters_HookID(t *testing.T) {
+	mockUpdater := new(MockRepoBumper)
+	mockUpdater.On("GetVersions", mock.Anything).Return([]*types.SemanticVersion{{Major: 2}}, nil)
+
+	bumper := &Bumper{cfg: &config.Config{
+		Allow:          config.BumpMajor,
+		HookIDs:        []string{"black"},
+		MaxConcurrency: 1,
+		Logger:         zap.NewNop(),
+	}}
+
+	repos := []types.Repo{
+		{Repo: "https://github.com/psf/black", Rev: "v1.0.0", SemVer: &types.SemanticVersion{Major: 1}, Hooks: []types.Hook{{ID: "black"}, {ID: "black-jupyter"}}},
+		{Repo: "https://github.com/pycqa/flake8", Rev: "v1.0.0", SemVer: &types.SemanticVersion{Major: 1}, Hooks: []types.Hook{{ID: "flake8"}}},
+		{Repo: "https://github.com/owner/mirror", Rev: "v1.0.0", SemVer: &types.SemanticVersion{Major: 1}, Hooks: []types.Hook{{ID: "isort"}, {ID: "black"}}},
+		{Repo: "https://github.com/owner/no-hooks", Rev: "v1.0.0", SemVer: &types.SemanticVersion{Major: 1}},
+	}
+
+	results := bumper.checkReposWithUpdaters(t.Context(), repos, map[string]RepoBumper{config.VendorGitHub: mockUpdater})
+
+	require.Len(t, results, 4)
+	assert.True(t, results[0].UpdateRequired)
+	assert.True(t, results[1].Ignored)
+	assert.True(t, results[2].UpdateRequired)
+	assert.True(t, results[3].Ignored)
+	mockUpdater.AssertNumberOfCalls(t, "GetVersions", 2)
+
+	t.Run("combined with ignore", func(t *testing.T) {
+		bumper.cfg.Ignore = []string{"owner/mirror"}
+
+		results := bumper.checkReposWithUpdaters(t.Context(), repos, map[string]RepoBumper{config.VendorGitHub: mockUpdater})
+
+		assert.False(t, results[0].Ignored)
+		assert.True(t, results[2].Ignored)
+	})
+}
+
+func TestBumper_checkReposWithUpdaters_NegativeCache(t *testing.T) {
+	cacheDir := t.TempDir()
+	repos := []types.Repo{
+		{Repo: "https://github.com/owner/untagged", Rev: "v1.0.0", SemVer: &types.SemanticVersion{Major: 1}},
+		{Repo: "https://github.com/owner/flaky", Rev: "v1.0.0", SemVer: &types.SemanticVersion{Major: 1}},
+	}
+
+	mockUpdater := new(MockRepoBumper)
+	mockUpdater.On("GetVersions", mock.MatchedBy(func(repo *types.Repo) bool { return repo.Repo == repos[0].Repo })).
+		Return([]*types.SemanticVersion(nil), newNoVersionsError(&repos[0]))
+	mockUpdater.On("GetVersions", mock.MatchedBy(func(repo *types.Repo) bool { return repo.Repo == repos[1].Repo })).
+		Return([]*types.SemanticVersion(nil), errors.New("GitHub API returned status 500"))
+
+	// every run creates a new Bumper, so only the negative cache on disk is shared between them
+	run := func() []types.UpdateResult {
+		bumper := &Bumper{
+			cfg:       &config.Config{Allow: config.BumpMajor, MaxConcurrency: 1, Logger: zap.NewNop()},
+			cache:     newVersionCache(),
+			negatives: io.NewNegativeCache(io.NewOSFileSystem(), cacheDir, time.Hour),
+		}
+		return bumper.checkReposWithUpdaters(t.Context(), repos, map[string]RepoBumper{config.VendorGitHub: mockUpdater})
+	}
+
+	first := run()
+	second := run()
+
+	for _, results := range [][]types.UpdateResult{first, second} {
+		var noVersions *NoVersionsError
+		assert.ErrorAs(t, results[0].Error, &noVersions)
+		assert.ErrorContains(t, results[0].Error, "no semantic version tags found for repo: https://github.com/owner/untagged")
+		assert.ErrorContains(t, results[1].Error, "GitHub API returned status 500")
+	}
+
+	// the untagged repo is only fetched on the first run, other errors are not cached
+	mockUpdater.AssertNumberOfCalls(t, "GetVersions", 3)
+}
+
+func TestBumper_checkReposWithUpdaters_SkipReason(t *testing.T) {
+	mockUpdater := new(MockRepoBumper)
+	mockUpdater.On("GetVersions", mock.Anything).Return([]*types.SemanticVersion{{Major: 2}}, nil)
+
+	bumper := &Bumper{cfg: &config.Config{
+		Allow:          config.BumpMajor,
+		MaxConcurrency: 1,
+		Logger:         zap.NewNop(),
+	}}
+
+	repos := []types.Repo{
+		{Repo: "https://github.com/owner/sha", Rev: "a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2"},
+		{Repo: "https://github.com/owner/branch", Rev: "main"},
+		{Repo: config.SentinelLocal},
+		{Repo: "https://github.com/owner/repo", Rev: "v1.0.0", SemVer: &types.SemanticVersion{Major: 1}},
+	}
+
+	results := bumper.checkReposWithUpdaters(t.Context(), repos, map[string]RepoBumper{config.VendorGitHub: mockUpdater})
+
+	require.Len(t, results, 4)
+	assert.Equal(t, config.SkipReasonNoVersion, results[0].SkipReason)
+	assert.Equal(t, config.SkipReasonNoVersion, results[1].SkipReason)
+	assert.Equal(t, config.SkipReasonSentinel, results[2].SkipReason)
+	assert.Empty(t, results[3].SkipReason)
+	assert.True(t, results[3].UpdateRequired)
+	mockUpdater.AssertNumberOfCalls(t, "GetVersions", 1)
+
+	hasUpdates, err := bumper.processResults(results)
+	assert.NoError(t, err)
+	assert.True(t, hasUpdates)
+}
+
+func TestBumper_checkReposWithUpdaters_DisallowMutableRefs(t *testing.T) {
+	mockUpdater := new(MockRepoBumper)
+	mockUpdater.On("GetVersions", mock.Anything).Return([]*types.SemanticVersion{{Major: 1}}, nil)
+
+	bumper := &Bumper{cfg: &config.Config{
+		Allow:               config.BumpMajor,
+		Ignore:              []string{"https://github.com/owner/ignored"},
+		DisallowMutableRefs: true,
+		MaxConcurrency:      1,
+		Logger:              zap.NewNop(),
+	}}
+
+	repos := []types.Repo{
+		{Repo: "https://github.com/owner/main", Rev: "main"},
+		{Repo: "https://github.com/owner/head", Rev: "HEAD"},
+		{Repo: "https://github.com/owner/branch", Rev: "dev"},
+		{Repo: "https://github.com/owner/sha", Rev: "a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2"},
+		{Repo: "https://github.com/owner/ignored", Rev: "main"},
+		{Repo: config.SentinelLocal},
+		{Repo: "https://github.com/owner/repo", Rev: "v1.0.0", SemVer: &types.SemanticVersion{Major: 1}},
+	}
+
+	results := bumper.checkReposWithUpdaters(t.Context(), repos, map[string]RepoBumper{config.VendorGitHub: mockUpdater})
+
+	require.Len(t, results, 7)
+	for _, result := range results[:3] {
+		assert.ErrorIs(t, result.Error, ErrMutableRef)
+		assert.ErrorContains(t, result.Error, result.Repo.Repo+" is at "+result.Repo.Rev)
+	}
+	assert.Equal(t, config.SkipReasonNoVersion, results[3].SkipReason)
+	assert.NoError(t, results[4].Error, "ignored repositories should not be reported")
+	assert.Equal(t, config.SkipReasonNoVersion, results[4].SkipReason)
+	assert.Equal(t, config.SkipReasonSentinel, results[5].SkipReason)
+	assert.NoError(t, results[6].Error)
+
+	_, err := bumper.processResults(results)
+	assert.ErrorContains(t, err, "pinned to a mutable ref, pin it to a tag or a full commit SHA instead (3 times: ")
+}
+
+func TestBumper_processResults_BumpType(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	bumper := &Bumper{cfg: &config.Config{Logger: zap.New(core)}}
+
+	results := []types.UpdateResult{
+		{
+			Repo:           types.Repo{Repo: "https://github.com/owner/major", Rev: "v1.2.0", SemVer: &types.SemanticVersion{Major: 1, Minor: 2}},
+			LatestVersion:  &types.SemanticVersion{Major: 2},
+			UpdateRequired: true,
+		},
+		{
+			Repo:           types.Repo{Repo: "https://github.com/owner/minor", Rev: "v1.2.0", SemVer: &types.SemanticVersion{Major: 1, Minor: 2}},
+			LatestVersion:  &types.SemanticVersion{Major: 1, Minor: 3},
+			UpdateRequired: true,
+		},
+	}
+
+	hasUpdates, err := bumper.processResults(results)
+	require.NoError(t, err)
+	assert.True(t, hasUpdates)
+
+	assert.Equal(t, 1, logs.FilterMessage("Update available for https://github.com/owner/major: v1.2.0 -> 2.0.0 (major)").Len())
+	assert.Equal(t, 1, logs.FilterMessage("Update available for https://github.com/owner/minor: v1.2.0 -> 1.3.0 (minor)").Len())
+}
+
+func TestBumper_processResults_GroupedErrors(t *testing.T) {
+	rateLimited := errors.New("GitHub API rate limit exceeded")
+	mockUpdater := new(MockRepoBumper)
+	mockUpdater.On("GetVersions", mock.MatchedBy(func(repo *types.Repo) bool { return repo.Repo != "https://gitlab.com/group/missing" })).
+		Return([]*types.SemanticVersion(nil), rateLimited)
+	mockUpdater.On("GetVersions", mock.Anything).Return([]*types.SemanticVersion(nil), errors.New("GitLab API returned status 404"))
+
+	bumper := &Bumper{cfg: &config.Config{
+		Allow:          config.BumpMajor,
+		MaxConcurrency: 1,
+		Logger:         zap.NewNop(),
+	}}
+
+	repos := []types.Repo{
+		{Repo: "https://github.com/owner/first", Rev: "v1.0.0", SemVer: &types.SemanticVersion{Major: 1}},
+		{Repo: "https://gitlab.com/group/missing", Rev: "v1.0.0", SemVer: &types.SemanticVersion{Major: 1}},
+		{Repo: "https://github.com/owner/second", Rev: "v1.0.0", SemVer: &types.SemanticVersion{Major: 1}},
+		{Repo: "https://github.com/owner/third", Rev: "v1.0.0", SemVer: &types.SemanticVersion{Major: 1}},
+	}
+
+	results := bumper.checkReposWithUpdaters(t.Context(), repos, map[string]RepoBumper{
+		config.VendorGitHub: mockUpdater,
+		config.VendorGitLab: mockUpdater,
+	})
+
+	hasUpdates, err := bumper.processResults(results)
+	require.Error(t, err)
+	assert.False(t, hasUpdates)
+	assert.Equal(t, "errors occurred while checking repositories: "+
+		"GitHub API rate limit exceeded (3 times: https://github.com/owner/first, https://github.com/owner/second, https://github.com/owner/third); "+
+		"GitLab API returned status 404 (https://gitlab.com/group/missing)", err.Error())
+
+	var checkErr *CheckError
+	require.ErrorAs(t, err, &checkErr)
+	assert.ErrorIs(t, err, rateLimited)
+	joined, ok := checkErr.Unwrap().(interface{ Unwrap() []error })
+	require.True(t, ok, "the individual errors should be joined")
+	assert.Len(t, joined.Unwrap(), 4)
+}
+
+func TestBumper_isSkipped(t *testing.T) {
+	tests := []struct {
+		name     string
+		only     []string
+		ignore   []string
+		repo     string
+		expected bool
+	}{
+		{
+			name:     "no filters",
+			repo:     "https://github.com/psf/black",
+			expected: false,
+		},
+		{
+			name:     "single repo selected by only",
+			only:     []string{"https://github.com/psf/black"},
+			repo:     "https://github.com/psf/black",
+			expected: false,
+		},
+		{
+			name:     "repo outside of only selection",
+			only:     []string{"https://github.com/psf/black"},
+			repo:     "https://github.com/pycqa/flake8",
+			expected: true,
+		},
+		{
+			name:     "repo selected by only glob",
+			only:     []string{"https://github.com/pycqa/*"},
+			repo:     "https://github.com/pycqa/flake8",
+			expected: false,
+		},
+		{
+			name:     "ignore filters within only selection",
+			only:     []string{"https://github.com/pycqa/*"},
+			ignore:   []string{"isort"},
+			repo:     "https://github.com/pycqa/isort",
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bumper := &Bumper{cfg: &config.Config{Only: tt.only, Ignore: tt.ignore}}
+			assert.Equal(t, tt.expected, bumper.isSkipped(types.Repo{Repo: tt.repo}))
 		})
 	}
 }
 
-func assertFindLatestVersionResult(t *testing.T, result *types.SemanticVersion, err error, expectedVer *types.SemanticVersion, expectError bool) {
-	if expectError {
-		assert.Error(t, err, "Expected error but got none")
-		assert.Nil(t, result, "Result should be nil when error expected")
-	} else {
-		assert.NoError(t, err, "Unexpected error: %v", err)
-		assert.NotNil(t, result, "Result should not be nil")
-		assert.Equal(t, expectedVer.Major, result.Major, "Major version mismatch")
-		assert.Equal(t, expectedVer.Minor, result.Minor, "Minor version mismatch")
-		assert.Equal(t, expectedVer.Patch, result.Patch, "Patch version mismatch")
-		assert.Equal(t, expectedVer.PreRelease, result.PreRelease, "PreRelease mismatch")
+func TestBumper_checkDependenciesWithResolver(t *testing.T) {
+	mockResolver := new(MockDependencyResolver)
+	mockResolver.On("GetVersions", "flake8-bugbear").Return([]*types.SemanticVersion{{Major: 22, Minor: 1, Patch: 11}, {Major: 24, Minor: 2, Patch: 6}}, nil)
+	mockResolver.On("GetVersions", "types-requests").Return([]*types.SemanticVersion{{Major: 2, Minor: 31}}, nil)
+	mockResolver.On("GetVersions", "missing").Return([]*types.SemanticVersion(nil), fmt.Errorf("package missing not found on PyPI"))
+
+	bumper := &Bumper{
+		cfg: &config.Config{
+			Allow:          config.BumpMinor,
+			Ignore:         []string{"https://github.com/owner/ignored"},
+			MaxConcurrency: 2,
+			Logger:         zap.NewNop(),
+		},
+		cache: newVersionCache(),
+	}
+
+	repos := []types.Repo{
+		{Repo: "https://github.com/pycqa/flake8", Hooks: []types.Hook{
+			{ID: "flake8", AdditionalDependencies: []string{"flake8-bugbear==22.1.11", "flake8-docstrings"}},
+		}},
+		{Repo: config.SentinelLocal, Hooks: []types.Hook{
+			{ID: "mypy", AdditionalDependencies: []string{"types-requests==2.30.0", "flake8_bugbear==24.1.0"}},
+			{ID: "other", AdditionalDependencies: []string{"missing==1.0.0"}},
+		}},
+		{Repo: "https://github.com/owner/ignored", Hooks: []types.Hook{
+			{ID: "ignored", AdditionalDependencies: []string{"ignored==1.0.0"}},
+		}},
+	}
+
+	results := bumper.checkDependenciesWithResolvers(t.Context(), repos, map[string]DependencyResolver{config.DependencySourcePyPI: mockResolver})
+
+	require.Len(t, results, 4)
+	assert.Equal(t, "flake8-bugbear", results[0].Dependency.Name)
+	assert.False(t, results[0].UpdateRequired, "major bump is not allowed")
+	assert.Equal(t, "types-requests", results[1].Dependency.Name)
+	assert.True(t, results[1].UpdateRequired)
+	assert.Equal(t, "2.31.0", results[1].LatestVersion.String())
+	assert.True(t, results[2].UpdateRequired, "normalized package names share the cached versions")
+	assert.Equal(t, "24.2.6", results[2].LatestVersion.String())
+	assert.ErrorContains(t, results[3].Error, "failed to get latest version for missing")
+	mockResolver.AssertNumberOfCalls(t, "GetVersions", 3)
+}
+
+func TestBumper_checkDependenciesWithResolvers_NormalizedName(t *testing.T) {
+	mockResolver := new(MockDependencyResolver)
+	mockResolver.On("GetVersions", "flake8-bugbear").Return([]*types.SemanticVersion{{Major: 24, Minor: 2, Patch: 6}}, nil)
+
+	bumper := &Bumper{
+		cfg: &config.Config{
+			Allow:          config.BumpMajor,
+			MaxConcurrency: 1,
+			Logger:         zap.NewNop(),
+		},
+		cache: newVersionCache(),
+	}
+
+	repos := []types.Repo{
+		{Repo: "https://github.com/pycqa/flake8", Hooks: []types.Hook{
+			{ID: "flake8", AdditionalDependencies: []string{"Flake8_Bugbear==22.1.11"}},
+		}},
+	}
+
+	results := bumper.checkDependenciesWithResolvers(t.Context(), repos, map[string]DependencyResolver{config.DependencySourcePyPI: mockResolver})
+
+	require.Len(t, results, 1)
+	assert.Equal(t, "Flake8_Bugbear", results[0].Dependency.Name, "the spelling of the configuration is kept")
+	assert.True(t, results[0].UpdateRequired)
+	mockResolver.AssertExpectations(t)
+}
+
+func TestBumper_checkDependenciesWithResolvers_Npm(t *testing.T) {
+	npmResolver := new(MockDependencyResolver)
+	npmResolver.On("GetVersions", "eslint").Return([]*types.SemanticVersion{{Major: 8, Minor: 56}, {Major: 8, Minor: 57}, {Major: 9}}, nil)
+
+	bumper := &Bumper{
+		cfg: &config.Config{
+			Allow:          config.BumpMinor,
+			MaxConcurrency: 1,
+			Logger:         zap.NewNop(),
+		},
+		cache: newVersionCache(),
+	}
+
+	repos := []types.Repo{
+		{Repo: "https://github.com/pre-commit/mirrors-eslint", Hooks: []types.Hook{
+			{ID: "eslint", AdditionalDependencies: []string{"eslint@8.56.0", "flake8-bugbear==22.1.11"}},
+		}},
+	}
+
+	results := bumper.checkDependenciesWithResolvers(t.Context(), repos, map[string]DependencyResolver{config.DependencySourceNpm: npmResolver})
+
+	require.Len(t, results, 1, "dependencies of a source without a resolver should not be checked")
+	assert.Equal(t, config.DependencySourceNpm, results[0].Dependency.Source)
+	assert.True(t, results[0].UpdateRequired)
+	assert.Equal(t, "8.57.0", results[0].BumpVersion().String(), "the major bump is not allowed")
+	npmResolver.AssertExpectations(t)
+}
+
+func TestBumper_checkLocalVersionsWithResolvers(t *testing.T) {
+	resolver := new(MockDependencyResolver)
+	resolver.On("GetVersions", "ruff").Return([]*types.SemanticVersion{{Minor: 4, Patch: 1}, {Minor: 4, Patch: 2}, {Minor: 5}}, nil)
+
+	bumper := &Bumper{
+		cfg: &config.Config{
+			Allow:          config.BumpMajor,
+			MaxConcurrency: 1,
+			Logger:         zap.NewNop(),
+		},
+		cache: newVersionCache(),
+	}
+
+	pattern, err := types.ParseLocalVersionPattern(`pypi=(?P<name>ruff)==(?P<version>[0-9.]+)`)
+	require.NoError(t, err)
+
+	repos := []types.Repo{
+		{Repo: config.SentinelLocal, Hooks: []types.Hook{{ID: "ruff", Entry: "uvx ruff==0.4.1 check"}}},
+		{Repo: "https://github.com/astral-sh/ruff-pre-commit", Rev: "v0.4.1", Hooks: []types.Hook{{ID: "ruff", Entry: "ruff==0.4.1"}}},
+	}
+
+	results := bumper.checkLocalVersionsWithResolvers(t.Context(), repos, []*types.LocalVersionPattern{pattern}, map[string]DependencyResolver{config.DependencySourcePyPI: resolver})
+
+	require.Len(t, results, 1, "only the entry of local hooks should be scanned")
+	assert.Equal(t, "ruff==0.4.1", results[0].Dependency.Spec)
+	assert.True(t, results[0].UpdateRequired)
+	assert.Equal(t, "0.5.0", results[0].BumpVersion().String())
+	resolver.AssertExpectations(t)
+}
+
+func TestBumper_processCheckResults(t *testing.T) {
+	tests := []struct {
+		name          string
+		results       []types.UpdateResult
+		expectedError error
+		expectedOther bool
+	}{
+		{
+			name:    "up to date",
+			results: []types.UpdateResult{{Repo: types.Repo{Repo: "https://github.com/owner/repo"}}},
+		},
+		{
+			name:          "updates available",
+			results:       []types.UpdateResult{{Repo: types.Repo{Repo: "https://github.com/owner/repo"}, LatestVersion: &types.SemanticVersion{Major: 2}, UpdateRequired: true}},
+			expectedError: ErrUpdatesAvailable,
+		},
+		{
+			name: "errors take precedence over updates",
+			results: []types.UpdateResult{
+				{Repo: types.Repo{Repo: "https://github.com/owner/repo"}, LatestVersion: &types.SemanticVersion{Major: 2}, UpdateRequired: true},
+				{Repo: types.Repo{Repo: "https://github.com/owner/broken"}, Error: fmt.Errorf("GitHub API returned status 500")},
+			},
+			expectedOther: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bumper := &Bumper{cfg: &config.Config{Logger: zap.NewNop()}}
+
+			err := bumper.processCheckResults(tt.results)
+
+			switch {
+			case tt.expectedOther:
+				assert.Error(t, err)
+				assert.NotErrorIs(t, err, ErrUpdatesAvailable)
+				var updatesErr *UpdatesAvailableError
+				assert.False(t, errors.As(err, &updatesErr))
+			case tt.expectedError != nil:
+				assert.ErrorIs(t, err, tt.expectedError)
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBumper_processCheckResults_UpdatesAvailableError(t *testing.T) {
+	outdated := types.UpdateResult{
+		Repo:           types.Repo{Repo: "https://github.com/owner/outdated", Rev: "v1.0.0", SemVer: &types.SemanticVersion{Major: 1}},
+		LatestVersion:  &types.SemanticVersion{Major: 2},
+		UpdateRequired: true,
+	}
+	duplicate := outdated
+	duplicate.Duplicate = true
+	results := []types.UpdateResult{
+		outdated,
+		{Repo: types.Repo{Repo: "https://github.com/owner/current", Rev: "v1.0.0", SemVer: &types.SemanticVersion{Major: 1}}, LatestVersion: &types.SemanticVersion{Major: 1}},
+		duplicate,
+	}
+
+	bumper := &Bumper{cfg: &config.Config{Logger: zap.NewNop()}}
+	err := fmt.Errorf("check: %w", bumper.processCheckResults(results))
+
+	assert.ErrorIs(t, err, ErrUpdatesAvailable)
+	var updatesErr *UpdatesAvailableError
+	require.ErrorAs(t, err, &updatesErr)
+	require.Len(t, updatesErr.Results, 1, "only the results that require an update should be listed, once")
+	assert.Equal(t, "https://github.com/owner/outdated", updatesErr.Results[0].Name())
+	assert.EqualError(t, updatesErr, "updates are available for 1 hook")
+}
+
+func TestBumper_processUpdateResults_ContinueOnError(t *testing.T) {
+	original := `repos:
+  - repo: https://github.com/owner/first
+    rev: v1.0.0
+  - repo: https://github.com/owner/broken
+    rev: v1.0.0
+  - repo: https://github.com/owner/second
+    rev: v2.0.0
+`
+
+	results := []types.UpdateResult{
+		{
+			Repo:           types.Repo{Repo: "https://github.com/owner/first", Rev: "v1.0.0", SemVer: &types.SemanticVersion{Major: 1, Original: "1.0.0"}},
+			LatestVersion:  &types.SemanticVersion{Major: 1, Minor: 1},
+			UpdateRequired: true,
+		},
+		{
+			Repo:  types.Repo{Repo: "https://github.com/owner/broken", Rev: "v1.0.0", SemVer: &types.SemanticVersion{Major: 1, Original: "1.0.0"}},
+			Error: fmt.Errorf("GitHub API returned status 500"),
+		},
+		{
+			Repo:           types.Repo{Repo: "https://github.com/owner/second", Rev: "v2.0.0", SemVer: &types.SemanticVersion{Major: 2, Original: "2.0.0"}},
+			LatestVersion:  &types.SemanticVersion{Major: 2, Patch: 1},
+			UpdateRequired: true,
+		},
+	}
+
+	tests := []struct {
+		name            string
+		continueOnError bool
+		expected        string
+	}{
+		{
+			name:     "strict by default",
+			expected: original,
+		},
+		{
+			name:            "continue on error writes the successful updates",
+			continueOnError: true,
+			expected: `repos:
+  - repo: https://github.com/owner/first
+    rev: v1.1.0
+  - repo: https://github.com/owner/broken
+    rev: v1.0.0
+  - repo: https://github.com/owner/second
+    rev: v2.0.1
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), ".pre-commit-config.yaml")
+			require.NoError(t, os.WriteFile(configPath, []byte(original), 0644))
+			for i := range results {
+				results[i].ConfigPath = configPath
+			}
+
+			cfg := &config.Config{
+				NoSummary:       true,
+				ContinueOnError: tt.continueOnError,
+				Logger:          zap.NewNop(),
+			}
+			bumper := &Bumper{cfg: cfg, fileWriter: io.NewResultWriter(io.NewOSFileSystem(), new(bytes.Buffer), cfg.Logger)}
+
+			err := bumper.processUpdateResults(results)
+
+			assert.ErrorContains(t, err, "GitHub API returned status 500")
+			assert.Equal(t, tt.continueOnError, errors.Is(err, ErrPartialUpdate))
+
+			content, readErr := os.ReadFile(configPath)
+			require.NoError(t, readErr)
+			assert.Equal(t, tt.expected, string(content))
+		})
+	}
+}
+
+func TestBumper_processUpdateResults_Stdin(t *testing.T) {
+	tests := []struct {
+		name     string
+		results  []types.UpdateResult
+		expected string
+	}{
+		{
+			name: "updates are written to stdout",
+			results: []types.UpdateResult{{
+				Repo:           types.Repo{Repo: "https://github.com/owner/repo", Rev: "v1.0.0", SemVer: &types.SemanticVersion{Major: 1, Original: "1.0.0"}},
+				LatestVersion:  &types.SemanticVersion{Major: 1, Minor: 1},
+				UpdateRequired: true,
+			}},
+			expected: "repos:\n  - repo: https://github.com/owner/repo\n    rev: v1.1.0\n",
+		},
+		{
+			name: "unchanged configuration is passed through",
+			results: []types.UpdateResult{{
+				Repo:          types.Repo{Repo: "https://github.com/owner/repo", Rev: "v1.0.0", SemVer: &types.SemanticVersion{Major: 1, Original: "1.0.0"}},
+				LatestVersion: &types.SemanticVersion{Major: 1, Original: "1.0.0"},
+			}},
+			expected: "repos:\n  - repo: https://github.com/owner/repo\n    rev: v1.0.0\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout strings.Builder
+			stdin := strings.NewReader("repos:\n  - repo: https://github.com/owner/repo\n    rev: v1.0.0\n")
+			fs := io.NewStdioFileSystem(io.NewOSFileSystem(), stdin, &stdout)
+
+			for i := range tt.results {
+				tt.results[i].ConfigPath = config.StdinPath
+			}
+
+			cfg := &config.Config{
+				NoSummary: true,
+				Verify:    true,
+				Logger:    zap.NewNop(),
+			}
+			mockVerifier := new(MockConfigVerifier)
+			bumper := &Bumper{cfg: cfg, fileWriter: io.NewResultWriter(fs, new(bytes.Buffer), cfg.Logger), verifier: mockVerifier}
+
+			err := bumper.processUpdateResults(tt.results)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, stdout.String())
+			mockVerifier.AssertNotCalled(t, "VerifyConfig", mock.Anything)
+		})
+	}
+}
+
+func TestBumper_CheckRepos(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	configPath := filepath.Join(dir, ".pre-commit-config.yaml")
+	content := `repos:
+  - repo: https://github.com/owner/outdated
+    rev: v1.0.0
+  - repo: https://gitlab.com/group/current
+    rev: v2.0.0
+  - repo: local
+    hooks:
+      - id: lint
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0644))
+
+	githubBumper := new(MockRepoBumper)
+	githubBumper.On("GetVersions", mock.Anything).Return([]*types.SemanticVersion{{Major: 1}, {Major: 1, Minor: 1}}, nil)
+	gitlabBumper := new(MockRepoBumper)
+	gitlabBumper.On("GetVersions", mock.Anything).Return([]*types.SemanticVersion{{Major: 2}}, nil)
+
+	cfg := &config.Config{
+		PreCommitConfigPaths: []string{configPath},
+		Allow:                config.BumpMajor,
+		MaxConcurrency:       1,
+		Logger:               zap.NewNop(),
+	}
+	filesystem := io.NewOSFileSystem()
+	bumper := NewBumper(parser.NewParser(cfg.Logger, filesystem), cfg, io.NewResultWriter(filesystem, new(bytes.Buffer), cfg.Logger), http.DefaultClient)
+	bumper.RegisterRepoBumper(config.VendorGitHub, githubBumper)
+	bumper.RegisterRepoBumper(config.VendorGitLab, gitlabBumper)
+
+	results, err := bumper.CheckRepos(t.Context())
+	require.NoError(t, err)
+
+	require.Len(t, results, 3)
+	assert.Equal(t, "https://github.com/owner/outdated", results[0].Repo.Repo)
+	assert.True(t, results[0].UpdateRequired)
+	assert.Equal(t, "1.1.0", results[0].BumpVersion().String())
+	assert.Equal(t, "https://gitlab.com/group/current", results[1].Repo.Repo)
+	assert.False(t, results[1].UpdateRequired)
+	assert.Equal(t, config.SkipReasonSentinel, results[2].SkipReason)
+	for _, result := range results {
+		assert.Equal(t, configPath, result.ConfigPath)
+		assert.NoError(t, result.Error)
+	}
+
+	written, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(written), "checking must not modify the configuration file")
+	assert.NoFileExists(t, filepath.Join(dir, "summary.md"))
+	githubBumper.AssertExpectations(t)
+	gitlabBumper.AssertExpectations(t)
+}
+
+func TestBumper_Update_Cancelled(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".pre-commit-config.yaml")
+	content := `repos:
+  - repo: https://github.com/owner/first
+    rev: v1.0.0
+  - repo: https://github.com/owner/second
+    rev: v1.0.0
+  - repo: https://github.com/owner/third
+    rev: v1.0.0
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0644))
+
+	cfg := &config.Config{
+		PreCommitConfigPaths: []string{configPath},
+		Allow:                config.BumpMajor,
+		MaxConcurrency:       1,
+		Logger:               zap.NewNop(),
+	}
+	filesystem := io.NewOSFileSystem()
+	bumper := NewBumper(parser.NewParser(cfg.Logger, filesystem), cfg, io.NewResultWriter(filesystem, new(bytes.Buffer), cfg.Logger), http.DefaultClient)
+	updater := &blockingBumper{started: make(chan struct{}, 3)}
+	bumper.RegisterRepoBumper(config.VendorGitHub, updater)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan error, 1)
+	go func() { done <- bumper.Update(ctx) }()
+
+	<-updater.started
+	cancel()
+
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Update did not return after the context was cancelled")
+	}
+
+	assert.Equal(t, int32(1), updater.calls.Load(), "lookups waiting for a slot should not start after cancellation")
+	written, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(written), "a cancelled update must not modify the configuration file")
+}
+
+func TestBumper_Update_ConfigOut(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	configPath := filepath.Join(dir, ".pre-commit-config.yaml")
+	outPath := filepath.Join(dir, "new-config.yaml")
+	content := `repos:
+  - repo: https://github.com/owner/outdated
+    rev: v1.0.0
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0644))
+
+	githubBumper := new(MockRepoBumper)
+	githubBumper.On("GetVersions", mock.Anything).Return([]*types.SemanticVersion{{Major: 1}, {Major: 1, Minor: 1}}, nil)
+
+	cfg := &config.Config{
+		PreCommitConfigPaths: []string{configPath},
+		Allow:                config.BumpMajor,
+		ConfigOut:            outPath,
+		MaxConcurrency:       1,
+		Logger:               zap.NewNop(),
+	}
+	filesystem := io.NewOSFileSystem()
+	bumper := NewBumper(parser.NewParser(cfg.Logger, filesystem), cfg, io.NewResultWriter(filesystem, new(bytes.Buffer), cfg.Logger), http.DefaultClient)
+	bumper.RegisterRepoBumper(config.VendorGitHub, githubBumper)
+
+	require.NoError(t, bumper.Update(t.Context()))
+
+	original, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(original), "the configuration file must not be modified")
+
+	written, err := os.ReadFile(outPath)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Replace(content, "rev: v1.0.0", "rev: v1.1.0", 1), string(written))
+	assert.FileExists(t, filepath.Join(dir, "summary.md"), "the summary should still be written")
+
+	t.Run("multiple configuration files", func(t *testing.T) {
+		otherPath := filepath.Join(dir, "other-config.yaml")
+		require.NoError(t, os.WriteFile(otherPath, []byte(content), 0644))
+		cfg.PreCommitConfigPaths = []string{configPath, otherPath}
+		require.NoError(t, os.Remove(outPath))
+
+		err := bumper.Update(t.Context())
+
+		assert.ErrorContains(t, err, "--config-out requires a single configuration file, got 2")
+		assert.NoFileExists(t, outPath)
+	})
+}
+
+func TestBumper_Update_MaxBumps(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	configPath := filepath.Join(dir, ".pre-commit-config.yaml")
+	content := `repos:
+  - repo: https://github.com/owner/one
+    rev: v1.0.0
+  - repo: https://github.com/owner/two
+    rev: v1.0.0
+  - repo: https://github.com/owner/three
+    rev: v1.0.0
+  - repo: https://github.com/owner/four
+    rev: v1.0.0
+  - repo: https://github.com/owner/five
+    rev: v1.0.0
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0644))
+
+	githubBumper := new(MockRepoBumper)
+	githubBumper.On("GetVersions", mock.Anything).Return([]*types.SemanticVersion{{Major: 1}, {Major: 1, Minor: 1}}, nil)
+
+	cfg := &config.Config{
+		PreCommitConfigPaths: []string{configPath},
+		Allow:                config.BumpMajor,
+		MaxBumps:             2,
+		MaxConcurrency:       1,
+		Logger:               zap.NewNop(),
+	}
+	filesystem := io.NewOSFileSystem()
+	bumper := NewBumper(parser.NewParser(cfg.Logger, filesystem), cfg, io.NewResultWriter(filesystem, new(bytes.Buffer), cfg.Logger), http.DefaultClient)
+	bumper.RegisterRepoBumper(config.VendorGitHub, githubBumper)
+
+	require.NoError(t, bumper.Update(t.Context()))
+
+	written, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(string(written), "rev: v1.1.0"), "only the first two updates should be written")
+	assert.Equal(t, 3, strings.Count(string(written), "rev: v1.0.0"), "the other updates should be deferred")
+	assert.Contains(t, string(written), "owner/one\n    rev: v1.1.0")
+	assert.Contains(t, string(written), "owner/two\n    rev: v1.1.0")
+
+	summary, err := os.ReadFile(filepath.Join(dir, "summary.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(summary), "### ⏳ Deferred")
+	assert.Contains(t, string(summary), "- ⏳ **https://github.com/owner/five**: v1.0.0 (update to 1.1.0 deferred to a later run)")
+	assert.Contains(t, string(summary), "- ⏳ **3** updates deferred to a later run (limited by --max-bumps)")
+	assert.Contains(t, string(summary), "- 🔄 **2** hooks updated")
+}
+
+func TestBumper_RegisterRepoBumper(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".pre-commit-config.yaml")
+	content := `repos:
+  - repo: https://git.example.org/team/hook
+    rev: v1.0.0
+  - repo: https://git.other.org/team/hook
+    rev: v1.0.0
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0644))
+
+	inHouseBumper := new(MockRepoBumper)
+	inHouseBumper.On("GetVersions", mock.Anything).Return([]*types.SemanticVersion{{Major: 1}, {Major: 1, Minor: 2}}, nil)
+
+	cfg := &config.Config{
+		PreCommitConfigPaths: []string{configPath},
+		Allow:                config.BumpMajor,
+		MaxConcurrency:       1,
+		Logger:               zap.NewNop(),
+	}
+	filesystem := io.NewOSFileSystem()
+	bumper := NewBumper(parser.NewParser(cfg.Logger, filesystem), cfg, io.NewResultWriter(filesystem, new(bytes.Buffer), cfg.Logger), http.DefaultClient)
+	bumper.RegisterRepoBumper("in-house", inHouseBumper, "git.example.org")
+
+	results, err := bumper.CheckRepos(t.Context())
+	require.NoError(t, err)
+
+	require.Len(t, results, 2)
+	assert.Equal(t, "in-house", results[0].Repo.GetVendor())
+	require.NoError(t, results[0].Error)
+	assert.Equal(t, "1.2.0", results[0].BumpVersion().String())
+	assert.Error(t, results[1].Error, "repositories on other hosts are not checked by the registered RepoBumper")
+
+	inHouseBumper.AssertNumberOfCalls(t, "GetVersions", 1)
+	inHouseBumper.AssertCalled(t, "GetVersions", mock.MatchedBy(func(repo *types.Repo) bool {
+		return repo.Repo == "https://git.example.org/team/hook"
+	}))
+}
+
+func TestBumper_DuplicateRepos(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(`[{"ref": "refs/tags/v1.0.0"}, {"ref": "refs/tags/v1.1.0"}]`))
+	}))
+	defer server.Close()
+
+	content := `repos:
+  - repo: https://github.com/owner/repo
+    rev: v1.0.0
+    hooks:
+      - id: lint
+  - repo: git@github.com:owner/repo.git
+    rev: v1.0.0
+    hooks:
+      - id: format
+`
+	configPath := filepath.Join(t.TempDir(), ".pre-commit-config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0644))
+
+	cfg := &config.Config{
+		PreCommitConfigPaths: []string{configPath},
+		Allow:                config.BumpMajor,
+		NoSummary:            true,
+		Logger:               zap.NewNop(),
+	}
+	filesystem := io.NewOSFileSystem()
+	bumper := NewBumper(parser.NewParser(cfg.Logger, filesystem), cfg, io.NewResultWriter(filesystem, new(bytes.Buffer), cfg.Logger), publicAPIClient(server, config.DefaultGitHubAPIURL))
+
+	results, err := bumper.CheckRepos(t.Context())
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, int32(1), calls.Load(), "the versions of a repeated repository are only looked up once")
+	assert.False(t, results[0].Duplicate)
+	assert.True(t, results[1].Duplicate)
+	assert.Equal(t, "git@github.com:owner/repo.git", results[1].Repo.Repo, "a duplicate keeps the URL of its own occurrence")
+	assert.True(t, results[1].UpdateRequired)
+
+	require.NoError(t, bumper.Update(t.Context()))
+
+	updated, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, strings.ReplaceAll(content, "rev: v1.0.0", "rev: v1.1.0"), string(updated), "every occurrence is rewritten")
+}
+
+func TestBumper_CheckRepos_CustomAPIURLs(t *testing.T) {
+	gitHubServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/owner/repo/git/refs/tags", r.URL.Path)
+		_, _ = w.Write([]byte(`[{"ref": "refs/tags/v1.0.0"}, {"ref": "refs/tags/v1.1.0"}]`))
+	}))
+	defer gitHubServer.Close()
+
+	gitLabServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/projects/group/project/repository/tags", r.URL.Path)
+		_, _ = w.Write([]byte(`[{"name": "v2.0.0"}, {"name": "v2.1.0"}]`))
+	}))
+	defer gitLabServer.Close()
+
+	configPath := filepath.Join(t.TempDir(), ".pre-commit-config.yaml")
+	content := fmt.Sprintf(`repos:
+  - repo: %s/owner/repo
+    rev: v1.0.0
+  - repo: %s/group/project
+    rev: v2.0.0
+`, gitHubServer.URL, gitLabServer.URL)
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0644))
+
+	cfg := &config.Config{
+		PreCommitConfigPaths: []string{configPath},
+		Allow:                config.BumpMajor,
+		GitHubAPIURL:         gitHubServer.URL,
+		GitLabAPIURL:         gitLabServer.URL + "/",
+		MaxAttempts:          1,
+		MaxConcurrency:       1,
+		Logger:               zap.NewNop(),
+	}
+	filesystem := io.NewOSFileSystem()
+	bumper := NewBumper(parser.NewParser(cfg.Logger, filesystem), cfg, io.NewResultWriter(filesystem, new(bytes.Buffer), cfg.Logger), http.DefaultClient)
+
+	results, err := bumper.CheckRepos(t.Context())
+	require.NoError(t, err)
+
+	require.Len(t, results, 2)
+	require.NoError(t, results[0].Error)
+	require.NoError(t, results[1].Error)
+	assert.Equal(t, "1.1.0", results[0].BumpVersion().String())
+	assert.Equal(t, "2.1.0", results[1].BumpVersion().String())
+}
+
+// approverFunc allows a function to be used as an Approver in tests
+type approverFunc func(result types.UpdateResult) (bool, error)
+
+func (f approverFunc) Approve(result types.UpdateResult) (bool, error) {
+	return f(result)
+}
+
+func TestBumper_Update_Approver(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"ref": "refs/tags/v1.0.0"}, {"ref": "refs/tags/v1.1.0"}]`))
+	}))
+	defer server.Close()
+
+	content := `repos:
+  - repo: https://github.com/owner/approved
+    rev: v1.0.0
+  - repo: https://github.com/owner/declined
+    rev: v1.0.0
+  - repo: https://github.com/owner/declined
+    rev: v1.0.0
+`
+	newBumper := func(t *testing.T, dryRun bool) (*Bumper, string) {
+		dir := t.TempDir()
+		t.Chdir(dir)
+		t.Setenv(config.EnvGitHubStepSummary, "")
+		configPath := filepath.Join(dir, ".pre-commit-config.yaml")
+		require.NoError(t, os.WriteFile(configPath, []byte(content), 0644))
+
+		cfg := &config.Config{
+			PreCommitConfigPaths: []string{configPath},
+			Allow:                config.BumpMajor,
+			DryRun:               dryRun,
+			Logger:               zap.NewNop(),
+		}
+		filesystem := io.NewOSFileSystem()
+		return NewBumper(parser.NewParser(cfg.Logger, filesystem), cfg, io.NewResultWriter(filesystem, new(bytes.Buffer), cfg.Logger), publicAPIClient(server, config.DefaultGitHubAPIURL)), configPath
+	}
+
+	t.Run("declined updates are not written", func(t *testing.T) {
+		bumper, configPath := newBumper(t, false)
+		var asked []string
+		bumper.SetApprover(approverFunc(func(result types.UpdateResult) (bool, error) {
+			asked = append(asked, result.Name())
+			return result.Repo.Repo == "https://github.com/owner/approved", nil
+		}))
+
+		require.NoError(t, bumper.Update(t.Context()))
+
+		assert.Equal(t, []string{"https://github.com/owner/approved", "https://github.com/owner/declined"}, asked,
+			"a repeated repository is only asked once")
+		updated, err := os.ReadFile(configPath)
+		require.NoError(t, err)
+		assert.Equal(t, strings.Replace(content, "rev: v1.0.0", "rev: v1.1.0", 1), string(updated))
+
+		summary, err := os.ReadFile("summary.md")
+		require.NoError(t, err)
+		assert.Contains(t, string(summary), "- ⏭️ **https://github.com/owner/declined**: v1.0.0 (update to 1.1.0 declined)")
+		assert.Contains(t, string(summary), "- 🔄 **1** hooks updated")
+		assert.Contains(t, string(summary), "- ⏭️ **1** updates declined")
+	})
+
+	t.Run("approver errors abort the update", func(t *testing.T) {
+		bumper, configPath := newBumper(t, false)
+		bumper.SetApprover(approverFunc(func(result types.UpdateResult) (bool, error) {
+			return false, errors.New("read failed")
+		}))
+
+		assert.ErrorContains(t, bumper.Update(t.Context()), "failed to approve update of https://github.com/owner/approved: read failed")
+		unchanged, err := os.ReadFile(configPath)
+		require.NoError(t, err)
+		assert.Equal(t, content, string(unchanged))
+	})
+
+	t.Run("approver is not asked on a dry run", func(t *testing.T) {
+		bumper, _ := newBumper(t, true)
+		bumper.SetApprover(approverFunc(func(result types.UpdateResult) (bool, error) {
+			t.Fatalf("unexpected approval of %s", result.Name())
+			return false, nil
+		}))
+
+		require.NoError(t, bumper.Update(t.Context()))
+	})
+}
+
+func TestBumper_TagPrefix(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"ref": "refs/tags/v2.0.0"}, {"ref": "refs/tags/backend-1.2.0"}, {"ref": "refs/tags/backend-1.3.0"},
+			{"ref": "refs/tags/release/1.0.0"}, {"ref": "refs/tags/release/1.1.0"}]`))
+	}))
+	defer server.Close()
+
+	content := `repos:
+  - repo: https://github.com/owner/backend
+    rev: backend-1.2.0 # pcb:tag-prefix=backend-
+  - repo: https://github.com/owner/release
+    rev: release/1.0.0
+`
+	configPath := filepath.Join(t.TempDir(), ".pre-commit-config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0644))
+
+	cfg := &config.Config{
+		PreCommitConfigPaths: []string{configPath},
+		Allow:                config.BumpMajor,
+		TagPrefix:            "release/",
+		NoSummary:            true,
+		Logger:               zap.NewNop(),
+	}
+	filesystem := io.NewOSFileSystem()
+	bumper := NewBumper(parser.NewParser(cfg.Logger, filesystem), cfg, io.NewResultWriter(filesystem, new(bytes.Buffer), cfg.Logger), publicAPIClient(server, config.DefaultGitHubAPIURL))
+
+	results, err := bumper.CheckRepos(t.Context())
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "backend-", results[0].Repo.TagPrefix, "the annotation takes precedence over --tag-prefix")
+	assert.Equal(t, "1.3.0", results[0].LatestVersion.String())
+	assert.Equal(t, "release/", results[1].Repo.TagPrefix)
+	assert.Equal(t, "1.1.0", results[1].LatestVersion.String())
+
+	require.NoError(t, bumper.Update(t.Context()))
+
+	updated, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, `repos:
+  - repo: https://github.com/owner/backend
+    rev: backend-1.3.0 # pcb:tag-prefix=backend-
+  - repo: https://github.com/owner/release
+    rev: release/1.1.0
+`, string(updated))
+}
+
+func TestBumper_MultipleConfigs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"ref": "refs/tags/v1.0.0"}, {"ref": "refs/tags/v1.1.0"}]`))
+	}))
+	defer server.Close()
+
+	upToDate := "repos:\n  - repo: https://github.com/owner/current\n    rev: v1.1.0\n"
+	outdated := "repos:\n  - repo: https://github.com/owner/outdated\n    rev: v1.0.0\n"
+
+	newBumper := func(t *testing.T) (*Bumper, string, string) {
+		dir := t.TempDir()
+		upToDatePath := filepath.Join(dir, ".pre-commit-config.yaml")
+		outdatedPath := filepath.Join(dir, ".pre-commit-config-docs.yaml")
+		require.NoError(t, os.WriteFile(upToDatePath, []byte(upToDate), 0644))
+		require.NoError(t, os.WriteFile(outdatedPath, []byte(outdated), 0644))
+
+		cfg := &config.Config{
+			PreCommitConfigPaths: []string{filepath.Join(dir, ".pre-commit-config*.yaml"), upToDatePath},
+			Allow:                config.BumpMajor,
+			NoSummary:            true,
+			Logger:               zap.NewNop(),
+		}
+		filesystem := io.NewOSFileSystem()
+		bumper := NewBumper(parser.NewParser(cfg.Logger, filesystem), cfg, io.NewResultWriter(filesystem, new(bytes.Buffer), cfg.Logger), publicAPIClient(server, config.DefaultGitHubAPIURL))
+		return bumper, upToDatePath, outdatedPath
 	}
+
+	t.Run("check aggregates the results of all files", func(t *testing.T) {
+		bumper, upToDatePath, outdatedPath := newBumper(t)
+
+		results, err := bumper.CheckRepos(t.Context())
+		require.NoError(t, err)
+		require.Len(t, results, 2, "files matched by the glob and listed explicitly are only processed once")
+		assert.Equal(t, outdatedPath, results[0].ConfigPath)
+		assert.True(t, results[0].UpdateRequired)
+		assert.Equal(t, upToDatePath, results[1].ConfigPath)
+		assert.False(t, results[1].UpdateRequired)
+
+		assert.ErrorIs(t, bumper.Check(t.Context()), ErrUpdatesAvailable)
+	})
+
+	t.Run("update only rewrites the outdated file", func(t *testing.T) {
+		bumper, upToDatePath, outdatedPath := newBumper(t)
+
+		require.NoError(t, bumper.Update(t.Context()))
+
+		content, err := os.ReadFile(upToDatePath)
+		require.NoError(t, err)
+		assert.Equal(t, upToDate, string(content))
+
+		content, err = os.ReadFile(outdatedPath)
+		require.NoError(t, err)
+		assert.Equal(t, "repos:\n  - repo: https://github.com/owner/outdated\n    rev: v1.1.0\n", string(content))
+	})
+
+	t.Run("glob without matches", func(t *testing.T) {
+		bumper := &Bumper{cfg: &config.Config{PreCommitConfigPaths: []string{filepath.Join(t.TempDir(), "*.yaml")}}}
+
+		_, err := bumper.configPaths()
+		assert.ErrorContains(t, err, "no pre-commit configuration files match")
+	})
 }
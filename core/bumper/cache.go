@@ -0,0 +1,102 @@
+package bumper
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/ramonvermeulen/pre-commit-bump/config"
+	"github.com/ramonvermeulen/pre-commit-bump/core/types"
+)
+
+// versionCache caches the versions of a repository within a single run.
+// It is safe for concurrent use, concurrent lookups of the same repository share a single fetch.
+type versionCache struct {
+	mu      sync.Mutex
+	entries map[string]*versionCacheEntry
+}
+
+// versionCacheEntry holds the outcome of fetching the versions of a single repository.
+type versionCacheEntry struct {
+	once     sync.Once
+	versions []*types.SemanticVersion
+	err      error
+}
+
+// newVersionCache creates a new empty versionCache.
+func newVersionCache() *versionCache {
+	return &versionCache{
+		entries: map[string]*versionCacheEntry{},
+	}
+}
+
+// getVersions returns the cached versions of the repository, or calls fetch and caches its outcome on a miss.
+// A nil cache always calls fetch.
+func (c *versionCache) getVersions(repo *types.Repo, fetch func() ([]*types.SemanticVersion, error)) ([]*types.SemanticVersion, error) {
+	return c.get(versionCacheKey(repo), fetch)
+}
+
+// getDependencyVersions returns the cached versions of the dependency, or calls fetch and caches its outcome on a miss.
+// A nil cache always calls fetch.
+func (c *versionCache) getDependencyVersions(dependency *types.Dependency, fetch func() ([]*types.SemanticVersion, error)) ([]*types.SemanticVersion, error) {
+	return c.get(dependencyCacheKey(dependency), fetch)
+}
+
+// get returns the cached versions of the given key, or calls fetch and caches its outcome on a miss.
+func (c *versionCache) get(key string, fetch func() ([]*types.SemanticVersion, error)) ([]*types.SemanticVersion, error) {
+	if c == nil {
+		return fetch()
+	}
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	if !ok {
+		entry = &versionCacheEntry{}
+		c.entries[key] = entry
+	}
+	c.mu.Unlock()
+
+	entry.once.Do(func() {
+		entry.versions, entry.err = fetch()
+	})
+
+	return entry.versions, entry.err
+}
+
+// versionCacheKey builds the cache key from the normalized repository URL and its version scheme.
+// The scheme and tag prefix are part of the key because the tags of a repository are parsed and filtered according to them.
+// The URL is normalized with types.NormalizeRepoURL, so the SSH and HTTPS URLs of a repository share an entry.
+func versionCacheKey(repo *types.Repo) string {
+	normalized := strings.ToLower(types.NormalizeRepoURL(strings.TrimSpace(repo.Repo)))
+	normalized = strings.TrimSuffix(normalized, "/")
+	normalized = strings.TrimSuffix(normalized, ".git")
+	key := normalized + "@" + string(repo.Scheme)
+	if repo.TagPrefix != "" {
+		key += "@" + repo.TagPrefix
+	}
+	return key
+}
+
+// repoCheckKey identifies the occurrences of a repository that yield the same result when checked.
+// Besides the normalized URL and version scheme of the version cache, the revision and the annotations are part of the key.
+func repoCheckKey(repo *types.Repo) string {
+	return strings.Join([]string{versionCacheKey(repo), repo.Rev, repo.Frozen, repo.AllowOverride, repo.Constraint}, "\x00")
+}
+
+// dependencyCacheKey builds the cache key from the source and the normalized package name of the dependency.
+func dependencyCacheKey(dependency *types.Dependency) string {
+	if dependency.Source == config.DependencySourceNpm {
+		return config.DependencySourceNpm + ":" + normalizedPackageName(dependency)
+	}
+	return config.DependencySourcePyPI + ":" + normalizedPackageName(dependency)
+}
+
+// normalizedPackageName returns the package name of the dependency as its registry compares it.
+// PyPI compares names case-insensitively and treats "-", "_" and "." as equal, npm package names are used as is.
+func normalizedPackageName(dependency *types.Dependency) string {
+	if dependency.Source == config.DependencySourceNpm {
+		return dependency.Name
+	}
+
+	normalized := strings.ToLower(dependency.Name)
+	return strings.NewReplacer("_", "-", ".", "-").Replace(normalized)
+}
@@ -0,0 +1,91 @@
+package bumper
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/ramonvermeulen/pre-commit-bump/config"
+	"github.com/ramonvermeulen/pre-commit-bump/core/types"
+)
+
+func TestBumper_checkReposForUpdates_CachesVersions(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(`[{"ref": "refs/tags/v1.0.0"}, {"ref": "refs/tags/v1.1.0"}]`))
+	}))
+	defer server.Close()
+
+	bumper := &Bumper{
+		cfg: &config.Config{
+			Allow:  config.BumpMajor,
+			Logger: zap.NewNop(),
+		},
+		httpClient: publicAPIClient(server, config.DefaultGitHubAPIURL),
+		cache:      newVersionCache(),
+	}
+
+	repos := []types.Repo{
+		{Repo: "https://github.com/owner/repo", Rev: "v1.0.0", Scheme: types.VersionSchemeSemVer, SemVer: &types.SemanticVersion{Major: 1}},
+		{Repo: "https://github.com/owner/repo.git", Rev: "v1.0.0", Scheme: types.VersionSchemeSemVer, SemVer: &types.SemanticVersion{Major: 1}},
+	}
+
+	results := bumper.checkReposForUpdates(t.Context(), repos)
+
+	require.Len(t, results, 2)
+	for _, result := range results {
+		assert.NoError(t, result.Error)
+		assert.True(t, result.UpdateRequired)
+		assert.Equal(t, "1.1.0", result.LatestVersion.String())
+	}
+	assert.Equal(t, int32(1), calls.Load())
+
+	bumper.checkReposForUpdates(t.Context(), repos)
+	assert.Equal(t, int32(1), calls.Load(), "versions should be reused for a subsequent check in the same run")
+}
+
+func TestVersionCacheKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        types.Repo
+		b        types.Repo
+		expected bool
+	}{
+		{
+			name:     "trailing slash and .git suffix are ignored",
+			a:        types.Repo{Repo: "https://github.com/Owner/Repo"},
+			b:        types.Repo{Repo: "https://github.com/owner/repo.git/"},
+			expected: true,
+		},
+		{
+			name:     "ssh and https urls of the same repository",
+			a:        types.Repo{Repo: "git@github.com:owner/repo.git"},
+			b:        types.Repo{Repo: "https://github.com/owner/repo"},
+			expected: true,
+		},
+		{
+			name:     "different version schemes are cached separately",
+			a:        types.Repo{Repo: "https://github.com/owner/repo", Scheme: types.VersionSchemeSemVer},
+			b:        types.Repo{Repo: "https://github.com/owner/repo", Scheme: types.VersionSchemeCalVer},
+			expected: false,
+		},
+		{
+			name:     "different repositories",
+			a:        types.Repo{Repo: "https://github.com/owner/repo"},
+			b:        types.Repo{Repo: "https://github.com/owner/other"},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, versionCacheKey(&tt.a) == versionCacheKey(&tt.b))
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package bumper
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ramonvermeulen/pre-commit-bump/core/types"
+)
+
+// CheckError is returned when one or more repositories or dependencies failed to be checked.
+// Its message groups identical failures, e.g. a rate limit that is hit for every repository, and lists the repositories
+// they occurred for. The individual errors are joined with errors.Join, so errors.Is and errors.As see every one of them.
+type CheckError struct {
+	groups []errorGroup
+	err    error
+}
+
+// errorGroup is a distinct failure and the names of the repositories or dependencies it occurred for.
+type errorGroup struct {
+	cause string
+	names []string
+}
+
+// newCheckError groups the errors of the failed results by their cause, in the order they first occurred.
+func newCheckError(failed []types.UpdateResult) *CheckError {
+	checkErr := &CheckError{}
+	errs := make([]error, 0, len(failed))
+	index := map[string]int{}
+
+	for _, result := range failed {
+		errs = append(errs, result.Error)
+
+		cause := errorCause(result.Error).Error()
+		i, ok := index[cause]
+		if !ok {
+			i = len(checkErr.groups)
+			index[cause] = i
+			checkErr.groups = append(checkErr.groups, errorGroup{cause: cause})
+		}
+		checkErr.groups[i].names = append(checkErr.groups[i].names, result.Name())
+	}
+
+	checkErr.err = errors.Join(errs...)
+	return checkErr
+}
+
+// Error returns the grouped failures, e.g. "GitHub API returned status 403 (3 times: owner/a, owner/b, owner/c)".
+func (e *CheckError) Error() string {
+	groups := make([]string, 0, len(e.groups))
+	for _, group := range e.groups {
+		names := strings.Join(group.names, ", ")
+		if len(group.names) > 1 {
+			groups = append(groups, fmt.Sprintf("%s (%d times: %s)", group.cause, len(group.names), names))
+		} else {
+			groups = append(groups, fmt.Sprintf("%s (%s)", group.cause, names))
+		}
+	}
+	return "errors occurred while checking repositories: " + strings.Join(groups, "; ")
+}
+
+// Unwrap returns the joined individual errors.
+func (e *CheckError) Unwrap() error {
+	return e.err
+}
+
+// errorCause strips the repository specific context the check adds, e.g. "failed to get latest version for <repo>",
+// so the same failure on different repositories is grouped together.
+func errorCause(err error) error {
+	if cause := errors.Unwrap(err); cause != nil {
+		return cause
+	}
+	return err
+}
+
+// NoVersionsError is returned when none of the tags of a repository is a valid version, optionally after its tag prefix.
+// It is recorded in the negative cache, so the tags of the repository are not fetched again on every run.
+type NoVersionsError struct {
+	Repo      string
+	Rev       string
+	TagPrefix string
+}
+
+// newNoVersionsError creates a NoVersionsError for the repository.
+func newNoVersionsError(repo *types.Repo) *NoVersionsError {
+	return &NoVersionsError{Repo: repo.Repo, Rev: repo.Rev, TagPrefix: repo.TagPrefix}
+}
+
+// Error returns which repository has no version tags, including the tag prefix the tags were selected by.
+func (e *NoVersionsError) Error() string {
+	if e.TagPrefix != "" {
+		return fmt.Sprintf("no semantic version tags with prefix %q found for repo: %s with rev: %s", e.TagPrefix, e.Repo, e.Rev)
+	}
+	return fmt.Sprintf("no semantic version tags found for repo: %s with rev: %s", e.Repo, e.Rev)
+}
+
+// UpdatesAvailableError is returned by Check when updates are available, Results are the hooks and dependencies that can
+// be bumped. It unwraps to ErrUpdatesAvailable, so errors.Is tells it apart from a failing check, and errors.As gives
+// access to the results.
+type UpdatesAvailableError struct {
+	Results []types.UpdateResult
+}
+
+// newUpdatesAvailableError collects the results that require an update, repeated occurrences are only listed once.
+func newUpdatesAvailableError(results []types.UpdateResult) *UpdatesAvailableError {
+	updatesErr := &UpdatesAvailableError{}
+	for _, result := range results {
+		if result.UpdateRequired && result.Error == nil && !result.Duplicate {
+			updatesErr.Results = append(updatesErr.Results, result)
+		}
+	}
+	return updatesErr
+}
+
+// Error returns the number of available updates, e.g. "updates are available for 2 hooks".
+func (e *UpdatesAvailableError) Error() string {
+	if len(e.Results) == 1 {
+		return fmt.Sprintf("%s for 1 hook", ErrUpdatesAvailable)
+	}
+	return fmt.Sprintf("%s for %d hooks", ErrUpdatesAvailable, len(e.Results))
+}
+
+// Unwrap returns ErrUpdatesAvailable.
+func (e *UpdatesAvailableError) Unwrap() error {
+	return ErrUpdatesAvailable
+}
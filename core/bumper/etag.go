@@ -0,0 +1,53 @@
+package bumper
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/ramonvermeulen/pre-commit-bump/core/io"
+)
+
+// withETag adds an If-None-Match header to the request when the cache holds an entry for the URL.
+// It returns the cached entry, or nil when there is none.
+func withETag(req *http.Request, etags *io.ETagCache, url string) *io.ETagEntry {
+	entry, ok := etags.Get(url)
+	if !ok {
+		return nil
+	}
+
+	req.Header.Set("If-None-Match", entry.ETag)
+	return entry
+}
+
+// decodeCachedTags decodes the tags of a cached entry, used when the API responds with 304 Not Modified.
+func decodeCachedTags[T TagProvider](entry *io.ETagEntry) ([]T, error) {
+	var tags []T
+	if err := json.Unmarshal(entry.Body, &tags); err != nil {
+		return nil, fmt.Errorf("failed to decode cached response: %w", err)
+	}
+	return tags, nil
+}
+
+// decodeTags decodes the tags of a response and stores the body in the cache when the response carries an ETag.
+// Failing to write the cache is not fatal, the tags are returned regardless.
+func decodeTags[T TagProvider](resp *http.Response, etags *io.ETagCache, url string, next string) ([]T, error) {
+	var body json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+
+	var tags []T
+	if err := json.Unmarshal(body, &tags); err != nil {
+		return nil, err
+	}
+
+	if etag := resp.Header.Get("ETag"); etag != "" {
+		if cacheErr := etags.Put(url, etag, body, next); cacheErr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to cache response of %s: %v\n", url, cacheErr)
+		}
+	}
+
+	return tags, nil
+}
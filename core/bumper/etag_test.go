@@ -0,0 +1,67 @@
+package bumper
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/ramonvermeulen/pre-commit-bump/config"
+	"github.com/ramonvermeulen/pre-commit-bump/core/io"
+	"github.com/ramonvermeulen/pre-commit-bump/core/types"
+)
+
+func TestGithubBumper_fetchTags_ETagCache(t *testing.T) {
+	var ifNoneMatch []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ifNoneMatch = append(ifNoneMatch, r.Header.Get("If-None-Match"))
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(`[{"ref": "refs/tags/v1.0.0"}, {"ref": "refs/tags/v1.4.0"}]`))
+	}))
+	defer server.Close()
+
+	etags := io.NewETagCache(io.NewOSFileSystem(), t.TempDir(), time.Hour)
+	repo := &types.Repo{Repo: "https://github.com/owner/repo"}
+
+	first, err := NewGithubBumper(zap.NewNop(), publicAPIClient(server, config.DefaultGitHubAPIURL), NewRetryPolicy(1), GithubOptions{ETags: etags}).GetVersions(t.Context(), repo)
+	require.NoError(t, err)
+
+	second, err := NewGithubBumper(zap.NewNop(), publicAPIClient(server, config.DefaultGitHubAPIURL), NewRetryPolicy(1), GithubOptions{ETags: etags}).GetVersions(t.Context(), repo)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"", `"v1"`}, ifNoneMatch)
+	assert.Equal(t, first, second)
+	assert.Equal(t, "1.4.0", findLatestVersion(second, false).String())
+}
+
+func TestGitLabBumper_fetchTags_ETagCache(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `W/"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `W/"v1"`)
+		_, _ = w.Write([]byte(`[{"name": "v2.0.0"}]`))
+	}))
+	defer server.Close()
+
+	etags := io.NewETagCache(io.NewOSFileSystem(), t.TempDir(), time.Hour)
+	gitlabBumper := NewGitLabBumper(zap.NewNop(), publicAPIClient(server, config.DefaultGitLabAPIURL), NewRetryPolicy(1), GitLabOptions{ETags: etags})
+
+	for range 2 {
+		versions, err := gitlabBumper.GetVersions(t.Context(), &types.Repo{Repo: "https://gitlab.com/owner/repo"})
+		require.NoError(t, err)
+		assert.Equal(t, "2.0.0", findLatestVersion(versions, false).String())
+	}
+	assert.Equal(t, 2, requests)
+}
@@ -0,0 +1,98 @@
+package bumper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ramonvermeulen/pre-commit-bump/config"
+	"github.com/ramonvermeulen/pre-commit-bump/core/types"
+)
+
+// NpmClient is a struct that implements the DependencyResolver interface for node packages on the npm registry.
+type NpmClient struct {
+	client *http.Client
+	apiURL string
+	retry  RetryPolicy
+}
+
+// NewNpmClient creates a new instance of NpmClient with the provided HTTP client and retry policy.
+func NewNpmClient(client *http.Client, retry RetryPolicy) *NpmClient {
+	return &NpmClient{
+		client: client,
+		apiURL: config.DefaultNpmRegistryURL,
+		retry:  retry,
+	}
+}
+
+// NpmVersion represents a single published version of a package on the npm registry.
+type NpmVersion struct {
+	Deprecated string `json:"deprecated"`
+}
+
+// NpmPackage represents the abbreviated metadata of a package on the npm registry.
+type NpmPackage struct {
+	Versions map[string]NpmVersion `json:"versions"`
+}
+
+// GetVersions retrieves the published versions of a package from the npm registry.
+// Deprecated versions, pre-releases and versions that are not a valid semantic version are skipped.
+func (n *NpmClient) GetVersions(ctx context.Context, name string) ([]*types.SemanticVersion, error) {
+	pkg, err := n.fetchPackage(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []*types.SemanticVersion
+	for version, metadata := range pkg.Versions {
+		if metadata.Deprecated != "" {
+			continue
+		}
+		semVer, ok := types.GetSemanticVersion(version)
+		if !ok || semVer.Original != version || semVer.PreRelease != "" {
+			continue
+		}
+		versions = append(versions, semVer)
+	}
+
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("no release versions found on npm for %s", name)
+	}
+
+	return versions, nil
+}
+
+// fetchPackage retrieves the abbreviated metadata of a package from the npm registry.
+// The slash of scoped packages like "@types/node" is escaped, as the registry expects.
+func (n *NpmClient) fetchPackage(ctx context.Context, name string) (*NpmPackage, error) {
+	apiURL := fmt.Sprintf("%s/%s", strings.TrimSuffix(n.apiURL, "/"), url.PathEscape(name))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create npm registry request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.npm.install-v1+json")
+
+	resp, err := n.retry.Do(n.client, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call npm registry: %w", err)
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("package %s not found on npm", name)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("npm registry returned status %d", resp.StatusCode)
+	}
+
+	var pkg NpmPackage
+	if err := json.NewDecoder(resp.Body).Decode(&pkg); err != nil {
+		return nil, fmt.Errorf("failed to decode npm registry response: %w", err)
+	}
+
+	return &pkg, nil
+}
@@ -0,0 +1,85 @@
+package bumper
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ramonvermeulen/pre-commit-bump/config"
+	"github.com/ramonvermeulen/pre-commit-bump/core/types"
+)
+
+func TestNpmClient_GetVersions(t *testing.T) {
+	var requestedPath, accept string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPath = r.URL.EscapedPath()
+		accept = r.Header.Get("Accept")
+		_, _ = w.Write([]byte(`{"name": "@typescript-eslint/parser", "versions": {
+			"6.0.0": {},
+			"6.19.1": {},
+			"6.21.0": {"deprecated": "broken release"},
+			"7.0.0-alpha.1": {},
+			"7.0.0": {},
+			"latest": {}
+		}}`))
+	}))
+	defer server.Close()
+
+	client := NewNpmClient(server.Client(), NewRetryPolicy(1))
+	client.apiURL = server.URL
+
+	versions, err := client.GetVersions(t.Context(), "@typescript-eslint/parser")
+	require.NoError(t, err)
+
+	assert.Equal(t, "/@typescript-eslint%2Fparser", requestedPath, "the slash of a scoped package should be escaped")
+	assert.Equal(t, "application/vnd.npm.install-v1+json", accept)
+	assert.Len(t, versions, 3, "deprecated versions, pre-releases and invalid versions should be skipped")
+	assert.Equal(t, "7.0.0", findLatestVersion(versions, false).String())
+	assert.Equal(t, "6.19.1", findAllowedVersion(sortCandidates(versions, true), &types.SemanticVersion{Major: 6}, config.BumpMinor).String(),
+		"the latest version under the allow policy should be selected")
+}
+
+func TestNpmClient_GetVersions_Errors(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		expectedError string
+	}{
+		{
+			name:          "package not found",
+			status:        http.StatusNotFound,
+			expectedError: "package missing not found on npm",
+		},
+		{
+			name:          "unexpected status",
+			status:        http.StatusInternalServerError,
+			expectedError: "npm registry returned status 500",
+		},
+		{
+			name:          "no release versions",
+			status:        http.StatusOK,
+			body:          `{"versions": {"1.0.0-beta.1": {}, "0.9.0": {"deprecated": "use 1.x"}}}`,
+			expectedError: "no release versions found on npm for missing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := NewNpmClient(server.Client(), NewRetryPolicy(1))
+			client.apiURL = server.URL
+
+			_, err := client.GetVersions(t.Context(), "missing")
+			assert.ErrorContains(t, err, tt.expectedError)
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package bumper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ramonvermeulen/pre-commit-bump/config"
+	"github.com/ramonvermeulen/pre-commit-bump/core/types"
+)
+
+// DependencyResolver defines the interface for resolving the available versions of a hook dependency.
+type DependencyResolver interface {
+	GetVersions(ctx context.Context, name string) ([]*types.SemanticVersion, error)
+}
+
+// PyPIClient is a struct that implements the DependencyResolver interface for Python packages on PyPI.
+type PyPIClient struct {
+	client *http.Client
+	apiURL string
+	retry  RetryPolicy
+}
+
+// NewPyPIClient creates a new instance of PyPIClient with the provided HTTP client and retry policy.
+func NewPyPIClient(client *http.Client, retry RetryPolicy) *PyPIClient {
+	return &PyPIClient{
+		client: client,
+		apiURL: config.DefaultPyPIURL,
+		retry:  retry,
+	}
+}
+
+// PyPIRelease represents a single distribution file of a release on PyPI.
+type PyPIRelease struct {
+	Yanked bool `json:"yanked"`
+}
+
+// PyPIProject represents the JSON API response of a project on PyPI.
+type PyPIProject struct {
+	Releases map[string][]PyPIRelease `json:"releases"`
+}
+
+// GetVersions retrieves the released versions of a package from PyPI.
+// Releases without files, releases of which every file is yanked, and pre-, post- and dev-releases are skipped.
+func (p *PyPIClient) GetVersions(ctx context.Context, name string) ([]*types.SemanticVersion, error) {
+	project, err := p.fetchProject(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []*types.SemanticVersion
+	for version, files := range project.Releases {
+		if !hasAvailableFile(files) {
+			continue
+		}
+		semVer, ok := types.GetReleaseVersion(version)
+		if !ok {
+			continue
+		}
+		versions = append(versions, semVer)
+	}
+
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("no release versions found on PyPI for %s", name)
+	}
+
+	return versions, nil
+}
+
+// fetchProject retrieves the project metadata of a package using the PyPI JSON API.
+func (p *PyPIClient) fetchProject(ctx context.Context, name string) (*PyPIProject, error) {
+	apiURL := fmt.Sprintf("%s/%s/json", strings.TrimSuffix(p.apiURL, "/"), url.PathEscape(name))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create PyPI API request: %w", err)
+	}
+
+	resp, err := p.retry.Do(p.client, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call PyPI API: %w", err)
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("package %s not found on PyPI", name)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("PyPI API returned status %d", resp.StatusCode)
+	}
+
+	var project PyPIProject
+	if err := json.NewDecoder(resp.Body).Decode(&project); err != nil {
+		return nil, fmt.Errorf("failed to decode PyPI API response: %w", err)
+	}
+
+	return &project, nil
+}
+
+// hasAvailableFile reports whether at least one file of a release is not yanked.
+func hasAvailableFile(files []PyPIRelease) bool {
+	for _, file := range files {
+		if !file.Yanked {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package bumper
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPyPIClient_GetVersions(t *testing.T) {
+	var requestedPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPath = r.URL.Path
+		_, _ = w.Write([]byte(`{"releases": {
+			"22.1.11": [{"yanked": false}],
+			"23.0": [{"yanked": false}],
+			"24.1.0": [{"yanked": true}],
+			"24.2.0rc1": [{"yanked": false}],
+			"24.3.0": []
+		}}`))
+	}))
+	defer server.Close()
+
+	client := NewPyPIClient(server.Client(), NewRetryPolicy(1))
+	client.apiURL = server.URL
+
+	versions, err := client.GetVersions(t.Context(), "flake8-bugbear")
+	require.NoError(t, err)
+
+	assert.Equal(t, "/flake8-bugbear/json", requestedPath)
+	assert.Len(t, versions, 2)
+	assert.Equal(t, "23.0", findLatestVersion(versions, false).String())
+}
+
+func TestPyPIClient_GetVersions_Errors(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		expectedError string
+	}{
+		{
+			name:          "package not found",
+			status:        http.StatusNotFound,
+			expectedError: "package missing not found on PyPI",
+		},
+		{
+			name:          "unexpected status",
+			status:        http.StatusForbidden,
+			expectedError: "PyPI API returned status 403",
+		},
+		{
+			name:          "no release versions",
+			status:        http.StatusOK,
+			body:          `{"releases": {"1.0.0a1": [{"yanked": false}]}}`,
+			expectedError: "no release versions found on PyPI for missing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := NewPyPIClient(server.Client(), NewRetryPolicy(1))
+			client.apiURL = server.URL
+
+			_, err := client.GetVersions(t.Context(), "missing")
+			assert.ErrorContains(t, err, tt.expectedError)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package bumper
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/ramonvermeulen/pre-commit-bump/core/types"
+)
+
+// TagResolver is implemented by RepoBumpers that can resolve a tag to the commit it points to.
+// It is required to bump revisions that are pinned to a commit SHA with a "# frozen: <tag>" comment.
+type TagResolver interface {
+	ResolveTag(ctx context.Context, repo *types.Repo, tag string) (string, error)
+}
+
+// resolveTagResponse sends a request that resolves a tag and returns the response body.
+// Annotated tags are expected to be dereferenced by the API, so the body describes the commit the tag points to.
+func resolveTagResponse(client *http.Client, retry RetryPolicy, req *http.Request, vendor string) ([]byte, error) {
+	resp, err := retry.Do(client, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s API: %w", vendor, err)
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to close response body: %v\n", closeErr)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s API returned status %d while resolving the tag", vendor, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s API response: %w", vendor, err)
+	}
+
+	return body, nil
+}
@@ -0,0 +1,129 @@
+package bumper
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/ramonvermeulen/pre-commit-bump/config"
+)
+
+// RetryPolicy retries HTTP requests on transient failures with exponential backoff and jitter.
+// Network errors, 429 and 5xx responses are considered transient.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first request
+	MaxAttempts int
+
+	// BaseDelay is the delay before the first retry, it doubles with every subsequent retry
+	BaseDelay time.Duration
+
+	// MaxDelay caps the backoff delay, a Retry-After header exceeding it is not waited for
+	MaxDelay time.Duration
+
+	sleep func(time.Duration)
+}
+
+// NewRetryPolicy creates a new RetryPolicy with the given attempt count and the default delays.
+func NewRetryPolicy(maxAttempts int) RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: maxAttempts,
+		BaseDelay:   config.DefaultRetryBaseDelay,
+		MaxDelay:    config.DefaultRetryMaxDelay,
+	}
+}
+
+// Do sends the request with the given client and retries it on transient failures.
+// It returns the last response or error once the request succeeds, fails permanently or the attempts are exhausted.
+func (p RetryPolicy) Do(client *http.Client, req *http.Request) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := client.Do(req.Clone(req.Context()))
+
+		if attempt >= p.MaxAttempts || !isTransient(resp, err) || req.Context().Err() != nil {
+			return resp, err
+		}
+
+		delay := p.backoff(attempt)
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+				if retryAfter > p.MaxDelay {
+					return resp, err
+				}
+				delay = retryAfter
+			}
+			closeBody(resp)
+		}
+
+		if err := p.wait(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// backoff returns the exponential backoff delay with jitter for the given attempt.
+// The delay is picked randomly between half and the full exponential delay.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay << (attempt - 1)
+	if delay <= 0 || delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	half := delay / 2
+	if half <= 0 {
+		return delay
+	}
+	return half + rand.N(half+1)
+}
+
+// wait sleeps for the given delay, or until the context is cancelled in which case the context error is returned.
+func (p RetryPolicy) wait(ctx context.Context, delay time.Duration) error {
+	if p.sleep != nil {
+		p.sleep(delay)
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// isTransient reports whether the outcome of a request is worth retrying.
+func isTransient(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+}
+
+// parseRetryAfter parses a Retry-After header, which is either a number of seconds or an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
+
+// closeBody closes the body of a response that is discarded before retrying.
+func closeBody(resp *http.Response) {
+	if closeErr := resp.Body.Close(); closeErr != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to close response body: %v\n", closeErr)
+	}
+}
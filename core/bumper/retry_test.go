@@ -0,0 +1,132 @@
+package bumper
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicy_Do(t *testing.T) {
+	tests := []struct {
+		name           string
+		maxAttempts    int
+		statuses       []int
+		retryAfter     string
+		expectedStatus int
+		expectedCalls  int
+		expectedSleeps []time.Duration
+	}{
+		{
+			name:           "fails twice then succeeds",
+			maxAttempts:    3,
+			statuses:       []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusOK},
+			expectedStatus: http.StatusOK,
+			expectedCalls:  3,
+		},
+		{
+			name:           "gives up after max attempts",
+			maxAttempts:    2,
+			statuses:       []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusOK},
+			expectedStatus: http.StatusBadGateway,
+			expectedCalls:  2,
+		},
+		{
+			name:           "does not retry client errors",
+			maxAttempts:    3,
+			statuses:       []int{http.StatusNotFound, http.StatusOK},
+			expectedStatus: http.StatusNotFound,
+			expectedCalls:  1,
+		},
+		{
+			name:           "respects Retry-After",
+			maxAttempts:    3,
+			statuses:       []int{http.StatusTooManyRequests, http.StatusOK},
+			retryAfter:     "2",
+			expectedStatus: http.StatusOK,
+			expectedCalls:  2,
+			expectedSleeps: []time.Duration{2 * time.Second},
+		},
+		{
+			name:           "does not wait for Retry-After beyond the max delay",
+			maxAttempts:    3,
+			statuses:       []int{http.StatusTooManyRequests, http.StatusOK},
+			retryAfter:     "3600",
+			expectedStatus: http.StatusTooManyRequests,
+			expectedCalls:  1,
+			expectedSleeps: []time.Duration{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(tt.statuses[calls])
+				calls++
+			}))
+			defer server.Close()
+
+			sleeps := []time.Duration{}
+			policy := NewRetryPolicy(tt.maxAttempts)
+			policy.sleep = func(delay time.Duration) { sleeps = append(sleeps, delay) }
+
+			req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+			require.NoError(t, err)
+
+			resp, err := policy.Do(server.Client(), req)
+			require.NoError(t, err)
+			defer closeBody(resp)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			assert.Equal(t, tt.expectedCalls, calls)
+			if tt.expectedSleeps != nil {
+				assert.Equal(t, tt.expectedSleeps, sleeps)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_DoNetworkError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	serverURL := server.URL
+	server.Close()
+
+	sleeps := 0
+	policy := NewRetryPolicy(3)
+	policy.sleep = func(time.Duration) { sleeps++ }
+
+	req, err := http.NewRequest(http.MethodGet, serverURL, nil)
+	require.NoError(t, err)
+
+	_, err = policy.Do(http.DefaultClient, req)
+
+	assert.Error(t, err)
+	assert.Equal(t, 2, sleeps)
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	for attempt, expectedMax := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 3: 400 * time.Millisecond, 10: time.Second} {
+		delay := policy.backoff(attempt)
+		assert.GreaterOrEqual(t, delay, expectedMax/2)
+		assert.LessOrEqual(t, delay, expectedMax)
+	}
+}
+
+func TestRetryPolicy_waitCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	err := NewRetryPolicy(3).wait(ctx, time.Hour)
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
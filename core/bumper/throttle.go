@@ -0,0 +1,60 @@
+package bumper
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// throttledTransport is an http.RoundTripper that limits the rate of requests per host.
+// Every host has a token bucket holding a single token, so requests to the same host are spaced by at least the interval,
+// while requests to different hosts do not wait for each other.
+type throttledTransport struct {
+	next     http.RoundTripper
+	interval time.Duration
+
+	mu    sync.Mutex
+	slots map[string]time.Time
+}
+
+// NewThrottledTransport wraps the RoundTripper so at most requestsPerSecond requests are sent to each host.
+// The rate limit is shared by all clients using the returned RoundTripper, including retried requests.
+func NewThrottledTransport(next http.RoundTripper, requestsPerSecond float64) http.RoundTripper {
+	return &throttledTransport{
+		next:     next,
+		interval: time.Duration(float64(time.Second) / requestsPerSecond),
+		slots:    map[string]time.Time{},
+	}
+}
+
+// RoundTrip waits until the host of the request may be sent another request and sends it with the wrapped RoundTripper.
+func (t *throttledTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	delay := t.reserve(req.URL.Host)
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+
+	return t.next.RoundTrip(req)
+}
+
+// reserve claims the next free slot of the host and returns how long to wait for it.
+func (t *throttledTransport) reserve(host string) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	slot := t.slots[host]
+	if slot.Before(now) {
+		slot = now
+	}
+	t.slots[host] = slot.Add(t.interval)
+
+	return slot.Sub(now)
+}
@@ -0,0 +1,52 @@
+package bumper
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThrottledTransport_Spacing(t *testing.T) {
+	var mu sync.Mutex
+	var received []time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		received = append(received, time.Now())
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	const requests = 4
+	const interval = 50 * time.Millisecond
+	client := &http.Client{Transport: NewThrottledTransport(http.DefaultTransport, float64(time.Second/interval))}
+
+	start := time.Now()
+	var waitGroup sync.WaitGroup
+	for range requests {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			resp, err := client.Get(server.URL)
+			if assert.NoError(t, err) {
+				_ = resp.Body.Close()
+			}
+		}()
+	}
+	waitGroup.Wait()
+
+	assert.GreaterOrEqual(t, time.Since(start), (requests-1)*interval, "concurrent requests should be spaced by the rate limit")
+	require.Len(t, received, requests)
+}
+
+func TestThrottledTransport_PerHost(t *testing.T) {
+	transport := NewThrottledTransport(http.DefaultTransport, 1).(*throttledTransport)
+
+	assert.Zero(t, transport.reserve("api.github.com"))
+	assert.Zero(t, transport.reserve("gitlab.com"), "other hosts should not wait for each other")
+	assert.InDelta(t, time.Second, transport.reserve("api.github.com"), float64(50*time.Millisecond))
+}
@@ -0,0 +1,96 @@
+package io
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"time"
+)
+
+// ETagEntry is a cached API response that can be revalidated with its ETag.
+type ETagEntry struct {
+	ETag     string          `json:"etag"`
+	Body     json.RawMessage `json:"body"`
+	Next     string          `json:"next,omitempty"`
+	StoredAt time.Time       `json:"stored_at"`
+}
+
+// ETagCache stores API responses on disk together with their ETag, so they can be reused between runs.
+// Requests send the ETag in an If-None-Match header, and a 304 Not Modified response reuses the cached body.
+// A nil ETagCache is valid and caches nothing.
+type ETagCache struct {
+	fs     FileSystem
+	dir    string
+	expiry time.Duration
+	now    func() time.Time
+}
+
+// NewETagCache creates a new ETagCache storing its entries in dir.
+// Entries older than expiry are ignored, an expiry of zero keeps entries forever.
+func NewETagCache(fs FileSystem, dir string, expiry time.Duration) *ETagCache {
+	return &ETagCache{
+		fs:     fs,
+		dir:    dir,
+		expiry: expiry,
+		now:    time.Now,
+	}
+}
+
+// Get returns the cached entry for the given URL.
+// It returns false if there is no entry, the entry can not be read or the entry is expired.
+func (c *ETagCache) Get(url string) (*ETagEntry, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	data, err := c.fs.ReadFile(c.path(url))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry ETagEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.ETag == "" {
+		return nil, false
+	}
+
+	if c.expiry > 0 && c.now().Sub(entry.StoredAt) > c.expiry {
+		return nil, false
+	}
+
+	return &entry, true
+}
+
+// Put stores the response body of the given URL together with its ETag and the URL of the next page.
+func (c *ETagCache) Put(url string, etag string, body []byte, next string) error {
+	if c == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(ETagEntry{
+		ETag:     etag,
+		Body:     body,
+		Next:     next,
+		StoredAt: c.now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache entry: %w", err)
+	}
+
+	if err := c.fs.MkdirAll(c.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory %s: %w", c.dir, err)
+	}
+
+	if err := c.fs.WriteFile(c.path(url), data, 0644); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+
+	return nil
+}
+
+// path returns the file the entry of the given URL is stored in.
+func (c *ETagCache) path(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
@@ -0,0 +1,43 @@
+package io
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestETagCache_PutGet(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	cache := NewETagCache(newMemoryFileSystem(), "cache", time.Hour)
+	cache.now = func() time.Time { return now }
+
+	_, ok := cache.Get("https://api.github.com/repos/owner/repo/git/refs/tags")
+	assert.False(t, ok)
+
+	err := cache.Put("https://api.github.com/repos/owner/repo/git/refs/tags", `"abc"`, []byte(`[{"ref":"refs/tags/v1.0.0"}]`), "https://next")
+	require.NoError(t, err)
+
+	entry, ok := cache.Get("https://api.github.com/repos/owner/repo/git/refs/tags")
+	require.True(t, ok)
+	assert.Equal(t, `"abc"`, entry.ETag)
+	assert.JSONEq(t, `[{"ref":"refs/tags/v1.0.0"}]`, string(entry.Body))
+	assert.Equal(t, "https://next", entry.Next)
+
+	_, ok = cache.Get("https://api.github.com/repos/owner/other/git/refs/tags")
+	assert.False(t, ok)
+
+	now = now.Add(2 * time.Hour)
+	_, ok = cache.Get("https://api.github.com/repos/owner/repo/git/refs/tags")
+	assert.False(t, ok, "expired entries should be ignored")
+}
+
+func TestETagCache_Nil(t *testing.T) {
+	var cache *ETagCache
+
+	assert.NoError(t, cache.Put("https://example.com", `"abc"`, []byte(`[]`), ""))
+
+	_, ok := cache.Get("https://example.com")
+	assert.False(t, ok)
+}
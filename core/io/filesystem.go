@@ -3,9 +3,12 @@ package io
 import "os"
 
 // FileSystem abstracts file system operations for better testability
+// Implementations must be safe for concurrent use on distinct files, as configuration files are written concurrently.
 type FileSystem interface {
 	ReadFile(filename string) ([]byte, error)
 	WriteFile(filename string, data []byte, perm int) error
+	AppendFile(filename string, data []byte, perm int) error
+	MkdirAll(path string, perm int) error
 }
 
 // OSFileSystem implements FileSystem using the standard os package
@@ -25,3 +28,22 @@ func (fs *OSFileSystem) ReadFile(filename string) ([]byte, error) {
 func (fs *OSFileSystem) WriteFile(filename string, data []byte, perm int) error {
 	return os.WriteFile(filename, data, os.FileMode(perm))
 }
+
+// AppendFile appends data to a file in the file system, creating the file if it does not exist
+func (fs *OSFileSystem) AppendFile(filename string, data []byte, perm int) error {
+	file, err := os.OpenFile(filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, os.FileMode(perm))
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(data); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// MkdirAll creates a directory and all missing parents in the file system
+func (fs *OSFileSystem) MkdirAll(path string, perm int) error {
+	return os.MkdirAll(path, os.FileMode(perm))
+}
@@ -0,0 +1,46 @@
+package io
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ramonvermeulen/pre-commit-bump/core/types"
+)
+
+// WriteGitHubAnnotations prints a GitHub Actions workflow annotation to stdout for every hook that can be bumped.
+// The annotations point at the rev key of the repository, so they show up next to the outdated hook.
+func (s *ResultWriter) WriteGitHubAnnotations(results []types.UpdateResult) error {
+	_, err := fmt.Fprint(s.stdout, buildGitHubAnnotations(results))
+	return err
+}
+
+// buildGitHubAnnotations renders a "::warning" workflow command for every result that requires an update.
+func buildGitHubAnnotations(results []types.UpdateResult) string {
+	var buf strings.Builder
+
+	for _, result := range results {
+		if !result.UpdateRequired || result.Error != nil {
+			continue
+		}
+
+		properties := "file=" + escapeGitHubProperty(result.ConfigPath)
+		if result.Dependency == nil && result.Repo.RevLine > 0 {
+			properties += fmt.Sprintf(",line=%d", result.Repo.RevLine)
+		}
+
+		message := fmt.Sprintf("%s can be bumped %s -> %s", result.Name(), result.CurrentVersion(), result.BumpVersion().String())
+		buf.WriteString(fmt.Sprintf("::warning %s::%s\n", properties, escapeGitHubData(message)))
+	}
+
+	return buf.String()
+}
+
+// escapeGitHubData escapes the message of a workflow command, as documented for GitHub Actions.
+func escapeGitHubData(data string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(data)
+}
+
+// escapeGitHubProperty escapes a property value of a workflow command, which additionally can not contain ":" and ",".
+func escapeGitHubProperty(value string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(value)
+}
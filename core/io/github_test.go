@@ -0,0 +1,64 @@
+package io
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ramonvermeulen/pre-commit-bump/core/types"
+)
+
+func TestBuildGitHubAnnotations(t *testing.T) {
+	tests := []struct {
+		name     string
+		results  []types.UpdateResult
+		expected string
+	}{
+		{
+			name: "update with line number",
+			results: []types.UpdateResult{{
+				Repo:           types.Repo{Repo: "https://github.com/psf/black", Rev: "24.1.0", RevLine: 4},
+				LatestVersion:  &types.SemanticVersion{Major: 24, Minor: 2},
+				UpdateRequired: true,
+			}},
+			expected: "::warning file=.pre-commit-config.yaml,line=4::https://github.com/psf/black can be bumped 24.1.0 -> 24.2.0\n",
+		},
+		{
+			name: "update without line number",
+			results: []types.UpdateResult{{
+				Repo:           types.Repo{Repo: "https://github.com/psf/black", Rev: "24.1.0"},
+				LatestVersion:  &types.SemanticVersion{Major: 24, Minor: 2},
+				UpdateRequired: true,
+			}},
+			expected: "::warning file=.pre-commit-config.yaml::https://github.com/psf/black can be bumped 24.1.0 -> 24.2.0\n",
+		},
+		{
+			name: "up to date, ignored and failed results are skipped",
+			results: []types.UpdateResult{
+				{Repo: types.Repo{Repo: "https://github.com/psf/black", Rev: "24.2.0", RevLine: 4}, LatestVersion: &types.SemanticVersion{Major: 24, Minor: 2}},
+				{Repo: types.Repo{Repo: "https://github.com/pycqa/isort", Rev: "5.0.0", RevLine: 7}, Ignored: true},
+				{Repo: types.Repo{Repo: "https://github.com/pycqa/flake8", Rev: "7.0.0", RevLine: 10}, Error: fmt.Errorf("GitHub API returned status 500")},
+			},
+			expected: "",
+		},
+		{
+			name: "message is escaped",
+			results: []types.UpdateResult{{
+				Repo:           types.Repo{Repo: "https://example.org/100%/repo", Rev: "1.0.0", RevLine: 2},
+				LatestVersion:  &types.SemanticVersion{Major: 2},
+				UpdateRequired: true,
+			}},
+			expected: "::warning file=.pre-commit-config.yaml,line=2::https://example.org/100%25/repo can be bumped 1.0.0 -> 2.0.0\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := range tt.results {
+				tt.results[i].ConfigPath = ".pre-commit-config.yaml"
+			}
+			assert.Equal(t, tt.expected, buildGitHubAnnotations(tt.results))
+		})
+	}
+}
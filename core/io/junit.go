@@ -0,0 +1,133 @@
+package io
+
+import (
+	"encoding/xml"
+	"fmt"
+
+	"github.com/ramonvermeulen/pre-commit-bump/core/types"
+)
+
+// junitTestSuites is the root element of a JUnit XML report.
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+// junitTestSuite groups the test cases of a single pre-commit configuration file.
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+// junitTestCase represents a single repository in the JUnit XML report.
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Error     *junitFailure `xml:"error,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+// junitFailure holds the details of a failed or errored test case.
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Content string `xml:",chardata"`
+}
+
+// junitSkipped marks a test case that was not run.
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// WriteJUnitReport writes the results as a JUnit XML report to reportPath.
+// Every repository is a test case, which fails when an update is available and errors when the check itself failed.
+// Ignored repositories and repositories that cannot be checked are reported as skipped. Every pre-commit configuration file is a separate test suite.
+// Repeated occurrences of a repository are reported once.
+func (s *ResultWriter) WriteJUnitReport(reportPath string, results []types.UpdateResult) error {
+	data, err := buildJUnitReport(results)
+	if err != nil {
+		return err
+	}
+
+	return s.fs.WriteFile(reportPath, data, 0644)
+}
+
+// buildJUnitReport renders the results as a JUnit XML document, with a test suite per configuration file.
+func buildJUnitReport(results []types.UpdateResult) ([]byte, error) {
+	report := junitTestSuites{
+		Name:   "pre-commit-bump",
+		Suites: []junitTestSuite{},
+	}
+	suiteIndex := map[string]int{}
+
+	for _, result := range results {
+		if result.Duplicate {
+			continue
+		}
+
+		index, ok := suiteIndex[result.ConfigPath]
+		if !ok {
+			index = len(report.Suites)
+			suiteIndex[result.ConfigPath] = index
+			report.Suites = append(report.Suites, junitTestSuite{Name: result.ConfigPath})
+		}
+		suite := &report.Suites[index]
+
+		source := result.Repo.GetVendor()
+		if result.Dependency != nil {
+			source = result.Dependency.Source
+		}
+
+		testCase := junitTestCase{
+			Name:      result.Name(),
+			ClassName: "pre-commit-bump." + source,
+		}
+
+		switch {
+		case result.SkipReason != "":
+			testCase.Skipped = &junitSkipped{Message: result.SkipReason}
+			suite.Skipped++
+		case result.Ignored:
+			testCase.Skipped = &junitSkipped{Message: "ignored"}
+			suite.Skipped++
+		case result.Error != nil:
+			testCase.Error = &junitFailure{
+				Message: "failed to check for updates",
+				Type:    "error",
+				Content: result.Error.Error(),
+			}
+			suite.Errors++
+			report.Errors++
+		case result.UpdateRequired:
+			bumpType := result.BumpVersion().GetBumpType(result.CurrentSemVer())
+			testCase.Failure = &junitFailure{
+				Message: fmt.Sprintf("update available: %s -> %s", result.CurrentVersion(), result.BumpVersion().String()),
+				Type:    bumpType,
+				Content: fmt.Sprintf("%s can be bumped from %s to %s (%s)",
+					result.Name(), result.CurrentVersion(), result.BumpVersion().String(), bumpType),
+			}
+			suite.Failures++
+			report.Failures++
+		}
+
+		suite.TestCases = append(suite.TestCases, testCase)
+		suite.Tests++
+		report.Tests++
+	}
+
+	data, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal junit report: %w", err)
+	}
+
+	return append([]byte(xml.Header), append(data, '\n')...), nil
+}
@@ -0,0 +1,129 @@
+package io
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/ramonvermeulen/pre-commit-bump/config"
+	"github.com/ramonvermeulen/pre-commit-bump/core/types"
+)
+
+// memoryFileSystem is an in-memory FileSystem used in tests
+type memoryFileSystem struct {
+	files map[string][]byte
+}
+
+func newMemoryFileSystem() *memoryFileSystem {
+	return &memoryFileSystem{files: map[string][]byte{}}
+}
+
+func (m *memoryFileSystem) ReadFile(filename string) ([]byte, error) {
+	data, ok := m.files[filename]
+	if !ok {
+		return nil, fmt.Errorf("file not found: %s", filename)
+	}
+	return data, nil
+}
+
+func (m *memoryFileSystem) WriteFile(filename string, data []byte, perm int) error {
+	m.files[filename] = data
+	return nil
+}
+
+func (m *memoryFileSystem) AppendFile(filename string, data []byte, perm int) error {
+	m.files[filename] = append(m.files[filename], data...)
+	return nil
+}
+
+func (m *memoryFileSystem) MkdirAll(path string, perm int) error {
+	return nil
+}
+
+func TestResultWriter_WriteJUnitReport(t *testing.T) {
+	results := []types.UpdateResult{
+		{
+			Repo: types.Repo{
+				Repo:   "https://github.com/owner/up-to-date",
+				Rev:    "v1.0.0",
+				SemVer: &types.SemanticVersion{Major: 1},
+			},
+			LatestVersion: &types.SemanticVersion{Major: 1},
+		},
+		{
+			Repo: types.Repo{
+				Repo:   "https://github.com/owner/outdated",
+				Rev:    "v1.0.0",
+				SemVer: &types.SemanticVersion{Major: 1},
+			},
+			LatestVersion:  &types.SemanticVersion{Major: 1, Minor: 2},
+			UpdateRequired: true,
+		},
+		{
+			Repo: types.Repo{
+				Repo:   "https://gitlab.com/owner/broken",
+				Rev:    "v1.0.0",
+				SemVer: &types.SemanticVersion{Major: 1},
+			},
+			Error: fmt.Errorf("GitLab API returned status 500"),
+		},
+		{
+			Repo: types.Repo{
+				Repo:   "https://github.com/owner/pinned",
+				Rev:    "v1.0.0",
+				SemVer: &types.SemanticVersion{Major: 1},
+			},
+			Ignored: true,
+		},
+		{
+			Repo:       types.Repo{Repo: "https://github.com/owner/branch", Rev: "main"},
+			SkipReason: config.SkipReasonNoVersion,
+		},
+	}
+
+	for i := range results {
+		results[i].ConfigPath = ".pre-commit-config.yaml"
+	}
+
+	fs := newMemoryFileSystem()
+	writer := NewResultWriter(fs, new(bytes.Buffer), zap.NewNop())
+
+	err := writer.WriteJUnitReport("report.xml", results)
+	require.NoError(t, err)
+
+	var report junitTestSuites
+	require.NoError(t, xml.Unmarshal(fs.files["report.xml"], &report))
+
+	assert.Equal(t, 5, report.Tests)
+	assert.Equal(t, 1, report.Failures)
+	assert.Equal(t, 1, report.Errors)
+	require.Len(t, report.Suites, 1)
+
+	suite := report.Suites[0]
+	assert.Equal(t, ".pre-commit-config.yaml", suite.Name)
+	assert.Equal(t, 2, suite.Skipped)
+	require.Len(t, suite.TestCases, 5)
+
+	assert.Equal(t, "https://github.com/owner/up-to-date", suite.TestCases[0].Name)
+	assert.Nil(t, suite.TestCases[0].Failure)
+	assert.Nil(t, suite.TestCases[0].Error)
+
+	assert.Equal(t, "pre-commit-bump.github", suite.TestCases[1].ClassName)
+	require.NotNil(t, suite.TestCases[1].Failure)
+	assert.Equal(t, "update available: v1.0.0 -> 1.2.0", suite.TestCases[1].Failure.Message)
+	assert.Equal(t, "minor", suite.TestCases[1].Failure.Type)
+
+	require.NotNil(t, suite.TestCases[2].Error)
+	assert.Contains(t, suite.TestCases[2].Error.Content, "status 500")
+
+	require.NotNil(t, suite.TestCases[3].Skipped)
+	assert.Nil(t, suite.TestCases[3].Failure)
+
+	require.NotNil(t, suite.TestCases[4].Skipped)
+	assert.Equal(t, config.SkipReasonNoVersion, suite.TestCases[4].Skipped.Message)
+}
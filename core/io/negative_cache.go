@@ -0,0 +1,83 @@
+package io
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"time"
+)
+
+// negativeEntry records when a repository was found to have no usable version tags.
+type negativeEntry struct {
+	StoredAt time.Time `json:"stored_at"`
+}
+
+// NegativeCache stores on disk which repositories have no usable version tags, so they are not fetched again on every run.
+// Entries expire after a short time, as a repository may publish its first version at any moment.
+// A nil NegativeCache is valid and caches nothing.
+type NegativeCache struct {
+	fs     FileSystem
+	dir    string
+	expiry time.Duration
+	now    func() time.Time
+}
+
+// NewNegativeCache creates a new NegativeCache storing its entries in dir, entries older than expiry are ignored.
+func NewNegativeCache(fs FileSystem, dir string, expiry time.Duration) *NegativeCache {
+	return &NegativeCache{
+		fs:     fs,
+		dir:    dir,
+		expiry: expiry,
+		now:    time.Now,
+	}
+}
+
+// Has reports whether the key was recorded as having no usable version tags within the expiry.
+func (c *NegativeCache) Has(key string) bool {
+	if c == nil {
+		return false
+	}
+
+	data, err := c.fs.ReadFile(c.path(key))
+	if err != nil {
+		return false
+	}
+
+	var entry negativeEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.StoredAt.IsZero() {
+		return false
+	}
+
+	return c.now().Sub(entry.StoredAt) <= c.expiry
+}
+
+// Put records that the key has no usable version tags.
+func (c *NegativeCache) Put(key string) error {
+	if c == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(negativeEntry{StoredAt: c.now()})
+	if err != nil {
+		return fmt.Errorf("failed to marshal negative cache entry: %w", err)
+	}
+
+	if err := c.fs.MkdirAll(c.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory %s: %w", c.dir, err)
+	}
+
+	if err := c.fs.WriteFile(c.path(key), data, 0644); err != nil {
+		return fmt.Errorf("failed to write negative cache entry: %w", err)
+	}
+
+	return nil
+}
+
+// path returns the file the entry of the given key is stored in.
+// The key is prefixed, so the entries never collide with those of the ETagCache in the same directory.
+func (c *NegativeCache) path(key string) string {
+	sum := sha256.Sum256([]byte("negative:" + key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
@@ -0,0 +1,39 @@
+package io
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegativeCache_PutHas(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	cache := NewNegativeCache(newMemoryFileSystem(), "cache", time.Hour)
+	cache.now = func() time.Time { return now }
+
+	assert.False(t, cache.Has("github.com/owner/repo@semver"))
+
+	require.NoError(t, cache.Put("github.com/owner/repo@semver"))
+
+	assert.True(t, cache.Has("github.com/owner/repo@semver"))
+	assert.False(t, cache.Has("github.com/owner/other@semver"))
+
+	now = now.Add(2 * time.Hour)
+	assert.False(t, cache.Has("github.com/owner/repo@semver"), "expired entries should be ignored")
+}
+
+func TestNegativeCache_DoesNotCollideWithETagCache(t *testing.T) {
+	fs := newMemoryFileSystem()
+	require.NoError(t, NewETagCache(fs, "cache", time.Hour).Put("github.com/owner/repo@semver", `"abc"`, []byte(`[]`), ""))
+
+	assert.False(t, NewNegativeCache(fs, "cache", time.Hour).Has("github.com/owner/repo@semver"))
+}
+
+func TestNegativeCache_Nil(t *testing.T) {
+	var cache *NegativeCache
+
+	assert.NoError(t, cache.Put("github.com/owner/repo@semver"))
+	assert.False(t, cache.Has("github.com/owner/repo@semver"))
+}
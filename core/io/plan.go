@@ -0,0 +1,221 @@
+package io
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/ramonvermeulen/pre-commit-bump/core/types"
+)
+
+// Edit is a single change to a pre-commit configuration file, the bytes from Start to End are replaced with New.
+// Start and End are byte offsets in the original file, Line is the line of Start. OldRev and NewRev are the revisions
+// of a repository, or the versions of a dependency when Dependency is set. BumpType is major, minor or patch, and empty
+// when the versions can not be compared.
+type Edit struct {
+	Repo       string `json:"repo"`
+	Dependency string `json:"dependency,omitempty"`
+	File       string `json:"file"`
+	OldRev     string `json:"old_rev"`
+	NewRev     string `json:"new_rev"`
+	BumpType   string `json:"bump_type,omitempty"`
+	Line       int    `json:"line"`
+	Start      int    `json:"start"`
+	End        int    `json:"end"`
+	Old        string `json:"old"`
+	New        string `json:"new"`
+}
+
+// PlanPreCommitChanges computes the edits that bump the results in the content of the configuration file, without
+// applying them. Every occurrence of a repository or dependency is edited. The edits are sorted and do not overlap.
+func PlanPreCommitChanges(configPath string, content string, results []types.UpdateResult) []Edit {
+	var edits []Edit
+
+	for _, result := range results {
+		if !result.UpdateRequired || result.Error != nil || result.Declined || result.Deferred {
+			continue
+		}
+
+		var resultEdits []Edit
+		switch {
+		case result.Dependency != nil:
+			resultEdits = dependencyEdits(content, result.Dependency, result.BumpVersion().String())
+		case result.Repo.Frozen == "" && result.FrozenRev != "":
+			resultEdits = freezeRevEdits(content, result)
+		case result.Repo.Frozen != "":
+			resultEdits = frozenRevEdits(content, result)
+		default:
+			resultEdits = revEdits(content, result)
+		}
+
+		bumpType := result.BumpVersion().GetBumpType(result.CurrentSemVer())
+		for _, edit := range resultEdits {
+			edit.Repo = result.Repo.Repo
+			edit.BumpType = bumpType
+			if result.Dependency != nil {
+				edit.Dependency = result.Dependency.Name
+			}
+			edit.File = configPath
+			edit.Line = strings.Count(content[:edit.Start], "\n") + 1
+			edits = append(edits, edit)
+		}
+	}
+
+	slices.SortStableFunc(edits, func(a, b Edit) int {
+		return a.Start - b.Start
+	})
+
+	planned := edits[:0]
+	for _, edit := range edits {
+		if len(planned) > 0 && edit.Start < planned[len(planned)-1].End {
+			continue
+		}
+		planned = append(planned, edit)
+	}
+	return planned
+}
+
+// applyEdits applies the sorted, non-overlapping edits to the content.
+func applyEdits(content string, edits []Edit) string {
+	var buf strings.Builder
+	offset := 0
+	for _, edit := range edits {
+		buf.WriteString(content[offset:edit.Start])
+		buf.WriteString(edit.New)
+		offset = edit.End
+	}
+	buf.WriteString(content[offset:])
+	return buf.String()
+}
+
+// WriteJSONPlan prints the edits that the update command applies to the pre-commit configuration files as a JSON list
+// to stdout. The files are only read, so combined with --dry-run it shows what an update would change.
+func (s *ResultWriter) WriteJSONPlan(results []types.UpdateResult) error {
+	edits, err := s.planEdits(results)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(s.stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(edits)
+}
+
+// planEdits plans the edits of every configuration file the results belong to.
+func (s *ResultWriter) planEdits(results []types.UpdateResult) ([]Edit, error) {
+	edits := []Edit{}
+	for _, configPath := range configPaths(results) {
+		data, err := s.fs.ReadFile(configPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config file: %w", err)
+		}
+
+		var configResults []types.UpdateResult
+		for _, result := range results {
+			if result.ConfigPath == configPath {
+				configResults = append(configResults, result)
+			}
+		}
+		edits = append(edits, PlanPreCommitChanges(configPath, string(data), configResults)...)
+	}
+	return edits, nil
+}
+
+// configPaths returns the distinct configuration files of the results, in order of appearance.
+func configPaths(results []types.UpdateResult) []string {
+	var paths []string
+	for _, result := range results {
+		if !slices.Contains(paths, result.ConfigPath) {
+			paths = append(paths, result.ConfigPath)
+		}
+	}
+	return paths
+}
+
+// revEdits replaces the revision of every occurrence of the repository.
+// The new revision keeps the prefix and suffix of the current revision, e.g. the "v" of "v1.2.3".
+func revEdits(content string, result types.UpdateResult) []Edit {
+	pattern := fmt.Sprintf(`(?m)(repo:\s+%s\s+rev:\s+['"]?)%s(['"]?\s*(?:#.*)?$)`,
+		regexp.QuoteMeta(result.Repo.Repo), regexp.QuoteMeta(result.Repo.Rev))
+	newRev := result.Repo.FormatRevision(result.BumpVersion())
+
+	var edits []Edit
+	for _, match := range regexp.MustCompile(pattern).FindAllStringSubmatchIndex(content, -1) {
+		edits = append(edits, Edit{
+			OldRev: result.Repo.Rev,
+			NewRev: newRev,
+			Start:  match[3],
+			End:    match[4],
+			Old:    content[match[3]:match[4]],
+			New:    newRev,
+		})
+	}
+	return edits
+}
+
+// frozenRevEdits replaces the commit SHA of a frozen repository and the tag of its "# frozen: <tag>" comment.
+func frozenRevEdits(content string, result types.UpdateResult) []Edit {
+	pattern := fmt.Sprintf(`(?m)(repo:\s+%s\s+rev:\s+['"]?)%s(['"]?\s*#\s*frozen:\s*)%s((?:\s.*)?$)`,
+		regexp.QuoteMeta(result.Repo.Repo), regexp.QuoteMeta(result.Repo.Rev), regexp.QuoteMeta(result.Repo.Frozen))
+	newTag := result.Repo.FormatRevision(result.BumpVersion())
+
+	var edits []Edit
+	for _, match := range regexp.MustCompile(pattern).FindAllStringSubmatchIndex(content, -1) {
+		edits = append(edits, Edit{
+			OldRev: result.Repo.Rev,
+			NewRev: result.FrozenRev,
+			Start:  match[3],
+			End:    match[6],
+			Old:    content[match[3]:match[6]],
+			New:    result.FrozenRev + content[match[4]:match[5]] + newTag,
+		})
+	}
+	return edits
+}
+
+// freezeRevEdits replaces the tag of a repository with the commit SHA it points to and adds a "# frozen: <tag>" comment.
+// An existing trailing comment is kept after the tag, so annotations like "# pcb:allow=patch" still apply.
+func freezeRevEdits(content string, result types.UpdateResult) []Edit {
+	pattern := fmt.Sprintf(`(?m)(repo:\s+%s\s+rev:\s+['"]?)%s(['"]?)[ \t]*(?:#[ \t]*(.*))?$`,
+		regexp.QuoteMeta(result.Repo.Repo), regexp.QuoteMeta(result.Repo.Rev))
+	frozen := "  # frozen: " + result.Repo.FormatRevision(result.BumpVersion())
+
+	var edits []Edit
+	for _, match := range regexp.MustCompile(pattern).FindAllStringSubmatchIndex(content, -1) {
+		comment := frozen
+		if match[6] >= 0 && match[6] < match[7] {
+			comment += " " + content[match[6]:match[7]]
+		}
+		edits = append(edits, Edit{
+			OldRev: result.Repo.Rev,
+			NewRev: result.FrozenRev,
+			Start:  match[3],
+			End:    match[1],
+			Old:    content[match[3]:match[1]],
+			New:    result.FrozenRev + content[match[4]:match[5]] + comment,
+		})
+	}
+	return edits
+}
+
+// dependencyEdits replaces the pinned specification of the dependency with the specification pinned to the new version.
+// The specification is only replaced as a whole list item, so "flake8==6.0.0" does not touch "pep8-flake8==6.0.0".
+func dependencyEdits(content string, dependency *types.Dependency, newVersion string) []Edit {
+	pattern := fmt.Sprintf(`(?m)(^|[\s\[,'"])%s($|[\s\],'"#])`, regexp.QuoteMeta(dependency.Spec))
+	newSpec := dependency.WithVersion(newVersion)
+
+	var edits []Edit
+	for _, match := range regexp.MustCompile(pattern).FindAllStringSubmatchIndex(content, -1) {
+		edits = append(edits, Edit{
+			OldRev: dependency.Version,
+			NewRev: newVersion,
+			Start:  match[3],
+			End:    match[4],
+			Old:    content[match[3]:match[4]],
+			New:    newSpec,
+		})
+	}
+	return edits
+}
@@ -0,0 +1,45 @@
+package io
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ErrPreCommitNotInstalled is returned when the pre-commit executable can not be found on the PATH.
+var ErrPreCommitNotInstalled = errors.New("pre-commit is not installed")
+
+// ConfigVerifier verifies that a pre-commit configuration file is accepted by pre-commit itself.
+type ConfigVerifier interface {
+	VerifyConfig(configPath string) error
+}
+
+// PreCommitCLI implements ConfigVerifier by shelling out to the pre-commit executable.
+type PreCommitCLI struct {
+	executable string
+}
+
+// NewPreCommitCLI creates a new PreCommitCLI that uses the pre-commit executable found on the PATH
+func NewPreCommitCLI() *PreCommitCLI {
+	return &PreCommitCLI{
+		executable: "pre-commit",
+	}
+}
+
+// VerifyConfig runs "pre-commit validate-config" against the given configuration file.
+// It returns ErrPreCommitNotInstalled when pre-commit is not available, or an error containing
+// the pre-commit output when the configuration is rejected.
+func (p *PreCommitCLI) VerifyConfig(configPath string) error {
+	executable, err := exec.LookPath(p.executable)
+	if err != nil {
+		return ErrPreCommitNotInstalled
+	}
+
+	output, err := exec.Command(executable, "validate-config", configPath).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("pre-commit rejected %s: %w: %s", configPath, err, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
@@ -1,10 +1,15 @@
 package io
 
 import (
+	_ "embed"
 	"fmt"
-	"regexp"
+	stdio "io"
+	"os"
+	"slices"
 	"strings"
+	"text/template"
 
+	"github.com/ramonvermeulen/pre-commit-bump/config"
 	"github.com/ramonvermeulen/pre-commit-bump/core/types"
 
 	"go.uber.org/zap"
@@ -14,90 +19,364 @@ import (
 // It provides methods to write a summary of the updates and to update the pre-commit configuration file itself.
 // It uses a FileSystem interface to abstract file operations, allowing for easier testing and mocking.
 type ResultWriter struct {
-	fs     FileSystem
-	logger *zap.Logger
+	fs FileSystem
+	// stdout receives the reports and the summary preview that are printed instead of written to a file
+	stdout          stdio.Writer
+	logger          *zap.Logger
+	summaryTemplate *template.Template
 }
 
-// NewResultWriter creates a new ResultWriter instance
-func NewResultWriter(fs FileSystem, logger *zap.Logger) *ResultWriter {
+// defaultSummaryTemplate is the markdown template of the summary, used unless a custom template is loaded.
+//
+//go:embed templates/summary.md.tmpl
+var defaultSummaryTemplate string
+
+// NewResultWriter creates a new ResultWriter instance that writes files to fs and prints to stdout
+func NewResultWriter(fs FileSystem, stdout stdio.Writer, logger *zap.Logger) *ResultWriter {
 	return &ResultWriter{
-		fs:     fs,
-		logger: logger,
+		fs:              fs,
+		stdout:          stdout,
+		logger:          logger,
+		summaryTemplate: template.Must(ParseSummaryTemplate(defaultSummaryTemplate)),
+	}
+}
+
+// ParseSummaryTemplate parses the text of a summary template, which is executed with a SummaryData.
+func ParseSummaryTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("summary").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse summary template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// LoadSummaryTemplate reads the Go text/template at templatePath and uses it instead of the default summary template.
+func (s *ResultWriter) LoadSummaryTemplate(templatePath string) error {
+	data, err := s.fs.ReadFile(templatePath)
+	if err != nil {
+		return fmt.Errorf("failed to read summary template: %w", err)
+	}
+
+	tmpl, err := ParseSummaryTemplate(string(data))
+	if err != nil {
+		return err
 	}
+
+	s.summaryTemplate = tmpl
+	return nil
+}
+
+// SummaryData is the data the summary template is executed with.
+type SummaryData struct {
+	// AllowLevel is the global allow level of the run, e.g. "minor"
+	AllowLevel string
+	// Results are the results of the run, repeated occurrences of a repository are left out
+	Results []types.UpdateResult
+	// Files are the sections of the summary per pre-commit configuration file
+	Files []SummaryFile
+	// GroupByFile is true when the results span multiple pre-commit configuration files
+	GroupByFile bool
+	// Counts are the number of results per outcome
+	Counts SummaryCounts
+}
+
+// SummaryFile holds the non-empty sections of the summary of a single pre-commit configuration file.
+type SummaryFile struct {
+	Path     string
+	Sections []SummarySection
 }
 
-// WriteSummary generates a summary of the updates and writes it to a markdown file
+// SummarySection is a section of the summary, like "Minor updates", with a markdown list item per result.
+type SummarySection struct {
+	Title string
+	Lines []string
+}
+
+// SummaryCounts are the number of results per outcome, as listed at the bottom of the summary.
+type SummaryCounts struct {
+	Updated     int
+	UpToDate    int
+	Blocked     int
+	Declined    int
+	Deferred    int
+	Unpublished int
+	Failed      int
+	Ignored     int
+	Skipped     int
+}
+
+// WriteSummary generates a summary of the updates and writes it to a markdown file.
+// The summary is rendered with the summary template, the default template lists the results in sections by bump type
+// and outcome, see summarySections, sorted by name within a section.
+// When the results span multiple pre-commit configuration files, they are grouped by file first.
+// Repositories with a pre-release newer than their latest stable release list both versions.
+// When running in GitHub Actions the summary is also appended to the step summary of the job.
+// Failing to append to the step summary is not fatal, the markdown file is written regardless.
 func (s *ResultWriter) WriteSummary(results []types.UpdateResult, allowLevel string) error {
 	summaryPath := "summary.md"
 
+	summary, err := s.renderSummary(results, allowLevel)
+	if err != nil {
+		return err
+	}
+
+	if err := s.fs.WriteFile(summaryPath, []byte(summary), 0644); err != nil {
+		return err
+	}
+
+	if stepSummaryPath := os.Getenv(config.EnvGitHubStepSummary); stepSummaryPath != "" {
+		if err := s.fs.AppendFile(stepSummaryPath, []byte(summary), 0644); err != nil {
+			s.logger.Sugar().Warnf("Failed to append summary to %s, it is only written to %s: %v", stepSummaryPath, summaryPath, err)
+		}
+	}
+
+	return nil
+}
+
+// PreviewSummary prints the summary that WriteSummary would write to stdout, e.g. to review it on a dry run.
+// Neither the markdown file nor the step summary of the job are written.
+func (s *ResultWriter) PreviewSummary(results []types.UpdateResult, allowLevel string) error {
+	summary, err := s.renderSummary(results, allowLevel)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprint(s.stdout, summary)
+	return err
+}
+
+// renderSummary renders the summary template with the results.
+func (s *ResultWriter) renderSummary(results []types.UpdateResult, allowLevel string) (string, error) {
 	var buf strings.Builder
-	buf.WriteString("# Pre-commit Hook Update Summary\n\n")
-	buf.WriteString(fmt.Sprintf("**Update Policy**: Only %s version updates are allowed\n\n", allowLevel))
+	if err := s.summaryTemplate.Execute(&buf, buildSummaryData(results, allowLevel)); err != nil {
+		return "", fmt.Errorf("failed to render summary template: %w", err)
+	}
+	return buf.String(), nil
+}
 
-	updatesApplied := 0
-	upToDate := 0
-	constrainedUpdates := 0
+// buildSummaryData classifies the results into the sections and counts of the summary.
+func buildSummaryData(results []types.UpdateResult, allowLevel string) SummaryData {
+	data := SummaryData{
+		AllowLevel:  allowLevel,
+		GroupByFile: hasMultipleConfigPaths(results),
+	}
+	counts := map[summaryKind]int{}
 
-	for _, result := range results {
-		if result.UpdateRequired {
-			buf.WriteString(fmt.Sprintf("- 🔄 **%s**: %s → %s\n",
-				result.Repo.Repo, result.Repo.Rev, result.LatestVersion.String()))
-			updatesApplied++
-		} else {
-			if result.LatestVersion != nil && result.Repo.SemVer != nil {
-				if result.LatestVersion.IsNewerVersionThan(result.Repo.SemVer) {
-					buf.WriteString(fmt.Sprintf("- ⚠️ **%s**: %s (newer version %s available but not allowed by %s policy)\n",
-						result.Repo.Repo, result.Repo.Rev, result.LatestVersion.String(), allowLevel))
-					constrainedUpdates++
-				} else {
-					buf.WriteString(fmt.Sprintf("- ✅ **%s**: %s (up to date)\n",
-						result.Repo.Repo, result.Repo.Rev))
-					upToDate++
+	for _, configPath := range configPaths(results) {
+		var entries []summaryEntry
+		for _, result := range results {
+			if result.Duplicate || result.ConfigPath != configPath {
+				continue
+			}
+			entry := summarizeResult(result, allowLevel)
+			counts[entry.kind]++
+			entries = append(entries, entry)
+			data.Results = append(data.Results, result)
+		}
+		slices.SortStableFunc(entries, func(a, b summaryEntry) int {
+			return strings.Compare(a.name, b.name)
+		})
+
+		file := SummaryFile{Path: configPath}
+		for _, section := range summarySections {
+			var lines []string
+			for _, entry := range entries {
+				if slices.Contains(section.kinds, entry.kind) {
+					lines = append(lines, entry.line)
 				}
-			} else {
-				buf.WriteString(fmt.Sprintf("- ✅ **%s**: %s (up to date)\n",
-					result.Repo.Repo, result.Repo.Rev))
-				upToDate++
+			}
+			if len(lines) > 0 {
+				file.Sections = append(file.Sections, SummarySection{Title: section.title, Lines: lines})
 			}
 		}
+		data.Files = append(data.Files, file)
 	}
 
-	buf.WriteString("---\n\n")
-	buf.WriteString("## Summary\n\n")
-	buf.WriteString(fmt.Sprintf("- 🔄 **%d** hooks updated\n", updatesApplied))
-	buf.WriteString(fmt.Sprintf("- ✅ **%d** hooks up to date\n", upToDate))
-	if constrainedUpdates > 0 {
-		buf.WriteString(fmt.Sprintf("- ⚠️ **%d** hooks have newer versions available (blocked by %s policy)\n", constrainedUpdates, allowLevel))
+	data.Counts = SummaryCounts{
+		Updated:     counts[summaryMajor] + counts[summaryMinor] + counts[summaryPatch],
+		UpToDate:    counts[summaryUpToDate],
+		Blocked:     counts[summaryBlocked],
+		Declined:    counts[summaryDeclined],
+		Deferred:    counts[summaryDeferred],
+		Unpublished: counts[summaryUnpublished],
+		Failed:      counts[summaryFailed],
+		Ignored:     counts[summaryIgnored],
+		Skipped:     counts[summarySkipped],
 	}
+	return data
+}
+
+// summaryKind classifies a result in the summary.
+type summaryKind int
+
+const (
+	summaryMajor summaryKind = iota
+	summaryMinor
+	summaryPatch
+	summaryBlocked
+	summaryDeclined
+	summaryDeferred
+	summaryUnpublished
+	summaryFailed
+	summaryIgnored
+	summarySkipped
+	summaryUpToDate
+)
 
-	return s.fs.WriteFile(summaryPath, []byte(buf.String()), 0644)
+// summarySections are the sections of the summary in order, with the kinds of results listed in them.
+var summarySections = []struct {
+	title string
+	kinds []summaryKind
+}{
+	{title: "🔄 Major updates", kinds: []summaryKind{summaryMajor}},
+	{title: "🔄 Minor updates", kinds: []summaryKind{summaryMinor}},
+	{title: "🔄 Patch updates", kinds: []summaryKind{summaryPatch}},
+	{title: "⚠️ Blocked", kinds: []summaryKind{summaryBlocked}},
+	{title: "⏭️ Declined", kinds: []summaryKind{summaryDeclined}},
+	{title: "⏳ Deferred", kinds: []summaryKind{summaryDeferred}},
+	{title: "❗ Unpublished", kinds: []summaryKind{summaryUnpublished}},
+	{title: "❌ Errors", kinds: []summaryKind{summaryFailed}},
+	{title: "⏭️ Skipped", kinds: []summaryKind{summaryIgnored, summarySkipped}},
+	{title: "✅ Up to date", kinds: []summaryKind{summaryUpToDate}},
 }
 
-// WritePreCommitChanges updates the pre-commit configuration file with the latest versions
+// summaryEntry is the markdown of a single result in the summary.
+type summaryEntry struct {
+	kind summaryKind
+	name string
+	line string
+}
+
+// summarizeResult classifies the result and renders its markdown list item.
+// Updates are classified by the bump type of the version they are bumped to and link to the compare page of the vendor. An update from a version that cannot be
+// compared is listed as a major update, so it gets the most attention in a review.
+func summarizeResult(result types.UpdateResult, allowLevel string) summaryEntry {
+	entry := summaryEntry{name: result.Name()}
+
+	switch {
+	case result.SkipReason != "":
+		entry.kind = summarySkipped
+		if result.CurrentVersion() != "" {
+			entry.line = fmt.Sprintf("- ⏭️ **%s**: %s (skipped, %s)\n", result.Name(), result.CurrentVersion(), result.SkipReason)
+		} else {
+			entry.line = fmt.Sprintf("- ⏭️ **%s** (skipped, %s)\n", result.Name(), result.SkipReason)
+		}
+	case result.Error != nil:
+		entry.kind = summaryFailed
+		entry.line = fmt.Sprintf("- ❌ **%s**: %s (failed to check for updates)\n", result.Name(), result.CurrentVersion())
+	case result.Ignored:
+		entry.kind = summaryIgnored
+		entry.line = fmt.Sprintf("- ⏭️ **%s**: %s (ignored)\n", result.Name(), result.CurrentVersion())
+	case result.UpdateRequired && result.Declined:
+		entry.kind = summaryDeclined
+		entry.line = fmt.Sprintf("- ⏭️ **%s**: %s (update to %s declined)\n", result.Name(), result.CurrentVersion(), result.BumpVersion().String())
+	case result.UpdateRequired && result.Deferred:
+		entry.kind = summaryDeferred
+		entry.line = fmt.Sprintf("- ⏳ **%s**: %s (update to %s deferred to a later run)\n", result.Name(), result.CurrentVersion(), result.BumpVersion().String())
+	case result.UpdateRequired:
+		switch result.BumpVersion().GetBumpType(result.CurrentSemVer()) {
+		case config.BumpMinor:
+			entry.kind = summaryMinor
+		case config.BumpPatch:
+			entry.kind = summaryPatch
+		default:
+			entry.kind = summaryMajor
+		}
+		if result.BumpVersion().Compare(result.LatestVersion) != 0 {
+			entry.line = fmt.Sprintf("- 🔄 **%s**: %s → %s (latest is %s, not allowed by %s policy)",
+				result.Name(), result.CurrentVersion(), result.BumpVersion().String(), result.LatestVersion.String(), resultPolicy(result, allowLevel))
+		} else {
+			entry.line = fmt.Sprintf("- 🔄 **%s**: %s → %s", result.Name(), result.CurrentVersion(), result.BumpVersion().String())
+		}
+		if compareURL := resultCompareURL(result); compareURL != "" {
+			entry.line += fmt.Sprintf(" ([compare](%s))", compareURL)
+		}
+		entry.line += "\n"
+	case result.Unpublished:
+		entry.kind = summaryUnpublished
+		if result.LatestVersion != nil {
+			entry.line = fmt.Sprintf("- ❗ **%s**: %s (pinned version no longer published, latest is %s)\n",
+				result.Name(), result.CurrentVersion(), result.LatestVersion.String())
+		} else {
+			entry.line = fmt.Sprintf("- ❗ **%s**: %s (pinned version no longer published)\n", result.Name(), result.CurrentVersion())
+		}
+	case result.LatestVersion.IsNewerVersionThan(result.CurrentSemVer()):
+		entry.kind = summaryBlocked
+		if result.AllowedVersion != nil {
+			entry.line = fmt.Sprintf("- ⚠️ **%s**: %s (latest is %s but highest allowed under %s policy is %s)\n",
+				result.Name(), result.CurrentVersion(), result.LatestVersion.String(), resultPolicy(result, allowLevel), result.AllowedVersion.String())
+		} else {
+			entry.line = fmt.Sprintf("- ⚠️ **%s**: %s (newer version %s available but not allowed by %s policy)\n",
+				result.Name(), result.CurrentVersion(), result.LatestVersion.String(), resultPolicy(result, allowLevel))
+		}
+	default:
+		entry.kind = summaryUpToDate
+		entry.line = fmt.Sprintf("- ✅ **%s**: %s (up to date)\n", result.Name(), result.CurrentVersion())
+	}
+
+	if result.LatestStable != nil && result.LatestPrerelease != nil {
+		entry.line += fmt.Sprintf("  - latest stable %s, latest pre-release %s\n", result.LatestStable.String(), result.LatestPrerelease.String())
+	}
+	return entry
+}
+
+// resultCompareURL returns the URL of the page that compares the current revision of a bumped repository with the new one.
+// It returns an empty string for dependencies and for vendors without a compare page.
+func resultCompareURL(result types.UpdateResult) string {
+	if result.Dependency != nil {
+		return ""
+	}
+	return result.Repo.CompareURL(result.Repo.VersionRev(), result.Repo.FormatRevision(result.BumpVersion()))
+}
+
+// resultPolicy returns the allow level the bump decision of the result was made with.
+// An allow annotation on the repository takes precedence over the global allow level, dependencies always use the global level.
+func resultPolicy(result types.UpdateResult, allowLevel string) string {
+	if result.Dependency == nil && result.Repo.AllowOverride != "" {
+		return result.Repo.AllowOverride
+	}
+	return allowLevel
+}
+
+// hasMultipleConfigPaths reports whether the results belong to more than one pre-commit configuration file.
+func hasMultipleConfigPaths(results []types.UpdateResult) bool {
+	for _, result := range results {
+		if result.ConfigPath != results[0].ConfigPath {
+			return true
+		}
+	}
+	return false
+}
+
+// WritePreCommitChanges updates the pre-commit configuration file with the latest versions.
+// The new revisions keep the prefix and suffix of the current revisions, e.g. the "v" of "v1.2.3".
+// Results of other configuration files are ignored, so a repository that appears in several files is bumped in each
+// file with the result of that file.
+// The changes are the edits planned by PlanPreCommitChanges, so they match the plan of the json output format.
 func (s *ResultWriter) WritePreCommitChanges(configPath string, results []types.UpdateResult) error {
+	return s.WritePreCommitChangesTo(configPath, configPath, results)
+}
+
+// WritePreCommitChangesTo reads the pre-commit configuration file at configPath and writes it with the latest versions
+// to outPath, leaving the configuration file itself untouched when the paths differ.
+func (s *ResultWriter) WritePreCommitChangesTo(configPath, outPath string, results []types.UpdateResult) error {
 	data, err := s.fs.ReadFile(configPath)
 	if err != nil {
 		return fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	content := string(data)
-
+	var configResults []types.UpdateResult
 	for _, result := range results {
-		if !result.UpdateRequired || result.Error != nil {
-			continue
+		if result.ConfigPath == "" || result.ConfigPath == configPath {
+			configResults = append(configResults, result)
 		}
+	}
 
-		repoURL := regexp.QuoteMeta(result.Repo.Repo)
-		currentRev := regexp.QuoteMeta(result.Repo.SemVer.String())
-		newRev := result.LatestVersion.String()
-
-		pattern := fmt.Sprintf(`(repo:\s+%s\s+rev:\s+?[a-zA-Z]?)%s`, repoURL, currentRev)
-		replacement := fmt.Sprintf("${1}%s", newRev)
-		re := regexp.MustCompile(pattern)
-		content = re.ReplaceAllString(content, replacement)
-
-		s.logger.Sugar().Debugf("Updated %s from %s to %s", result.Repo.Repo, result.Repo.Rev, newRev)
+	content := string(data)
+	edits := PlanPreCommitChanges(configPath, content, configResults)
+	for _, edit := range edits {
+		s.logger.Sugar().Debugf("Updated %s on line %d from %s to %s", edit.Repo, edit.Line, edit.OldRev, edit.NewRev)
 	}
 
-	return s.fs.WriteFile(configPath, []byte(content), 0644)
+	return s.fs.WriteFile(outPath, []byte(applyEdits(content, edits)), 0644)
 }
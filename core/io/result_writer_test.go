@@ -0,0 +1,724 @@
+package io
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/ramonvermeulen/pre-commit-bump/config"
+	"github.com/ramonvermeulen/pre-commit-bump/core/types"
+)
+
+func TestResultWriter_WritePreCommitChanges(t *testing.T) {
+	tests := []struct {
+		name     string
+		rev      string
+		latest   string
+		expected string
+	}{
+		{
+			name:     "v prefixed tag",
+			rev:      "v1.2.3",
+			latest:   "v1.3.0",
+			expected: "v1.3.0",
+		},
+		{
+			name:     "bare tag",
+			rev:      "1.2.3",
+			latest:   "1.3.0",
+			expected: "1.3.0",
+		},
+		{
+			name:     "release- prefixed tag",
+			rev:      "release-1.2.3",
+			latest:   "v1.3.0",
+			expected: "release-1.3.0",
+		},
+		{
+			name:     "bare tag with a v prefixed latest tag",
+			rev:      "1.2.3",
+			latest:   "v1.3.0",
+			expected: "1.3.0",
+		},
+		{
+			name:     "v prefixed tag with a bare latest tag",
+			rev:      "v1.2.3",
+			latest:   "1.3.0",
+			expected: "v1.3.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := newMemoryFileSystem()
+			fs.files[".pre-commit-config.yaml"] = []byte("repos:\n  - repo: https://github.com/owner/repo\n    rev: " + tt.rev + " # pinned\n")
+
+			currentVersion, ok := types.GetSemanticVersion(tt.rev)
+			require.True(t, ok)
+			latestVersion, ok := types.GetSemanticVersion(tt.latest)
+			require.True(t, ok)
+
+			results := []types.UpdateResult{{
+				Repo:           types.Repo{Repo: "https://github.com/owner/repo", Rev: tt.rev, SemVer: currentVersion},
+				LatestVersion:  latestVersion,
+				UpdateRequired: true,
+			}}
+
+			err := NewResultWriter(fs, new(bytes.Buffer), zap.NewNop()).WritePreCommitChanges(".pre-commit-config.yaml", results)
+			require.NoError(t, err)
+
+			assert.Equal(t, "repos:\n  - repo: https://github.com/owner/repo\n    rev: "+tt.expected+" # pinned\n", string(fs.files[".pre-commit-config.yaml"]))
+		})
+	}
+}
+
+func TestResultWriter_WritePreCommitChanges_Frozen(t *testing.T) {
+	fs := newMemoryFileSystem()
+	fs.files[".pre-commit-config.yaml"] = []byte("repos:\n  - repo: https://github.com/owner/repo\n    rev: 1111111111111111111111111111111111111111  # frozen: v1.2.3\n")
+
+	results := []types.UpdateResult{{
+		Repo: types.Repo{
+			Repo:   "https://github.com/owner/repo",
+			Rev:    "1111111111111111111111111111111111111111",
+			Frozen: "v1.2.3",
+			SemVer: &types.SemanticVersion{Major: 1, Minor: 2, Patch: 3, Original: "1.2.3"},
+		},
+		LatestVersion:  &types.SemanticVersion{Major: 1, Minor: 3},
+		FrozenRev:      "2222222222222222222222222222222222222222",
+		UpdateRequired: true,
+	}}
+
+	err := NewResultWriter(fs, new(bytes.Buffer), zap.NewNop()).WritePreCommitChanges(".pre-commit-config.yaml", results)
+	require.NoError(t, err)
+
+	assert.Equal(t, "repos:\n  - repo: https://github.com/owner/repo\n    rev: 2222222222222222222222222222222222222222  # frozen: v1.3.0\n", string(fs.files[".pre-commit-config.yaml"]))
+}
+
+func TestResultWriter_WritePreCommitChanges_Freeze(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		expected string
+	}{
+		{
+			name:     "tag without comment",
+			line:     "rev: v1.2.3",
+			expected: "rev: 2222222222222222222222222222222222222222  # frozen: v1.3.0",
+		},
+		{
+			name:     "quoted tag with annotation",
+			line:     "rev: 'v1.2.3' # pcb:allow=minor",
+			expected: "rev: '2222222222222222222222222222222222222222'  # frozen: v1.3.0 pcb:allow=minor",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := newMemoryFileSystem()
+			fs.files[".pre-commit-config.yaml"] = []byte("repos:\n  - repo: https://github.com/owner/repo\n    " + tt.line + "\n")
+
+			results := []types.UpdateResult{{
+				Repo: types.Repo{
+					Repo:   "https://github.com/owner/repo",
+					Rev:    "v1.2.3",
+					SemVer: &types.SemanticVersion{Major: 1, Minor: 2, Patch: 3, Original: "1.2.3"},
+				},
+				LatestVersion:  &types.SemanticVersion{Major: 1, Minor: 3},
+				FrozenRev:      "2222222222222222222222222222222222222222",
+				UpdateRequired: true,
+			}}
+
+			err := NewResultWriter(fs, new(bytes.Buffer), zap.NewNop()).WritePreCommitChanges(".pre-commit-config.yaml", results)
+			require.NoError(t, err)
+
+			assert.Equal(t, "repos:\n  - repo: https://github.com/owner/repo\n    "+tt.expected+"\n", string(fs.files[".pre-commit-config.yaml"]))
+		})
+	}
+}
+
+func TestResultWriter_WriteSummary_GitHubStepSummary(t *testing.T) {
+	results := []types.UpdateResult{{
+		Repo:           types.Repo{Repo: "https://github.com/owner/repo", Rev: "v1.0.0"},
+		LatestVersion:  &types.SemanticVersion{Major: 1, Minor: 1},
+		UpdateRequired: true,
+	}}
+
+	t.Run("appends to the step summary", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+		stepSummaryPath := filepath.Join(t.TempDir(), "step_summary.md")
+		require.NoError(t, os.WriteFile(stepSummaryPath, []byte("# Previous step\n"), 0644))
+		t.Setenv(config.EnvGitHubStepSummary, stepSummaryPath)
+
+		err := NewResultWriter(NewOSFileSystem(), new(bytes.Buffer), zap.NewNop()).WriteSummary(results, "major")
+		require.NoError(t, err)
+
+		summary, err := os.ReadFile("summary.md")
+		require.NoError(t, err)
+		stepSummary, err := os.ReadFile(stepSummaryPath)
+		require.NoError(t, err)
+		assert.Equal(t, "# Previous step\n"+string(summary), string(stepSummary))
+		assert.Contains(t, string(summary), "https://github.com/owner/repo**: v1.0.0 → 1.1.0")
+	})
+
+	t.Run("falls back to summary.md when the step summary is unwritable", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+		t.Setenv(config.EnvGitHubStepSummary, t.TempDir())
+
+		err := NewResultWriter(NewOSFileSystem(), new(bytes.Buffer), zap.NewNop()).WriteSummary(results, "major")
+		require.NoError(t, err)
+
+		_, err = os.Stat("summary.md")
+		assert.NoError(t, err)
+	})
+}
+
+func TestResultWriter_WriteSummary_GroupsByFile(t *testing.T) {
+	results := []types.UpdateResult{
+		{
+			ConfigPath:     ".pre-commit-config.yaml",
+			Repo:           types.Repo{Repo: "https://github.com/owner/outdated", Rev: "v1.0.0", SemVer: &types.SemanticVersion{Major: 1, Original: "1.0.0"}},
+			LatestVersion:  &types.SemanticVersion{Major: 1, Minor: 1},
+			UpdateRequired: true,
+		},
+		{
+			ConfigPath:    "docs/.pre-commit-config.yaml",
+			Repo:          types.Repo{Repo: "https://github.com/owner/current", Rev: "v1.1.0", SemVer: &types.SemanticVersion{Major: 1, Minor: 1}},
+			LatestVersion: &types.SemanticVersion{Major: 1, Minor: 1},
+		},
+	}
+
+	fs := newMemoryFileSystem()
+	err := NewResultWriter(fs, new(bytes.Buffer), zap.NewNop()).WriteSummary(results, "major")
+	require.NoError(t, err)
+
+	assert.Contains(t, string(fs.files["summary.md"]), "### `.pre-commit-config.yaml`\n\n"+
+		"#### 🔄 Minor updates\n\n- 🔄 **https://github.com/owner/outdated**: v1.0.0 → 1.1.0 ([compare](https://github.com/owner/outdated/compare/v1.0.0...v1.1.0))\n\n"+
+		"### `docs/.pre-commit-config.yaml`\n\n"+
+		"#### ✅ Up to date\n\n- ✅ **https://github.com/owner/current**: v1.1.0 (up to date)\n")
+}
+
+func TestResultWriter_WriteSummary_Sections(t *testing.T) {
+	repo := func(name string, current *types.SemanticVersion) types.Repo {
+		return types.Repo{Repo: "https://github.com/owner/" + name, Rev: "v" + current.String(), SemVer: current}
+	}
+	v1 := &types.SemanticVersion{Major: 1, Original: "1.0.0"}
+	results := []types.UpdateResult{
+		{Repo: repo("zeta", v1), LatestVersion: &types.SemanticVersion{Major: 1, Patch: 1}, UpdateRequired: true},
+		{Repo: repo("current", v1), LatestVersion: v1},
+		{Repo: repo("major", v1), LatestVersion: &types.SemanticVersion{Major: 2}, UpdateRequired: true},
+		{Repo: repo("failing", v1), Error: assert.AnError},
+		{Repo: repo("alpha", v1), LatestVersion: &types.SemanticVersion{Major: 1, Patch: 2}, UpdateRequired: true},
+		{Repo: repo("blocked", v1), LatestVersion: &types.SemanticVersion{Major: 2}},
+		{Repo: repo("minor", v1), LatestVersion: &types.SemanticVersion{Major: 1, Minor: 1}, UpdateRequired: true},
+		{Repo: types.Repo{Repo: "local"}, SkipReason: config.SkipReasonSentinel},
+	}
+
+	fs := newMemoryFileSystem()
+	err := NewResultWriter(fs, new(bytes.Buffer), zap.NewNop()).WriteSummary(results, config.BumpMinor)
+	require.NoError(t, err)
+
+	assert.Equal(t, "# Pre-commit Hook Update Summary\n\n"+
+		"**Update Policy**: Only minor version updates are allowed\n\n"+
+		"### 🔄 Major updates\n\n"+
+		"- 🔄 **https://github.com/owner/major**: v1.0.0 → 2.0.0 ([compare](https://github.com/owner/major/compare/v1.0.0...v2.0.0))\n\n"+
+		"### 🔄 Minor updates\n\n"+
+		"- 🔄 **https://github.com/owner/minor**: v1.0.0 → 1.1.0 ([compare](https://github.com/owner/minor/compare/v1.0.0...v1.1.0))\n\n"+
+		"### 🔄 Patch updates\n\n"+
+		"- 🔄 **https://github.com/owner/alpha**: v1.0.0 → 1.0.2 ([compare](https://github.com/owner/alpha/compare/v1.0.0...v1.0.2))\n"+
+		"- 🔄 **https://github.com/owner/zeta**: v1.0.0 → 1.0.1 ([compare](https://github.com/owner/zeta/compare/v1.0.0...v1.0.1))\n\n"+
+		"### ⚠️ Blocked\n\n"+
+		"- ⚠️ **https://github.com/owner/blocked**: v1.0.0 (newer version 2.0.0 available but not allowed by minor policy)\n\n"+
+		"### ❌ Errors\n\n"+
+		"- ❌ **https://github.com/owner/failing**: v1.0.0 (failed to check for updates)\n\n"+
+		"### ⏭️ Skipped\n\n"+
+		"- ⏭️ **local** (skipped, local or meta hooks)\n\n"+
+		"### ✅ Up to date\n\n"+
+		"- ✅ **https://github.com/owner/current**: v1.0.0 (up to date)\n\n"+
+		"---\n\n"+
+		"## Summary\n\n"+
+		"- 🔄 **4** hooks updated\n"+
+		"- ✅ **1** hooks up to date\n"+
+		"- ⚠️ **1** hooks have newer versions available (blocked by minor policy)\n"+
+		"- ❌ **1** hooks failed to be checked\n"+
+		"- ⏭️ **1** hooks skipped\n", string(fs.files["summary.md"]))
+}
+
+func TestResultWriter_WriteSummary_CustomTemplate(t *testing.T) {
+	t.Setenv(config.EnvGitHubStepSummary, "")
+	fs := newMemoryFileSystem()
+	fs.files["summary.tmpl"] = []byte(`{{.Counts.Updated}}/{{len .Results}} updated ({{.AllowLevel}})
+{{range .Results}}{{.Name}}: {{.CurrentVersion}}{{if .UpdateRequired}} -> {{.BumpVersion}}{{end}}
+{{end}}{{range .Files}}{{range .Sections}}[{{.Title}}: {{len .Lines}}]{{end}}{{end}}
+`)
+
+	writer := NewResultWriter(fs, new(bytes.Buffer), zap.NewNop())
+	require.NoError(t, writer.LoadSummaryTemplate("summary.tmpl"))
+
+	results := []types.UpdateResult{
+		{
+			ConfigPath:     ".pre-commit-config.yaml",
+			Repo:           types.Repo{Repo: "https://example.org/owner/black", Rev: "1.0.0", SemVer: &types.SemanticVersion{Major: 1, Original: "1.0.0"}},
+			LatestVersion:  &types.SemanticVersion{Major: 1, Minor: 1, Original: "1.1.0"},
+			UpdateRequired: true,
+		},
+		{
+			ConfigPath:    ".pre-commit-config.yaml",
+			Repo:          types.Repo{Repo: "https://example.org/owner/isort", Rev: "5.0.0", SemVer: &types.SemanticVersion{Major: 5, Original: "5.0.0"}},
+			LatestVersion: &types.SemanticVersion{Major: 5, Original: "5.0.0"},
+		},
+	}
+	require.NoError(t, writer.WriteSummary(results, config.BumpMinor))
+
+	expected := `1/2 updated (minor)
+https://example.org/owner/black: 1.0.0 -> 1.1.0
+https://example.org/owner/isort: 5.0.0
+[🔄 Minor updates: 1][✅ Up to date: 1]
+`
+	assert.Equal(t, expected, string(fs.files["summary.md"]))
+}
+
+func TestResultWriter_LoadSummaryTemplate_Errors(t *testing.T) {
+	fs := newMemoryFileSystem()
+	fs.files["invalid.tmpl"] = []byte("{{.Counts.Updated")
+	writer := NewResultWriter(fs, new(bytes.Buffer), zap.NewNop())
+
+	err := writer.LoadSummaryTemplate("missing.tmpl")
+	assert.ErrorContains(t, err, "failed to read summary template")
+
+	err = writer.LoadSummaryTemplate("invalid.tmpl")
+	assert.ErrorContains(t, err, "failed to parse summary template")
+}
+
+func TestResultWriter_WriteSummary_CompareLinks(t *testing.T) {
+	dependency, ok := types.ParseDependency("flake8-bugbear==22.1.11")
+	require.True(t, ok)
+	dependency.HookID = "flake8"
+	results := []types.UpdateResult{
+		{
+			Repo:           types.Repo{Repo: "git@gitlab.com:group/repo.git", Rev: "v1.0.0", SemVer: &types.SemanticVersion{Major: 1, Original: "1.0.0"}},
+			LatestVersion:  &types.SemanticVersion{Major: 1, Minor: 1},
+			UpdateRequired: true,
+		},
+		{
+			Repo:           types.Repo{Repo: "https://github.com/owner/frozen", Rev: "1111111", Frozen: "v2.0.0", SemVer: &types.SemanticVersion{Major: 2, Original: "2.0.0"}},
+			LatestVersion:  &types.SemanticVersion{Major: 2, Minor: 1},
+			UpdateRequired: true,
+		},
+		{
+			Repo:           types.Repo{Repo: "https://github.com/PyCQA/flake8"},
+			Dependency:     dependency,
+			LatestVersion:  &types.SemanticVersion{Major: 24, Minor: 2},
+			UpdateRequired: true,
+		},
+	}
+
+	fs := newMemoryFileSystem()
+	err := NewResultWriter(fs, new(bytes.Buffer), zap.NewNop()).WriteSummary(results, config.BumpMajor)
+	require.NoError(t, err)
+
+	summary := string(fs.files["summary.md"])
+	assert.Contains(t, summary, "- 🔄 **git@gitlab.com:group/repo.git**: v1.0.0 → 1.1.0 ([compare](https://gitlab.com/group/repo/-/compare/v1.0.0...v1.1.0))\n")
+	assert.Contains(t, summary, "- 🔄 **https://github.com/owner/frozen**: v2.0.0 → 2.1.0 ([compare](https://github.com/owner/frozen/compare/v2.0.0...v2.1.0))\n")
+	assert.Contains(t, summary, "- 🔄 **https://github.com/PyCQA/flake8 (flake8: flake8-bugbear)**: 22.1.11 → 24.2.0\n", "dependencies are not linked")
+}
+
+func TestResultWriter_WriteSummary_AllowNone(t *testing.T) {
+	current := &types.SemanticVersion{Major: 1}
+	results := []types.UpdateResult{{
+		Repo:          types.Repo{Repo: "https://github.com/owner/repo", Rev: "v1.0.0", SemVer: current},
+		LatestVersion: &types.SemanticVersion{Major: 1, Minor: 1},
+	}}
+
+	fs := newMemoryFileSystem()
+	err := NewResultWriter(fs, new(bytes.Buffer), zap.NewNop()).WriteSummary(results, config.BumpNone)
+	require.NoError(t, err)
+
+	summary := string(fs.files["summary.md"])
+	assert.Contains(t, summary, "**Update Policy**: No version updates are allowed, available updates are only reported")
+	assert.Contains(t, summary, "- ⚠️ **https://github.com/owner/repo**: v1.0.0 (newer version 1.1.0 available but not allowed by none policy)")
+	assert.Contains(t, summary, "- ⚠️ **1** hooks have newer versions available (blocked by none policy)")
+}
+
+func TestResultWriter_WriteSummary_HighestAllowedVersion(t *testing.T) {
+	results := []types.UpdateResult{{
+		Repo:           types.Repo{Repo: "https://github.com/owner/repo", Rev: "v1.0.0", SemVer: &types.SemanticVersion{Major: 1}},
+		LatestVersion:  &types.SemanticVersion{Major: 3},
+		AllowedVersion: &types.SemanticVersion{Major: 1, Patch: 4},
+	}}
+
+	fs := newMemoryFileSystem()
+	err := NewResultWriter(fs, new(bytes.Buffer), zap.NewNop()).WriteSummary(results, config.BumpPatch)
+	require.NoError(t, err)
+
+	summary := string(fs.files["summary.md"])
+	assert.Contains(t, summary, "- ⚠️ **https://github.com/owner/repo**: v1.0.0 (latest is 3.0.0 but highest allowed under patch policy is 1.0.4)")
+	assert.Contains(t, summary, "- ⚠️ **1** hooks have newer versions available (blocked by patch policy)")
+}
+
+func TestResultWriter_WriteSummary_Unpublished(t *testing.T) {
+	results := []types.UpdateResult{{
+		Repo:          types.Repo{Repo: "https://github.com/owner/repo", Rev: "v2.0.0", SemVer: &types.SemanticVersion{Major: 2}},
+		LatestVersion: &types.SemanticVersion{Major: 1, Minor: 5},
+		Unpublished:   true,
+	}}
+
+	fs := newMemoryFileSystem()
+	err := NewResultWriter(fs, new(bytes.Buffer), zap.NewNop()).WriteSummary(results, config.BumpMajor)
+	require.NoError(t, err)
+
+	summary := string(fs.files["summary.md"])
+	assert.Contains(t, summary, "- ❗ **https://github.com/owner/repo**: v2.0.0 (pinned version no longer published, latest is 1.5.0)")
+	assert.Contains(t, summary, "- ❗ **1** hooks are pinned to a version that is no longer published")
+	assert.Contains(t, summary, "- ✅ **0** hooks up to date")
+}
+
+func TestResultWriter_WriteSummary_LatestPrerelease(t *testing.T) {
+	results := []types.UpdateResult{{
+		Repo:             types.Repo{Repo: "https://github.com/owner/repo", Rev: "v1.2.0", SemVer: &types.SemanticVersion{Major: 1, Minor: 2}},
+		LatestVersion:    &types.SemanticVersion{Major: 1, Minor: 2},
+		LatestStable:     &types.SemanticVersion{Major: 1, Minor: 2},
+		LatestPrerelease: &types.SemanticVersion{Major: 1, Minor: 3, PreRelease: "rc.1"},
+	}}
+
+	fs := newMemoryFileSystem()
+	err := NewResultWriter(fs, new(bytes.Buffer), zap.NewNop()).WriteSummary(results, config.BumpMajor)
+	require.NoError(t, err)
+
+	summary := string(fs.files["summary.md"])
+	assert.Contains(t, summary, "- ✅ **https://github.com/owner/repo**: v1.2.0 (up to date)\n  - latest stable 1.2.0, latest pre-release 1.3.0-rc.1\n")
+}
+
+func TestResultWriter_WriteSummary_Duplicate(t *testing.T) {
+	result := types.UpdateResult{
+		Repo:           types.Repo{Repo: "https://github.com/owner/repo", Rev: "v1.0.0", SemVer: &types.SemanticVersion{Major: 1}},
+		LatestVersion:  &types.SemanticVersion{Major: 1, Minor: 1},
+		UpdateRequired: true,
+	}
+	duplicate := result
+	duplicate.Repo.Repo = "git@github.com:owner/repo.git"
+	duplicate.Duplicate = true
+
+	fs := newMemoryFileSystem()
+	err := NewResultWriter(fs, new(bytes.Buffer), zap.NewNop()).WriteSummary([]types.UpdateResult{result, duplicate}, config.BumpMajor)
+	require.NoError(t, err)
+
+	summary := string(fs.files["summary.md"])
+	assert.Contains(t, summary, "- 🔄 **https://github.com/owner/repo**: v1.0.0 → 1.1.0")
+	assert.NotContains(t, summary, "git@github.com:owner/repo.git")
+	assert.Contains(t, summary, "- 🔄 **1** hooks updated")
+}
+
+func TestResultWriter_HighestAllowedBelowLatest(t *testing.T) {
+	results := []types.UpdateResult{{
+		ConfigPath:     ".pre-commit-config.yaml",
+		Repo:           types.Repo{Repo: "https://github.com/owner/repo", Rev: "v1.0.0", SemVer: &types.SemanticVersion{Major: 1, Original: "1.0.0"}},
+		LatestVersion:  &types.SemanticVersion{Major: 2},
+		AllowedVersion: &types.SemanticVersion{Major: 1, Patch: 1},
+		UpdateRequired: true,
+	}}
+
+	fs := newMemoryFileSystem()
+	fs.files[".pre-commit-config.yaml"] = []byte("repos:\n  - repo: https://github.com/owner/repo\n    rev: v1.0.0\n")
+	writer := NewResultWriter(fs, new(bytes.Buffer), zap.NewNop())
+
+	require.NoError(t, writer.WritePreCommitChanges(".pre-commit-config.yaml", results))
+	assert.Contains(t, string(fs.files[".pre-commit-config.yaml"]), "rev: v1.0.1")
+
+	require.NoError(t, writer.WriteSummary(results, config.BumpPatch))
+	assert.Contains(t, string(fs.files["summary.md"]), "- 🔄 **https://github.com/owner/repo**: v1.0.0 → 1.0.1 (latest is 2.0.0, not allowed by patch policy)")
+}
+
+func TestResultWriter_WriteSummary_AllowOverride(t *testing.T) {
+	results := []types.UpdateResult{{
+		Repo: types.Repo{
+			Repo:          "https://github.com/owner/repo",
+			Rev:           "v1.0.0",
+			SemVer:        &types.SemanticVersion{Major: 1},
+			AllowOverride: config.BumpNone,
+		},
+		LatestVersion: &types.SemanticVersion{Major: 1, Minor: 1},
+	}}
+
+	fs := newMemoryFileSystem()
+	err := NewResultWriter(fs, new(bytes.Buffer), zap.NewNop()).WriteSummary(results, config.BumpMajor)
+	require.NoError(t, err)
+
+	summary := string(fs.files["summary.md"])
+	assert.Contains(t, summary, "**Update Policy**: Only major version updates are allowed")
+	assert.Contains(t, summary, "- ⚠️ **https://github.com/owner/repo**: v1.0.0 (newer version 1.1.0 available but not allowed by none policy)")
+}
+
+func TestBuildSummaryData_Counts(t *testing.T) {
+	v1 := &types.SemanticVersion{Major: 1, Original: "1.0.0"}
+	results := []types.UpdateResult{
+		{Repo: types.Repo{Repo: "https://github.com/owner/failing", Rev: "v1.0.0", SemVer: v1}, Error: assert.AnError},
+		{Repo: types.Repo{Repo: "https://github.com/owner/current", Rev: "v1.0.0", SemVer: v1}, LatestVersion: v1},
+		{Repo: types.Repo{Repo: "https://github.com/owner/updated", Rev: "v1.0.0", SemVer: v1}, LatestVersion: &types.SemanticVersion{Major: 1, Minor: 1}, UpdateRequired: true},
+		{Repo: types.Repo{Repo: "https://github.com/owner/ignored", Rev: "v1.0.0", SemVer: v1}, Ignored: true},
+	}
+
+	data := buildSummaryData(results, config.BumpMajor)
+
+	assert.Equal(t, SummaryCounts{Updated: 1, UpToDate: 1, Failed: 1, Ignored: 1}, data.Counts)
+	require.Len(t, data.Files, 1)
+	assert.Equal(t, []SummarySection{
+		{Title: "🔄 Minor updates", Lines: []string{"- 🔄 **https://github.com/owner/updated**: v1.0.0 → 1.1.0 ([compare](https://github.com/owner/updated/compare/v1.0.0...v1.1.0))\n"}},
+		{Title: "❌ Errors", Lines: []string{"- ❌ **https://github.com/owner/failing**: v1.0.0 (failed to check for updates)\n"}},
+		{Title: "⏭️ Skipped", Lines: []string{"- ⏭️ **https://github.com/owner/ignored**: v1.0.0 (ignored)\n"}},
+		{Title: "✅ Up to date", Lines: []string{"- ✅ **https://github.com/owner/current**: v1.0.0 (up to date)\n"}},
+	}, data.Files[0].Sections)
+}
+
+func TestResultWriter_WriteSummary_Skipped(t *testing.T) {
+	results := []types.UpdateResult{
+		{
+			Repo:       types.Repo{Repo: "https://github.com/owner/sha", Rev: "a1b2c3d"},
+			SkipReason: config.SkipReasonNoVersion,
+		},
+		{
+			Repo:       types.Repo{Repo: "https://github.com/owner/branch", Rev: "main"},
+			SkipReason: config.SkipReasonNoVersion,
+		},
+		{
+			Repo:       types.Repo{Repo: config.SentinelLocal},
+			SkipReason: config.SkipReasonSentinel,
+		},
+	}
+
+	fs := newMemoryFileSystem()
+	err := NewResultWriter(fs, new(bytes.Buffer), zap.NewNop()).WriteSummary(results, config.BumpMajor)
+	require.NoError(t, err)
+
+	summary := string(fs.files["summary.md"])
+	assert.Contains(t, summary, "- ⏭️ **https://github.com/owner/sha**: a1b2c3d (skipped, revision is not a version)")
+	assert.Contains(t, summary, "- ⏭️ **https://github.com/owner/branch**: main (skipped, revision is not a version)")
+	assert.Contains(t, summary, "- ⏭️ **local** (skipped, local or meta hooks)")
+	assert.Contains(t, summary, "- ⏭️ **3** hooks skipped")
+	assert.Contains(t, summary, "- ✅ **0** hooks up to date")
+}
+
+func TestDependencyEdits(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "block list",
+			content:  "additional_dependencies:\n  - flake8-bugbear==22.1.11\n  - pep8-flake8-bugbear==22.1.11\n",
+			expected: "additional_dependencies:\n  - flake8-bugbear==24.2.6\n  - pep8-flake8-bugbear==22.1.11\n",
+		},
+		{
+			name:     "quoted flow list",
+			content:  "additional_dependencies: ['flake8-bugbear==22.1.11', flake8-docstrings]\n",
+			expected: "additional_dependencies: ['flake8-bugbear==24.2.6', flake8-docstrings]\n",
+		},
+		{
+			name:     "trailing comment",
+			content:  "  - flake8-bugbear==22.1.11 # pinned\n",
+			expected: "  - flake8-bugbear==24.2.6 # pinned\n",
+		},
+	}
+
+	dependency, ok := types.ParseDependency("flake8-bugbear==22.1.11")
+	require.True(t, ok)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, applyEdits(tt.content, dependencyEdits(tt.content, dependency, "24.2.6")))
+		})
+	}
+}
+
+func TestDependencyEdits_LocalHook(t *testing.T) {
+	tests := []struct {
+		name     string
+		pattern  string
+		hook     types.Hook
+		content  string
+		expected string
+	}{
+		{
+			name:     "delimited match",
+			pattern:  `pypi=(?P<name>ruff)==(?P<version>[0-9.]+)`,
+			hook:     types.Hook{ID: "ruff", Entry: "uvx ruff==0.4.1 check"},
+			content:  "repos:\n  - repo: local\n    hooks:\n      - id: ruff\n        entry: uvx ruff==0.4.1 check\n",
+			expected: "repos:\n  - repo: local\n    hooks:\n      - id: ruff\n        entry: uvx ruff==0.5.0 check\n",
+		},
+		{
+			name:     "match followed by a path",
+			pattern:  `npm=(?P<name>tool)@v(?P<version>[0-9.]+)`,
+			hook:     types.Hook{ID: "tool", Entry: "run tool@v0.4.1/bin"},
+			content:  "repos:\n  - repo: local\n    hooks:\n      - id: tool\n        entry: run tool@v0.4.1/bin\n",
+			expected: "repos:\n  - repo: local\n    hooks:\n      - id: tool\n        entry: run tool@v0.5.0/bin\n",
+		},
+		{
+			name:     "match preceded by a flag",
+			pattern:  `pypi=(?P<name>ruff)-version=(?P<version>[0-9.]+)`,
+			hook:     types.Hook{ID: "ruff", Entry: "install", Args: []string{"--ruff-version=0.4.1"}},
+			content:  "repos:\n  - repo: local\n    hooks:\n      - id: ruff\n        entry: install\n        args: [--ruff-version=0.4.1]\n",
+			expected: "repos:\n  - repo: local\n    hooks:\n      - id: ruff\n        entry: install\n        args: [--ruff-version=0.5.0]\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pattern, err := types.ParseLocalVersionPattern(tt.pattern)
+			require.NoError(t, err)
+
+			repo := types.Repo{Repo: config.SentinelLocal, Hooks: []types.Hook{tt.hook}}
+			dependencies := repo.LocalPinnedVersions([]*types.LocalVersionPattern{pattern})
+			require.Len(t, dependencies, 1)
+
+			assert.Equal(t, tt.expected, applyEdits(tt.content, dependencyEdits(tt.content, dependencies[0], "0.5.0")))
+		})
+	}
+}
+
+func TestResultWriter_planEdits_MatchesWrite(t *testing.T) {
+	content := `repos:
+  - repo: https://github.com/owner/repo
+    rev: v1.2.3 # pinned
+    hooks:
+      - id: lint
+        additional_dependencies: [flake8-bugbear==22.1.11]
+  - repo: https://github.com/owner/frozen
+    rev: 1111111111111111111111111111111111111111  # frozen: v2.0.0
+  - repo: https://github.com/owner/current
+    rev: v3.0.0
+`
+	dependency, ok := types.ParseDependency("flake8-bugbear==22.1.11")
+	require.True(t, ok)
+
+	results := []types.UpdateResult{
+		{
+			ConfigPath:     ".pre-commit-config.yaml",
+			Repo:           types.Repo{Repo: "https://github.com/owner/repo", Rev: "v1.2.3", SemVer: &types.SemanticVersion{Major: 1, Minor: 2, Patch: 3, Original: "1.2.3"}},
+			LatestVersion:  &types.SemanticVersion{Major: 1, Minor: 3},
+			UpdateRequired: true,
+		},
+		{
+			ConfigPath:     ".pre-commit-config.yaml",
+			Repo:           types.Repo{Repo: "https://github.com/owner/repo"},
+			Dependency:     dependency,
+			LatestVersion:  &types.SemanticVersion{Major: 24, Minor: 2, Patch: 6},
+			UpdateRequired: true,
+		},
+		{
+			ConfigPath: ".pre-commit-config.yaml",
+			Repo: types.Repo{
+				Repo:   "https://github.com/owner/frozen",
+				Rev:    "1111111111111111111111111111111111111111",
+				Frozen: "v2.0.0",
+				SemVer: &types.SemanticVersion{Major: 2, Original: "2.0.0"},
+			},
+			LatestVersion:  &types.SemanticVersion{Major: 2, Minor: 1},
+			FrozenRev:      "2222222222222222222222222222222222222222",
+			UpdateRequired: true,
+		},
+		{
+			ConfigPath:    ".pre-commit-config.yaml",
+			Repo:          types.Repo{Repo: "https://github.com/owner/current", Rev: "v3.0.0", SemVer: &types.SemanticVersion{Major: 3}},
+			LatestVersion: &types.SemanticVersion{Major: 3},
+		},
+	}
+
+	fs := newMemoryFileSystem()
+	fs.files[".pre-commit-config.yaml"] = []byte(content)
+	writer := NewResultWriter(fs, new(bytes.Buffer), zap.NewNop())
+
+	edits, err := writer.planEdits(results)
+	require.NoError(t, err)
+	require.Len(t, edits, 3)
+	assert.Equal(t, Edit{
+		Repo:     "https://github.com/owner/repo",
+		File:     ".pre-commit-config.yaml",
+		OldRev:   "v1.2.3",
+		NewRev:   "v1.3.0",
+		BumpType: config.BumpMinor,
+		Line:     3,
+		Start:    strings.Index(content, "v1.2.3"),
+		End:      strings.Index(content, "v1.2.3") + len("v1.2.3"),
+		Old:      "v1.2.3",
+		New:      "v1.3.0",
+	}, edits[0])
+	assert.Equal(t, "flake8-bugbear", edits[1].Dependency)
+	assert.Equal(t, config.BumpMajor, edits[1].BumpType)
+	assert.Equal(t, 6, edits[1].Line)
+	assert.Equal(t, config.BumpMinor, edits[2].BumpType)
+	assert.Equal(t, 8, edits[2].Line)
+
+	planned := content
+	for i := len(edits) - 1; i >= 0; i-- {
+		assert.Equal(t, edits[i].Old, planned[edits[i].Start:edits[i].End])
+		planned = planned[:edits[i].Start] + edits[i].New + planned[edits[i].End:]
+	}
+
+	require.NoError(t, writer.WritePreCommitChanges(".pre-commit-config.yaml", results))
+	assert.Equal(t, string(fs.files[".pre-commit-config.yaml"]), planned, "the plan should match the edits of an update")
+	assert.Contains(t, planned, "rev: 2222222222222222222222222222222222222222  # frozen: v2.1.0")
+}
+
+func TestResultWriter_Stdout(t *testing.T) {
+	tests := []struct {
+		name     string
+		write    func(writer *ResultWriter, results []types.UpdateResult) error
+		expected string
+	}{
+		{
+			name: "SARIF report",
+			write: func(writer *ResultWriter, results []types.UpdateResult) error {
+				return writer.WriteSARIFReport(results)
+			},
+			expected: `"version": "2.1.0"`,
+		},
+		{
+			name: "GitHub annotations",
+			write: func(writer *ResultWriter, results []types.UpdateResult) error {
+				return writer.WriteGitHubAnnotations(results)
+			},
+			expected: "::warning file=.pre-commit-config.yaml",
+		},
+		{
+			name:     "JSON plan",
+			write:    func(writer *ResultWriter, results []types.UpdateResult) error { return writer.WriteJSONPlan(results) },
+			expected: `"new_rev": "v1.1.0"`,
+		},
+		{
+			name: "summary preview",
+			write: func(writer *ResultWriter, results []types.UpdateResult) error {
+				return writer.PreviewSummary(results, config.BumpMajor)
+			},
+			expected: "https://github.com/owner/repo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := newMemoryFileSystem()
+			fs.files[".pre-commit-config.yaml"] = []byte("repos:\n  - repo: https://github.com/owner/repo\n    rev: v1.0.0\n")
+			currentVersion, ok := types.GetSemanticVersion("v1.0.0")
+			require.True(t, ok)
+			latestVersion, ok := types.GetSemanticVersion("v1.1.0")
+			require.True(t, ok)
+			results := []types.UpdateResult{{
+				ConfigPath:     ".pre-commit-config.yaml",
+				Repo:           types.Repo{Repo: "https://github.com/owner/repo", Rev: "v1.0.0", RevLine: 3, SemVer: currentVersion},
+				LatestVersion:  latestVersion,
+				AllowedVersion: latestVersion,
+				UpdateRequired: true,
+			}}
+
+			stdout := new(bytes.Buffer)
+			require.NoError(t, tt.write(NewResultWriter(fs, stdout, zap.NewNop()), results))
+
+			assert.Contains(t, stdout.String(), tt.expected)
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package io
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/ramonvermeulen/pre-commit-bump/config"
+	"github.com/ramonvermeulen/pre-commit-bump/core/types"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+)
+
+// sarifLog is the root object of a SARIF 2.1.0 document.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+// sarifRun holds the results of a single run of pre-commit-bump.
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+// sarifTool describes pre-commit-bump and the rules its results refer to.
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+// sarifDriver is the tool component that produced the results.
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+// sarifRule describes a kind of bump, every result refers to the rule of its bump type.
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+// sarifResult is a single hook or dependency that can be bumped.
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+// sarifMessage is a plain text message.
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+// sarifLocation points at the pre-commit configuration file, and the rev key of the repository when its line is known.
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+// sarifPhysicalLocation is the file and region a result applies to.
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+// sarifArtifactLocation is the URI of the pre-commit configuration file.
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// sarifRegion is the line of the rev key of the repository.
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// sarifRules are the rules of the SARIF report, one per bump type.
+var sarifRules = []sarifRule{
+	{ID: sarifRuleID(config.BumpMajor), ShortDescription: sarifMessage{Text: "A new major version of the hook is available"}},
+	{ID: sarifRuleID(config.BumpMinor), ShortDescription: sarifMessage{Text: "A new minor version of the hook is available"}},
+	{ID: sarifRuleID(config.BumpPatch), ShortDescription: sarifMessage{Text: "A new patch version of the hook is available"}},
+}
+
+// WriteSARIFReport prints the results as a SARIF 2.1.0 document to stdout, so it can be uploaded to code scanning tools.
+// Every hook or dependency that can be bumped is a result, hooks that are up to date or failed to be checked are left out.
+func (s *ResultWriter) WriteSARIFReport(results []types.UpdateResult) error {
+	data, err := buildSARIFReport(results)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.stdout.Write(data)
+	return err
+}
+
+// buildSARIFReport renders the results that require an update as a SARIF document with a single run.
+func buildSARIFReport(results []types.UpdateResult) ([]byte, error) {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "pre-commit-bump",
+			InformationURI: "https://github.com/ramonvermeulen/pre-commit-bump",
+			Rules:          sarifRules,
+		}},
+		Results: []sarifResult{},
+	}
+
+	for _, result := range results {
+		if !result.UpdateRequired || result.Error != nil {
+			continue
+		}
+
+		// An unknown bump type, e.g. for a version that can not be compared, is reported as major to stay on the safe side
+		bumpType := result.BumpVersion().GetBumpType(result.CurrentSemVer())
+		if bumpType == "" {
+			bumpType = config.BumpMajor
+		}
+
+		location := sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(result.ConfigPath)}}
+		if result.Dependency == nil && result.Repo.RevLine > 0 {
+			location.Region = &sarifRegion{StartLine: result.Repo.RevLine}
+		}
+
+		run.Results = append(run.Results, sarifResult{
+			RuleID: sarifRuleID(bumpType),
+			Level:  "warning",
+			Message: sarifMessage{Text: fmt.Sprintf("%s can be bumped %s -> %s (%s)",
+				result.Name(), result.CurrentVersion(), result.BumpVersion().String(), bumpType)},
+			Locations: []sarifLocation{{PhysicalLocation: location}},
+		})
+	}
+
+	data, err := json.MarshalIndent(sarifLog{Schema: sarifSchema, Version: sarifVersion, Runs: []sarifRun{run}}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal sarif report: %w", err)
+	}
+
+	return append(data, '\n'), nil
+}
+
+// sarifRuleID returns the ID of the rule of a bump type, e.g. "major-update".
+func sarifRuleID(bumpType string) string {
+	return bumpType + "-update"
+}
@@ -0,0 +1,87 @@
+package io
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ramonvermeulen/pre-commit-bump/core/types"
+)
+
+func TestBuildSARIFReport(t *testing.T) {
+	results := []types.UpdateResult{
+		{
+			ConfigPath:     ".pre-commit-config.yaml",
+			Repo:           types.Repo{Repo: "https://github.com/psf/black", Rev: "24.1.0", RevLine: 4, SemVer: &types.SemanticVersion{Major: 24, Minor: 1}},
+			LatestVersion:  &types.SemanticVersion{Major: 24, Minor: 2},
+			UpdateRequired: true,
+		},
+		{
+			ConfigPath:     ".pre-commit-config.yaml",
+			Repo:           types.Repo{Repo: "https://github.com/pycqa/isort", Rev: "5.0.0", SemVer: &types.SemanticVersion{Major: 5}},
+			LatestVersion:  &types.SemanticVersion{Major: 6},
+			UpdateRequired: true,
+		},
+		{
+			ConfigPath:    ".pre-commit-config.yaml",
+			Repo:          types.Repo{Repo: "https://github.com/pycqa/flake8", Rev: "7.0.0", RevLine: 10, SemVer: &types.SemanticVersion{Major: 7}},
+			LatestVersion: &types.SemanticVersion{Major: 7},
+		},
+	}
+
+	data, err := buildSARIFReport(results)
+	require.NoError(t, err)
+
+	var report map[string]any
+	require.NoError(t, json.Unmarshal(data, &report))
+	assert.Equal(t, "2.1.0", report["version"])
+	assert.Equal(t, "https://json.schemastore.org/sarif-2.1.0.json", report["$schema"])
+
+	runs := report["runs"].([]any)
+	require.Len(t, runs, 1)
+	run := runs[0].(map[string]any)
+
+	driver := run["tool"].(map[string]any)["driver"].(map[string]any)
+	assert.Equal(t, "pre-commit-bump", driver["name"])
+	var ruleIDs []any
+	for _, rule := range driver["rules"].([]any) {
+		ruleIDs = append(ruleIDs, rule.(map[string]any)["id"])
+	}
+	assert.Equal(t, []any{"major-update", "minor-update", "patch-update"}, ruleIDs)
+
+	sarifResults := run["results"].([]any)
+	require.Len(t, sarifResults, 2)
+
+	first := sarifResults[0].(map[string]any)
+	assert.Equal(t, "minor-update", first["ruleId"])
+	assert.Equal(t, "warning", first["level"])
+	assert.Equal(t, "https://github.com/psf/black can be bumped 24.1.0 -> 24.2.0 (minor)", first["message"].(map[string]any)["text"])
+	location := first["locations"].([]any)[0].(map[string]any)["physicalLocation"].(map[string]any)
+	assert.Equal(t, ".pre-commit-config.yaml", location["artifactLocation"].(map[string]any)["uri"])
+	assert.Equal(t, float64(4), location["region"].(map[string]any)["startLine"])
+
+	second := sarifResults[1].(map[string]any)
+	assert.Equal(t, "major-update", second["ruleId"])
+	location = second["locations"].([]any)[0].(map[string]any)["physicalLocation"].(map[string]any)
+	assert.NotContains(t, location, "region", "a result without a known line should not have a region")
+}
+
+func TestBuildSARIFReport_UpToDate(t *testing.T) {
+	results := []types.UpdateResult{{
+		ConfigPath:    ".pre-commit-config.yaml",
+		Repo:          types.Repo{Repo: "https://github.com/psf/black", Rev: "24.2.0", RevLine: 4, SemVer: &types.SemanticVersion{Major: 24, Minor: 2}},
+		LatestVersion: &types.SemanticVersion{Major: 24, Minor: 2},
+	}}
+
+	data, err := buildSARIFReport(results)
+	require.NoError(t, err)
+
+	var report sarifLog
+	require.NoError(t, json.Unmarshal(data, &report))
+	require.Len(t, report.Runs, 1)
+	assert.NotNil(t, report.Runs[0].Results)
+	assert.Empty(t, report.Runs[0].Results)
+	assert.Contains(t, string(data), `"results": []`, "an up-to-date configuration should produce an empty results array")
+}
@@ -0,0 +1,52 @@
+package io
+
+import (
+	stdio "io"
+	"sync"
+
+	"github.com/ramonvermeulen/pre-commit-bump/config"
+)
+
+// StdioFileSystem is a FileSystem that reads the config.StdinPath path from stdin and writes it to stdout.
+// All other paths are passed on to the wrapped FileSystem.
+// Stdin is read once and kept, so the pre-commit configuration can be parsed and rewritten in the same run.
+type StdioFileSystem struct {
+	FileSystem
+	stdin  stdio.Reader
+	stdout stdio.Writer
+
+	once  sync.Once
+	input []byte
+	err   error
+}
+
+// NewStdioFileSystem creates a new StdioFileSystem wrapping fs.
+func NewStdioFileSystem(fs FileSystem, stdin stdio.Reader, stdout stdio.Writer) *StdioFileSystem {
+	return &StdioFileSystem{
+		FileSystem: fs,
+		stdin:      stdin,
+		stdout:     stdout,
+	}
+}
+
+// ReadFile reads stdin for config.StdinPath, and the file from the wrapped FileSystem otherwise
+func (fs *StdioFileSystem) ReadFile(filename string) ([]byte, error) {
+	if filename != config.StdinPath {
+		return fs.FileSystem.ReadFile(filename)
+	}
+
+	fs.once.Do(func() {
+		fs.input, fs.err = stdio.ReadAll(fs.stdin)
+	})
+	return fs.input, fs.err
+}
+
+// WriteFile writes data to stdout for config.StdinPath, and to the file in the wrapped FileSystem otherwise
+func (fs *StdioFileSystem) WriteFile(filename string, data []byte, perm int) error {
+	if filename != config.StdinPath {
+		return fs.FileSystem.WriteFile(filename, data, perm)
+	}
+
+	_, err := fs.stdout.Write(data)
+	return err
+}
@@ -0,0 +1,37 @@
+package io
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ramonvermeulen/pre-commit-bump/config"
+)
+
+func TestStdioFileSystem(t *testing.T) {
+	files := newMemoryFileSystem()
+	files.files["summary.md"] = []byte("# Summary\n")
+	var stdout bytes.Buffer
+
+	fs := NewStdioFileSystem(files, strings.NewReader("repos: []\n"), &stdout)
+
+	for range 2 {
+		data, err := fs.ReadFile(config.StdinPath)
+		require.NoError(t, err)
+		assert.Equal(t, "repos: []\n", string(data), "stdin should be kept for subsequent reads")
+	}
+
+	data, err := fs.ReadFile("summary.md")
+	require.NoError(t, err)
+	assert.Equal(t, "# Summary\n", string(data))
+
+	require.NoError(t, fs.WriteFile(config.StdinPath, []byte("repos: [1]\n"), 0644))
+	require.NoError(t, fs.WriteFile("summary.md", []byte("# Updated\n"), 0644))
+
+	assert.Equal(t, "repos: [1]\n", stdout.String())
+	assert.Equal(t, "# Updated\n", string(files.files["summary.md"]))
+	assert.NotContains(t, files.files, config.StdinPath)
+}
@@ -1,47 +1,71 @@
 package parser
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	stdio "io"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
 
+	"github.com/ramonvermeulen/pre-commit-bump/config"
+	"github.com/ramonvermeulen/pre-commit-bump/core/io"
 	"github.com/ramonvermeulen/pre-commit-bump/core/types"
+	"github.com/ramonvermeulen/pre-commit-bump/core/utils"
 
 	"go.uber.org/zap"
 
 	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+	yamlparser "github.com/goccy/go-yaml/parser"
 )
 
 // Parser is responsible for parsing the pre-commit configuration file.
 // It provides methods to read and validate the configuration file.
 type Parser struct {
 	logger *zap.Logger
+	fs     io.FileSystem
 }
 
 // NewParser creates a new instance of Parser.
-// It initializes the parser and returns a pointer to it.
-func NewParser(logger *zap.Logger) *Parser {
-	return &Parser{logger: logger}
+// It initializes the parser with the FileSystem the configuration file is read from and returns a pointer to it.
+func NewParser(logger *zap.Logger, fs io.FileSystem) *Parser {
+	return &Parser{
+		logger: logger,
+		fs:     fs,
+	}
 }
 
 // ParseConfig reads and parses the pre-commit configuration file from the given path.
+// The path config.StdinPath reads the configuration from stdin, when the FileSystem supports it.
+// A file with multiple YAML documents separated by "---" is supported, the repos of all documents are merged.
 // It returns a PreCommitConfig struct or an error if the parsing fails.
 func (p *Parser) ParseConfig(pCfgPath string) (*types.PreCommitConfig, error) {
-	absPath, err := p.validatePath(pCfgPath)
+	data, err := p.readConfig(pCfgPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to validate pCfg path: %w", err)
+		return nil, err
+	}
+
+	if err := checkConfigContent(pCfgPath, data); err != nil {
+		return nil, err
 	}
 
-	data, err := os.ReadFile(absPath)
+	documents, err := p.parseDocuments(data)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read pCfg file: %w", err)
+		return nil, err
 	}
 
 	var pCfg types.PreCommitConfig
 	pCfg.Logger = p.logger
-	if err := yaml.Unmarshal(data, &pCfg); err != nil {
-		return nil, fmt.Errorf("failed to parse yaml: %w", err)
+	var topLevelKeys yaml.MapSlice
+	for _, document := range documents {
+		pCfg.Repos = append(pCfg.Repos, document.Repos...)
+		topLevelKeys = mergeTopLevelKeys(topLevelKeys, document.topLevelKeys)
 	}
+	pCfg.SetTopLevelKeys(topLevelKeys)
 
 	err = pCfg.Validate()
 	if err != nil {
@@ -53,6 +77,209 @@ func (p *Parser) ParseConfig(pCfgPath string) (*types.PreCommitConfig, error) {
 	return &pCfg, nil
 }
 
+// checkConfigContent sniffs whether the content looks like a pre-commit configuration, so pointing --config at e.g. a
+// JSON or text file results in a clear error instead of a YAML parse error.
+// Empty content passes, it is reported as having no repositories by the validation of the configuration.
+func checkConfigContent(pCfgPath string, data []byte) error {
+	if len(bytes.TrimSpace(data)) == 0 || regexp.MustCompile(config.ReReposKey).Match(data) {
+		return nil
+	}
+
+	message := fmt.Sprintf("config %s does not look like a pre-commit YAML (missing 'repos:')", pCfgPath)
+	if ext := strings.ToLower(filepath.Ext(pCfgPath)); ext != "" && ext != ".yaml" && ext != ".yml" {
+		message += fmt.Sprintf(", expected a .yaml or .yml file instead of %s", ext)
+	}
+	return errors.New(message)
+}
+
+// parsedDocument is a single YAML document of the configuration file, with its annotations, frozen comments and
+// line numbers applied.
+type parsedDocument struct {
+	types.PreCommitConfig
+	topLevelKeys yaml.MapSlice
+}
+
+// parseDocuments decodes every YAML document of the configuration file.
+// Comments and line numbers are resolved per document, as the YAML paths of the repos restart in each document.
+func (p *Parser) parseDocuments(data []byte) ([]parsedDocument, error) {
+	comments := yaml.CommentMap{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data), yaml.CommentToMap(comments))
+	keysDecoder := yaml.NewDecoder(bytes.NewReader(data))
+
+	file, err := yamlparser.ParseBytes(data, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse yaml: %w", err)
+	}
+	var bodies []ast.Node
+	for _, doc := range file.Docs {
+		if doc.Body != nil {
+			bodies = append(bodies, doc.Body)
+		}
+	}
+
+	var documents []parsedDocument
+	for {
+		clear(comments)
+
+		var document parsedDocument
+		if err := decoder.Decode(&document.PreCommitConfig); err != nil {
+			if errors.Is(err, stdio.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("failed to parse yaml: %w", err)
+		}
+		if err := keysDecoder.Decode(&document.topLevelKeys); err != nil {
+			return nil, fmt.Errorf("failed to parse yaml: %w", err)
+		}
+
+		p.applyAnnotations(&document.PreCommitConfig, comments)
+		applyFrozenComments(&document.PreCommitConfig, comments)
+		if index := len(documents); index < len(bodies) {
+			applyLineNumbers(&document.PreCommitConfig, bodies[index])
+		}
+
+		documents = append(documents, document)
+	}
+
+	return documents, nil
+}
+
+// mergeTopLevelKeys appends the top-level keys of a document that were not read from an earlier document.
+func mergeTopLevelKeys(keys yaml.MapSlice, document yaml.MapSlice) yaml.MapSlice {
+	for _, item := range document {
+		if !slices.ContainsFunc(keys, func(key yaml.MapItem) bool { return key.Key == item.Key }) {
+			keys = append(keys, item)
+		}
+	}
+	return keys
+}
+
+// readConfig reads the raw pre-commit configuration from the given path.
+func (p *Parser) readConfig(pCfgPath string) ([]byte, error) {
+	if pCfgPath == config.StdinPath {
+		data, err := p.fs.ReadFile(pCfgPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read pCfg from stdin: %w", err)
+		}
+		return data, nil
+	}
+
+	absPath, err := p.validatePath(pCfgPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate pCfg path: %w", err)
+	}
+
+	data, err := p.fs.ReadFile(absPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pCfg file: %w", err)
+	}
+	return data, nil
+}
+
+// applyAnnotations reads the "# pcb:<key>=<value>" annotations from the comments of each repo and stores them on the Repo.
+// Annotations can be placed on the line above the repo entry, or as a trailing comment of the repo or rev key.
+func (p *Parser) applyAnnotations(pCfg *types.PreCommitConfig, comments yaml.CommentMap) {
+	for i := range pCfg.Repos {
+		repo := &pCfg.Repos[i]
+
+		for key, value := range repoAnnotations(comments, i) {
+			switch key {
+			case config.AnnotationAllow:
+				repo.AllowOverride = value
+			case config.AnnotationConstraint:
+				repo.Constraint = value
+			case config.AnnotationTagPrefix:
+				repo.TagPrefix = value
+			default:
+				p.logger.Sugar().Warnf("Ignoring unknown annotation %s%s for repo: %s", config.AnnotationPrefix, key, repo.Repo)
+			}
+		}
+	}
+}
+
+// applyFrozenComments reads the "# frozen: <tag>" comment of the rev key of each repo, as written by pre-commit autoupdate --freeze.
+func applyFrozenComments(pCfg *types.PreCommitConfig, comments yaml.CommentMap) {
+	re := regexp.MustCompile(config.ReFrozenComment)
+
+	for i := range pCfg.Repos {
+		for _, comment := range comments[fmt.Sprintf("$.repos[%d].rev", i)] {
+			for _, text := range comment.Texts {
+				if tag := utils.GetGroup(re, re.FindStringSubmatch(text), "tag"); tag != "" {
+					pCfg.Repos[i].Frozen = tag
+				}
+			}
+		}
+	}
+}
+
+// applyLineNumbers records the lines of the repo and rev keys of each repo, so results and validation errors can
+// point at the right spot in the file.
+// Line numbers are informational only, a path that cannot be resolved leaves them unset.
+func applyLineNumbers(pCfg *types.PreCommitConfig, body ast.Node) {
+	for i := range pCfg.Repos {
+		pCfg.Repos[i].RepoLine = nodeLine(body, fmt.Sprintf("$.repos[%d].repo", i))
+		pCfg.Repos[i].RevLine = nodeLine(body, fmt.Sprintf("$.repos[%d].rev", i))
+	}
+}
+
+// nodeLine returns the line of the node at the given YAML path within the document body, or zero if the path does
+// not exist.
+func nodeLine(body ast.Node, path string) int {
+	yamlPath, err := yaml.PathString(path)
+	if err != nil {
+		return 0
+	}
+
+	node, err := yamlPath.FilterNode(body)
+	if err != nil || node == nil {
+		return 0
+	}
+
+	return node.GetToken().Position.Line
+}
+
+// repoAnnotations collects the annotations from the comments that belong to the repo at the given index.
+func repoAnnotations(comments yaml.CommentMap, index int) map[string]string {
+	annotations := map[string]string{}
+
+	paths := []string{
+		fmt.Sprintf("$.repos[%d]", index),
+		fmt.Sprintf("$.repos[%d].repo", index),
+		fmt.Sprintf("$.repos[%d].rev", index),
+	}
+
+	for _, path := range paths {
+		for _, comment := range comments[path] {
+			for _, text := range comment.Texts {
+				for key, value := range parseAnnotations(text) {
+					annotations[key] = value
+				}
+			}
+		}
+	}
+
+	return annotations
+}
+
+// parseAnnotations parses all "pcb:<key>=<value>" annotations from a single comment text.
+// A value ends at the next annotation or the next "#", so "pcb:allow=patch # pinned for CVE" keeps a trailing comment
+// out of the value.
+func parseAnnotations(text string) map[string]string {
+	annotations := map[string]string{}
+
+	segments := strings.Split(text, config.AnnotationPrefix)
+	for _, segment := range segments[1:] {
+		key, value, found := strings.Cut(segment, "=")
+		if !found {
+			continue
+		}
+		value, _, _ = strings.Cut(value, "#")
+		annotations[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+
+	return annotations
+}
+
 // validatePath checks if the provided configPath is valid and exists.
 // It returns the absolute path if valid, or an error if not.
 func (p *Parser) validatePath(configPath string) (string, error) {
@@ -1,14 +1,19 @@
 package parser
 
 import (
+	"bytes"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/goccy/go-yaml"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
+	"github.com/ramonvermeulen/pre-commit-bump/core/io"
 	"github.com/ramonvermeulen/pre-commit-bump/core/types"
 )
 
@@ -98,6 +103,27 @@ repos:
 				assert.Equal(t, "https://github.com/owner/repo", config.Repos[2].Repo)
 			},
 		},
+		{
+			name:     "config with calendar versioned repo",
+			filename: "calver-config.yaml",
+			content: `repos:
+  - repo: https://github.com/psf/black
+    rev: 22.3.0
+    hooks:
+      - id: black
+  - repo: https://github.com/owner/calver
+    rev: 2024.03.1
+    hooks:
+      - id: test`,
+			expectError: false,
+			validate: func(t *testing.T, config *types.PreCommitConfig) {
+				assert.Len(t, config.Repos, 2)
+				assert.Equal(t, types.VersionSchemeSemVer, config.Repos[0].Scheme)
+				assert.Equal(t, &types.SemanticVersion{Major: 22, Minor: 3, Patch: 0, Original: "22.3.0"}, config.Repos[0].SemVer)
+				assert.Equal(t, types.VersionSchemeCalVer, config.Repos[1].Scheme)
+				assert.Equal(t, "2024.03.1", config.Repos[1].SemVer.String())
+			},
+		},
 		{
 			name:     "config with invalid semantic version",
 			filename: "invalid-semver.yaml",
@@ -112,6 +138,70 @@ repos:
 				assert.Nil(t, config.Repos[0].SemVer)
 			},
 		},
+		{
+			name:     "config with allow annotations",
+			filename: "annotated-config.yaml",
+			content: `repos:
+  # pcb:allow=patch
+  - repo: https://github.com/owner/head-comment
+    rev: v1.0.0
+  - repo: https://github.com/owner/no-annotation
+    rev: v1.0.0
+  - repo: https://github.com/owner/rev-comment
+    rev: v1.0.0 # pinned, pcb:allow=minor`,
+			expectError: false,
+			validate: func(t *testing.T, config *types.PreCommitConfig) {
+				require.Len(t, config.Repos, 3)
+				assert.Equal(t, "patch", config.Repos[0].AllowOverride)
+				assert.Empty(t, config.Repos[1].AllowOverride)
+				assert.Equal(t, "minor", config.Repos[2].AllowOverride)
+			},
+		},
+		{
+			name:     "config with annotations followed by a comment",
+			filename: "commented-annotation.yaml",
+			content: `repos:
+  - repo: https://github.com/owner/allow
+    rev: v1.0.0 # pcb:allow=patch  # pinned for CVE
+  - repo: https://github.com/owner/constraint
+    rev: v1.0.0 # pcb:constraint=>=1.0, <2.0 # until the v2 migration`,
+			expectError: false,
+			validate: func(t *testing.T, config *types.PreCommitConfig) {
+				require.Len(t, config.Repos, 2)
+				assert.Equal(t, "patch", config.Repos[0].AllowOverride)
+				assert.Equal(t, ">=1.0, <2.0", config.Repos[1].Constraint)
+			},
+		},
+		{
+			name:     "config with invalid allow annotation",
+			filename: "invalid-annotation.yaml",
+			content: `repos:
+  - repo: https://github.com/owner/repo
+    rev: v1.0.0 # pcb:allow=everything`,
+			expectError: true,
+			errorMsg:    "invalid allow annotation \"everything\" for repository: https://github.com/owner/repo (line 2)",
+		},
+		{
+			name:     "config with constraint annotation",
+			filename: "constraint-annotation.yaml",
+			content: `repos:
+  - repo: https://github.com/owner/repo
+    rev: v1.0.0 # pcb:constraint=>=1.0, <2.0`,
+			expectError: false,
+			validate: func(t *testing.T, config *types.PreCommitConfig) {
+				require.Len(t, config.Repos, 1)
+				assert.Equal(t, ">=1.0, <2.0", config.Repos[0].Constraint)
+			},
+		},
+		{
+			name:     "config with invalid constraint annotation",
+			filename: "invalid-constraint.yaml",
+			content: `repos:
+  - repo: https://github.com/owner/repo
+    rev: v1.0.0 # pcb:constraint=~>2`,
+			expectError: true,
+			errorMsg:    "invalid constraint annotation for repository: https://github.com/owner/repo (line 2)",
+		},
 		{
 			name:        "empty config file",
 			filename:    "empty.yaml",
@@ -126,7 +216,7 @@ repos:
   - repo: ""
     rev: 1.0.0`,
 			expectError: true,
-			errorMsg:    "repository URL is empty",
+			errorMsg:    "repository URL is empty (line 2)",
 		},
 		{
 			name:     "config with missing revision",
@@ -136,7 +226,47 @@ repos:
     hooks:
       - id: test`,
 			expectError: true,
-			errorMsg:    "revision is empty for repository",
+			errorMsg:    "revision is empty for repository: https://github.com/owner/repo (line 2)",
+		},
+		{
+			name:        "text file",
+			filename:    "notes.txt",
+			content:     "Remember to bump the hooks\n",
+			expectError: true,
+			errorMsg:    "does not look like a pre-commit YAML (missing 'repos:'), expected a .yaml or .yml file instead of .txt",
+		},
+		{
+			name:        "JSON file without repos",
+			filename:    "package.json",
+			content:     `{"name": "app", "version": "1.0.0"}`,
+			expectError: true,
+			errorMsg:    "does not look like a pre-commit YAML (missing 'repos:'), expected a .yaml or .yml file instead of .json",
+		},
+		{
+			name:     "JSON config with repos",
+			filename: "config.json",
+			content:  `{"repos": [{"repo": "https://github.com/owner/repo", "rev": "v1.0.0"}]}`,
+			validate: func(t *testing.T, config *types.PreCommitConfig) {
+				require.Len(t, config.Repos, 1)
+				assert.Equal(t, "v1.0.0", config.Repos[0].Rev)
+			},
+		},
+		{
+			name:     "yml extension",
+			filename: ".pre-commit-config.yml",
+			content: `repos:
+  - repo: https://github.com/owner/repo
+    rev: v1.0.0`,
+			validate: func(t *testing.T, config *types.PreCommitConfig) {
+				require.Len(t, config.Repos, 1)
+			},
+		},
+		{
+			name:        "YAML file without repos",
+			filename:    "values.yaml",
+			content:     "image: app:1.0.0\n",
+			expectError: true,
+			errorMsg:    "does not look like a pre-commit YAML (missing 'repos:')",
 		},
 		{
 			name:        "invalid YAML syntax",
@@ -145,6 +275,32 @@ repos:
 			expectError: true,
 			errorMsg:    "failed to parse",
 		},
+		{
+			name:     "config with line numbers",
+			filename: "line-numbers.yaml",
+			content: `# See https://pre-commit.com for more information
+repos:
+  - repo: https://github.com/psf/black
+    rev: 22.3.0
+    hooks:
+      - id: black
+
+  - repo: local
+    hooks:
+      - id: local-hook
+  - rev: v2.1.0 # key order is not fixed
+    repo: https://gitlab.com/owner/repo`,
+			expectError: false,
+			validate: func(t *testing.T, config *types.PreCommitConfig) {
+				assert.Len(t, config.Repos, 3)
+				assert.Equal(t, 3, config.Repos[0].RepoLine)
+				assert.Equal(t, 4, config.Repos[0].RevLine)
+				assert.Equal(t, 8, config.Repos[1].RepoLine)
+				assert.Equal(t, 0, config.Repos[1].RevLine)
+				assert.Equal(t, 12, config.Repos[2].RepoLine)
+				assert.Equal(t, 11, config.Repos[2].RevLine)
+			},
+		},
 		{
 			name:     "config with multiple repos",
 			filename: "multiple-repos.yaml",
@@ -168,6 +324,22 @@ repos:
 				assert.Nil(t, config.Repos[2].SemVer)
 			},
 		},
+		{
+			name:     "frozen revision",
+			filename: "frozen-config.yaml",
+			content: `repos:
+  - repo: https://github.com/psf/black
+    rev: 8a737e727ac5ab2f1d4cf5876720ed276dc8dc4b  # frozen: 22.3.0
+    hooks:
+      - id: black`,
+			expectError: false,
+			validate: func(t *testing.T, config *types.PreCommitConfig) {
+				assert.Len(t, config.Repos, 1)
+				assert.Equal(t, "8a737e727ac5ab2f1d4cf5876720ed276dc8dc4b", config.Repos[0].Rev)
+				assert.Equal(t, "22.3.0", config.Repos[0].Frozen)
+				assert.Equal(t, &types.SemanticVersion{Major: 22, Minor: 3, Original: "22.3.0"}, config.Repos[0].SemVer)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -178,7 +350,7 @@ repos:
 			err := os.WriteFile(configPath, []byte(tt.content), 0644)
 			require.NoError(t, err, "Failed to create test file")
 
-			parser := NewParser(zap.NewNop())
+			parser := NewParser(zap.NewNop(), io.NewOSFileSystem())
 			config, err := parser.ParseConfig(configPath)
 
 			if tt.expectError {
@@ -258,7 +430,7 @@ func TestParser_ParseConfig_FileErrors(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			parser := NewParser(zap.NewNop())
+			parser := NewParser(zap.NewNop(), io.NewOSFileSystem())
 			filePath := tt.setupFile(t)
 
 			config, err := parser.ParseConfig(filePath)
@@ -337,7 +509,7 @@ func TestParser_validatePath(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			parser := NewParser(zap.NewNop())
+			parser := NewParser(zap.NewNop(), io.NewOSFileSystem())
 			testPath := tt.setupPath(t)
 
 			_, err := parser.ParseConfig(testPath)
@@ -356,9 +528,118 @@ func TestParser_validatePath(t *testing.T) {
 	}
 }
 
+func TestParser_ParseConfig_Stdin(t *testing.T) {
+	stdin := strings.NewReader(`repos:
+  - repo: https://github.com/psf/black
+    rev: 22.3.0
+    hooks:
+      - id: black
+  - repo: https://gitlab.com/owner/repo
+    rev: v2.1.0
+    hooks:
+      - id: test`)
+
+	parser := NewParser(zap.NewNop(), io.NewStdioFileSystem(io.NewOSFileSystem(), stdin, &bytes.Buffer{}))
+	config, err := parser.ParseConfig("-")
+
+	require.NoError(t, err)
+	require.Len(t, config.Repos, 2)
+	assert.Equal(t, "https://github.com/psf/black", config.Repos[0].Repo)
+	assert.Equal(t, "22.3.0", config.Repos[0].Rev)
+	assert.Equal(t, "https://gitlab.com/owner/repo", config.Repos[1].Repo)
+	assert.Equal(t, "v2.1.0", config.Repos[1].Rev)
+}
+
+func TestParser_ParseConfig_TopLevelKeysRoundTrip(t *testing.T) {
+	content := `default_language_version:
+  python: python3.12
+repos:
+  - repo: https://github.com/psf/black
+    rev: 22.3.0
+    hooks:
+      - id: black
+        additional_dependencies: [click==8.1.7]
+ci:
+  autofix_prs: false
+  skip: [black]
+fail_fast: true
+`
+	parser := NewParser(zap.NewNop(), io.NewStdioFileSystem(io.NewOSFileSystem(), strings.NewReader(content), &bytes.Buffer{}))
+	config, err := parser.ParseConfig("-")
+	require.NoError(t, err)
+
+	require.Len(t, config.Extra, 3)
+	assert.Equal(t, "default_language_version", config.Extra[0].Key)
+	assert.Equal(t, "ci", config.Extra[1].Key)
+	assert.Equal(t, "fail_fast", config.Extra[2].Key)
+	assert.Equal(t, true, config.Extra[2].Value)
+
+	marshaled, err := yaml.Marshal(config)
+	require.NoError(t, err)
+
+	var expected, actual map[string]any
+	require.NoError(t, yaml.Unmarshal([]byte(content), &expected))
+	require.NoError(t, yaml.Unmarshal(marshaled, &actual))
+	assert.Equal(t, expected, actual, "marshaling the parsed configuration should not drop any keys")
+
+	output := string(marshaled)
+	assert.Less(t, strings.Index(output, "default_language_version:"), strings.Index(output, "repos:"))
+	assert.Less(t, strings.Index(output, "repos:"), strings.Index(output, "ci:"))
+	assert.Less(t, strings.Index(output, "ci:"), strings.Index(output, "fail_fast:"))
+}
+
+func TestParser_ParseConfig_MultipleDocuments(t *testing.T) {
+	content := `repos:
+  - repo: https://github.com/psf/black
+    rev: 22.3.0 # pcb:allow=minor
+    hooks:
+      - id: black
+---
+ci:
+  autofix_prs: false
+repos:
+  - repo: https://gitlab.com/owner/repo
+    rev: v2.1.0 # pcb:allow=patch
+    hooks:
+      - id: test
+`
+	configPath := filepath.Join(t.TempDir(), ".pre-commit-config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0o600))
+
+	parser := NewParser(zap.NewNop(), io.NewOSFileSystem())
+	config, err := parser.ParseConfig(configPath)
+	require.NoError(t, err)
+
+	require.Len(t, config.Repos, 2)
+	assert.Equal(t, "https://github.com/psf/black", config.Repos[0].Repo)
+	assert.Equal(t, "minor", config.Repos[0].AllowOverride)
+	assert.Equal(t, 3, config.Repos[0].RevLine)
+	assert.Equal(t, "https://gitlab.com/owner/repo", config.Repos[1].Repo)
+	assert.Equal(t, "patch", config.Repos[1].AllowOverride)
+	assert.Equal(t, 10, config.Repos[1].RepoLine)
+	assert.Equal(t, 11, config.Repos[1].RevLine)
+	require.Len(t, config.Extra, 1)
+	assert.Equal(t, "ci", config.Extra[0].Key)
+
+	var results []types.UpdateResult
+	for _, repo := range config.Repos {
+		latest, ok := types.GetSemanticVersion(fmt.Sprintf("%d.%d.%d", repo.SemVer.Major, repo.SemVer.Minor, repo.SemVer.Patch+1))
+		require.True(t, ok)
+		results = append(results, types.UpdateResult{Repo: repo, LatestVersion: latest, UpdateRequired: true})
+	}
+
+	err = io.NewResultWriter(io.NewOSFileSystem(), new(bytes.Buffer), zap.NewNop()).WritePreCommitChanges(configPath, results)
+	require.NoError(t, err)
+
+	written, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	expected := strings.NewReplacer("rev: 22.3.0", "rev: 22.3.1", "rev: v2.1.0", "rev: v2.1.1").Replace(content)
+	assert.Equal(t, expected, string(written), "the revisions should be rewritten within their own document")
+}
+
 func TestNewParser(t *testing.T) {
 	logger := zap.NewNop()
-	parser := NewParser(logger)
+	parser := NewParser(logger, io.NewOSFileSystem())
 
 	assert.NotNil(t, parser, "Parser should not be nil")
 }
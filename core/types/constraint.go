@@ -0,0 +1,116 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ramonvermeulen/pre-commit-bump/config"
+	"github.com/ramonvermeulen/pre-commit-bump/core/utils"
+)
+
+// Constraint is a version range a repository is capped to, e.g. ">=1.0, <2.0".
+// A version satisfies the constraint when it satisfies all of its comma separated clauses.
+type Constraint struct {
+	clauses []constraintClause
+}
+
+// constraintClause is a single comparison of a Constraint, e.g. "<2.0.0".
+type constraintClause struct {
+	operator string
+	version  *SemanticVersion
+}
+
+// ParseConstraint parses a comma separated list of comparisons like ">=1.0, <2.0" into a Constraint.
+// Supported operators are >=, >, <=, <, ==, = and !=, a clause without operator must equal the version.
+// Versions may have a "v" prefix and omit the minor and patch segments, which then default to zero.
+func ParseConstraint(constraint string) (*Constraint, error) {
+	re := regexp.MustCompile(config.ReConstraintClause)
+	parsed := &Constraint{}
+
+	for _, clause := range strings.Split(constraint, ",") {
+		clause = strings.TrimSpace(clause)
+		match := re.FindStringSubmatch(clause)
+		if match == nil {
+			return nil, fmt.Errorf("invalid constraint clause %q", clause)
+		}
+
+		version, ok := parseConstraintVersion(utils.GetGroup(re, match, "version"))
+		if !ok {
+			return nil, fmt.Errorf("invalid version in constraint clause %q", clause)
+		}
+
+		operator := utils.GetGroup(re, match, "operator")
+		if operator == "" || operator == "=" {
+			operator = "=="
+		}
+
+		parsed.clauses = append(parsed.clauses, constraintClause{operator: operator, version: version})
+	}
+
+	return parsed, nil
+}
+
+// parseConstraintVersion parses the version of a constraint clause, either a release version of up to three segments
+// or a full semantic version with a pre-release.
+func parseConstraintVersion(version string) (*SemanticVersion, bool) {
+	if semVer, ok := GetReleaseVersion(version); ok {
+		return semVer, true
+	}
+
+	semVer, ok := GetSemanticVersion(version)
+	if !ok || semVer.Original != version {
+		return nil, false
+	}
+	return semVer, true
+}
+
+// Allows reports whether the version satisfies all clauses of the constraint.
+// A nil constraint allows any version.
+func (c *Constraint) Allows(version *SemanticVersion) bool {
+	if c == nil {
+		return true
+	}
+
+	for _, clause := range c.clauses {
+		if !clause.allows(version) {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter returns the versions that satisfy the constraint, in their original order.
+func (c *Constraint) Filter(versions []*SemanticVersion) []*SemanticVersion {
+	if c == nil {
+		return versions
+	}
+
+	var allowed []*SemanticVersion
+	for _, version := range versions {
+		if c.Allows(version) {
+			allowed = append(allowed, version)
+		}
+	}
+	return allowed
+}
+
+// allows reports whether the version satisfies the comparison of the clause.
+func (cc constraintClause) allows(version *SemanticVersion) bool {
+	result := version.Compare(cc.version)
+
+	switch cc.operator {
+	case ">=":
+		return result >= 0
+	case ">":
+		return result > 0
+	case "<=":
+		return result <= 0
+	case "<":
+		return result < 0
+	case "!=":
+		return result != 0
+	}
+
+	return result == 0
+}
@@ -0,0 +1,94 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstraint_Allows(t *testing.T) {
+	tests := []struct {
+		name       string
+		constraint string
+		version    *SemanticVersion
+		expected   bool
+	}{
+		{
+			name:       "below upper bound",
+			constraint: "<2.0.0",
+			version:    &SemanticVersion{Major: 1, Minor: 9, Patch: 9},
+			expected:   true,
+		},
+		{
+			name:       "upper bound is exclusive",
+			constraint: "<2.0.0",
+			version:    &SemanticVersion{Major: 2},
+			expected:   false,
+		},
+		{
+			name:       "range with short versions",
+			constraint: ">=1.0, <2",
+			version:    &SemanticVersion{Major: 1, Minor: 5},
+			expected:   true,
+		},
+		{
+			name:       "below lower bound of range",
+			constraint: ">=1.0, <2",
+			version:    &SemanticVersion{Major: 0, Minor: 9},
+			expected:   false,
+		},
+		{
+			name:       "inclusive upper bound with v prefix",
+			constraint: "<=v1.4.2",
+			version:    &SemanticVersion{Major: 1, Minor: 4, Patch: 2},
+			expected:   true,
+		},
+		{
+			name:       "excluded version",
+			constraint: ">1.0.0, !=1.3.0",
+			version:    &SemanticVersion{Major: 1, Minor: 3},
+			expected:   false,
+		},
+		{
+			name:       "exact version without operator",
+			constraint: "1.2.3",
+			version:    &SemanticVersion{Major: 1, Minor: 2, Patch: 3},
+			expected:   true,
+		},
+		{
+			name:       "pre-release precedes its release",
+			constraint: "<2.0.0",
+			version:    &SemanticVersion{Major: 2, PreRelease: "rc.1"},
+			expected:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			constraint, err := ParseConstraint(tt.constraint)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, constraint.Allows(tt.version))
+		})
+	}
+}
+
+func TestParseConstraint_Invalid(t *testing.T) {
+	for _, constraint := range []string{"", "<", "~>2.0", "<2.0.0,", ">=one"} {
+		t.Run(constraint, func(t *testing.T) {
+			_, err := ParseConstraint(constraint)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestConstraint_Filter(t *testing.T) {
+	constraint, err := ParseConstraint("<2.0.0")
+	require.NoError(t, err)
+
+	versions := []*SemanticVersion{{Major: 1}, {Major: 2}, {Major: 1, Minor: 5}}
+	assert.Equal(t, []*SemanticVersion{{Major: 1}, {Major: 1, Minor: 5}}, constraint.Filter(versions))
+
+	var noConstraint *Constraint
+	assert.Equal(t, versions, noConstraint.Filter(versions))
+}
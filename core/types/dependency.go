@@ -0,0 +1,247 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ramonvermeulen/pre-commit-bump/config"
+	"github.com/ramonvermeulen/pre-commit-bump/core/utils"
+)
+
+// Hook represents a single hook of a repository in the pre-commit config file.
+type Hook struct {
+	ID                     string   `yaml:"id"`
+	Entry                  string   `yaml:"entry,omitempty"`
+	Args                   []string `yaml:"args,omitempty"`
+	AdditionalDependencies []string `yaml:"additional_dependencies,omitempty"`
+}
+
+// Dependency represents an additional dependency of a hook that is pinned to an exact version, e.g. "flake8-bugbear==22.1.11".
+type Dependency struct {
+	HookID  string
+	Spec    string
+	Name    string
+	Version string
+	SemVer  *SemanticVersion
+	// Source is the package index the dependency is resolved from, config.DependencySourcePyPI or config.DependencySourceNpm
+	Source string
+	// Pattern is the pattern the version was matched with in the entry or args of a local hook, nil for additional_dependencies
+	Pattern *regexp.Regexp
+}
+
+// LocalVersionPattern is a pattern of a version pinned in the entry or args of a local hook, with the registry it is resolved from.
+type LocalVersionPattern struct {
+	Source string
+	Regexp *regexp.Regexp
+}
+
+// ParseLocalVersionPattern parses a "<registry>=<regex>" pattern, e.g. "pypi=(?P<name>ruff)==(?P<version>[0-9.]+)".
+// The registry is config.DependencySourcePyPI or config.DependencySourceNpm, the regex has a name and a version group.
+func ParseLocalVersionPattern(value string) (*LocalVersionPattern, error) {
+	source, expression, found := strings.Cut(value, "=")
+	if !found {
+		return nil, fmt.Errorf("invalid local version pattern %q, expected <registry>=<regex>", value)
+	}
+
+	sources := []string{config.DependencySourcePyPI, config.DependencySourceNpm}
+	if !slices.Contains(sources, source) {
+		return nil, fmt.Errorf("invalid registry %q of local version pattern %q. Allowed values are: %v", source, value, sources)
+	}
+
+	re, err := regexp.Compile(expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regex of local version pattern %q: %w", value, err)
+	}
+	if re.SubexpIndex("name") < 0 || re.SubexpIndex("version") < 0 {
+		return nil, fmt.Errorf("regex of local version pattern %q has no name and version group", value)
+	}
+
+	return &LocalVersionPattern{Source: source, Regexp: re}, nil
+}
+
+// ParseDependency parses a pinned dependency specification.
+// Specifications pinned with "==" are Python packages on PyPI, specifications pinned with "@" like "eslint@8.56.0" are
+// npm packages. It returns false if the dependency is not pinned to an exact final release version.
+func ParseDependency(spec string) (*Dependency, bool) {
+	if dependency, ok := parsePyPIDependency(spec); ok {
+		return dependency, true
+	}
+	return parseNpmDependency(spec)
+}
+
+// parsePyPIDependency parses a Python package pinned with "==", e.g. "flake8-bugbear==22.1.11".
+func parsePyPIDependency(spec string) (*Dependency, bool) {
+	re := regexp.MustCompile(config.RePinnedDependency)
+	match := re.FindStringSubmatch(strings.TrimSpace(spec))
+	if match == nil {
+		return nil, false
+	}
+
+	version := utils.GetGroup(re, match, "version")
+	semVer, ok := GetReleaseVersion(version)
+	if !ok {
+		return nil, false
+	}
+
+	return &Dependency{
+		Spec:    spec,
+		Name:    utils.GetGroup(re, match, "name"),
+		Version: version,
+		SemVer:  semVer,
+		Source:  config.DependencySourcePyPI,
+	}, true
+}
+
+// parseNpmDependency parses an npm package pinned with "@" to an exact semantic version, e.g. "@types/node@20.11.5".
+// Ranges like "^8.0.0", dist-tags like "latest" and pre-releases are not pinned, so they are not accepted.
+func parseNpmDependency(spec string) (*Dependency, bool) {
+	re := regexp.MustCompile(config.RePinnedNpmDependency)
+	match := re.FindStringSubmatch(strings.TrimSpace(spec))
+	if match == nil {
+		return nil, false
+	}
+
+	version := utils.GetGroup(re, match, "version")
+	semVer, ok := GetSemanticVersion(version)
+	if !ok || semVer.Original != version || semVer.PreRelease != "" {
+		return nil, false
+	}
+
+	return &Dependency{
+		Spec:    spec,
+		Name:    utils.GetGroup(re, match, "name"),
+		Version: version,
+		SemVer:  semVer,
+		Source:  config.DependencySourceNpm,
+	}, true
+}
+
+// GetReleaseVersion parses a final release version of up to three numeric segments, as commonly used on PyPI.
+// Missing segments are treated as zero, so "6.0" compares like "6.0.0" but keeps its original formatting.
+// Pre-, post- and dev-releases are not accepted.
+func GetReleaseVersion(version string) (*SemanticVersion, bool) {
+	re := regexp.MustCompile(config.ReReleaseVersion)
+	match := re.FindStringSubmatch(version)
+	if match == nil {
+		return nil, false
+	}
+
+	segments := make([]int, 3)
+	for i, name := range []string{"major", "minor", "patch"} {
+		raw := utils.GetGroup(re, match, name)
+		if raw == "" {
+			continue
+		}
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, false
+		}
+		segments[i] = value
+	}
+
+	return &SemanticVersion{
+		Major:    segments[0],
+		Minor:    segments[1],
+		Patch:    segments[2],
+		Original: version,
+	}, true
+}
+
+// PinnedDependencies returns the pinned additional dependencies of all hooks of the repository.
+// Dependencies that are not pinned to an exact release version are skipped.
+func (r *Repo) PinnedDependencies() []*Dependency {
+	var dependencies []*Dependency
+	for _, hook := range r.Hooks {
+		for _, spec := range hook.AdditionalDependencies {
+			if dependency, ok := ParseDependency(spec); ok {
+				dependency.HookID = hook.ID
+				dependencies = append(dependencies, dependency)
+			}
+		}
+	}
+	return dependencies
+}
+
+// LocalPinnedVersions returns the versions pinned in the entry and args of the hooks of a local repository that match
+// any of the patterns, as dependencies resolved from the registry of the pattern. The Spec of such a dependency is the
+// delimited token that contains the text the pattern matched, e.g. "tool@v1.2.3/bin" for a match of "tool@v1.2.3", so
+// the token can be found and replaced in the configuration file. Repositories other than local hooks have no local
+// pinned versions.
+func (r *Repo) LocalPinnedVersions(patterns []*LocalVersionPattern) []*Dependency {
+	if r.Repo != config.SentinelLocal {
+		return nil
+	}
+
+	var dependencies []*Dependency
+	for _, hook := range r.Hooks {
+		for _, text := range append([]string{hook.Entry}, hook.Args...) {
+			for _, pattern := range patterns {
+				for _, index := range pattern.Regexp.FindAllStringSubmatchIndex(text, -1) {
+					match := submatches(text, index)
+					version := utils.GetGroup(pattern.Regexp, match, "version")
+					semVer, ok := GetReleaseVersion(version)
+					if !ok {
+						continue
+					}
+					dependencies = append(dependencies, &Dependency{
+						HookID:  hook.ID,
+						Spec:    delimitedToken(text, index[0], index[1]),
+						Name:    utils.GetGroup(pattern.Regexp, match, "name"),
+						Version: version,
+						SemVer:  semVer,
+						Source:  pattern.Source,
+						Pattern: pattern.Regexp,
+					})
+				}
+			}
+		}
+	}
+	return dependencies
+}
+
+// WithVersion returns the specification of the dependency pinned to the given version instead.
+// Extras and environment markers of the original specification are kept.
+func (d *Dependency) WithVersion(version string) string {
+	pattern := config.RePinnedDependency
+	if d.Source == config.DependencySourceNpm {
+		pattern = config.RePinnedNpmDependency
+	}
+
+	re := d.Pattern
+	if re == nil {
+		re = regexp.MustCompile(pattern)
+	}
+	match := re.FindStringSubmatchIndex(d.Spec)
+	index := re.SubexpIndex("version")
+	if match == nil || match[2*index] < 0 {
+		return d.Spec
+	}
+	return d.Spec[:match[2*index]] + version + d.Spec[match[2*index+1]:]
+}
+
+// submatches returns the text of the submatches at the index pairs of a regexp match, like FindStringSubmatch.
+func submatches(text string, index []int) []string {
+	match := make([]string, len(index)/2)
+	for i := range match {
+		if index[2*i] >= 0 {
+			match[i] = text[index[2*i]:index[2*i+1]]
+		}
+	}
+	return match
+}
+
+// delimitedToken widens text[start:end] to the token that contains it. The token is delimited by the start or end of
+// the text and the same characters that delimit a pinned specification in the configuration file: whitespace, brackets,
+// commas and quotes, and a comment after it.
+func delimitedToken(text string, start, end int) string {
+	for start > 0 && !strings.ContainsRune(" \t\n\r[,'\"", rune(text[start-1])) {
+		start--
+	}
+	for end < len(text) && !strings.ContainsRune(" \t\n\r],'\"#", rune(text[end])) {
+		end++
+	}
+	return text[start:end]
+}
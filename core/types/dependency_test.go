@@ -0,0 +1,200 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ramonvermeulen/pre-commit-bump/config"
+)
+
+func TestParseDependency(t *testing.T) {
+	tests := []struct {
+		name            string
+		spec            string
+		expectedOk      bool
+		expectedName    string
+		expectedVersion string
+		expectedSemVer  string
+		expectedSource  string
+	}{
+		{
+			name:            "pinned dependency",
+			spec:            "flake8-bugbear==22.1.11",
+			expectedOk:      true,
+			expectedName:    "flake8-bugbear",
+			expectedVersion: "22.1.11",
+			expectedSemVer:  "22.1.11",
+			expectedSource:  config.DependencySourcePyPI,
+		},
+		{
+			name:            "extras and two segment version",
+			spec:            "black[jupyter]==24.1",
+			expectedOk:      true,
+			expectedName:    "black",
+			expectedVersion: "24.1",
+			expectedSemVer:  "24.1",
+			expectedSource:  config.DependencySourcePyPI,
+		},
+		{
+			name:            "environment marker",
+			spec:            "types-setuptools==69.0.0; python_version >= '3.8'",
+			expectedOk:      true,
+			expectedName:    "types-setuptools",
+			expectedVersion: "69.0.0",
+			expectedSemVer:  "69.0.0",
+			expectedSource:  config.DependencySourcePyPI,
+		},
+		{
+			name: "unpinned dependency",
+			spec: "flake8-bugbear",
+		},
+		{
+			name: "range specifier",
+			spec: "flake8-bugbear>=22.1.11",
+		},
+		{
+			name: "pre-release",
+			spec: "flake8-bugbear==23.0.0rc1",
+		},
+		{
+			name:            "npm package",
+			spec:            "eslint@8.56.0",
+			expectedOk:      true,
+			expectedName:    "eslint",
+			expectedVersion: "8.56.0",
+			expectedSemVer:  "8.56.0",
+			expectedSource:  config.DependencySourceNpm,
+		},
+		{
+			name:            "scoped npm package",
+			spec:            "@types/node@20.11.5",
+			expectedOk:      true,
+			expectedName:    "@types/node",
+			expectedVersion: "20.11.5",
+			expectedSemVer:  "20.11.5",
+			expectedSource:  config.DependencySourceNpm,
+		},
+		{
+			name: "npm range",
+			spec: "eslint@^8.56.0",
+		},
+		{
+			name: "npm dist-tag",
+			spec: "prettier@latest",
+		},
+		{
+			name: "npm pre-release",
+			spec: "prettier@3.0.0-alpha.6",
+		},
+		{
+			name: "unversioned scoped npm package",
+			spec: "@types/node",
+		},
+		{
+			name: "python direct reference",
+			spec: "black @ https://example.org/black-24.1.0.tar.gz",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dependency, ok := ParseDependency(tt.spec)
+
+			assert.Equal(t, tt.expectedOk, ok)
+			if !tt.expectedOk {
+				return
+			}
+			assert.Equal(t, tt.expectedName, dependency.Name)
+			assert.Equal(t, tt.expectedVersion, dependency.Version)
+			assert.Equal(t, tt.expectedSemVer, dependency.SemVer.String())
+			assert.Equal(t, tt.expectedSource, dependency.Source)
+		})
+	}
+}
+
+func TestDependency_WithVersion(t *testing.T) {
+	dependency, ok := ParseDependency("black[jupyter] == 24.1.0 ; python_version >= '3.8'")
+	assert.True(t, ok)
+	assert.Equal(t, "black[jupyter] == 24.2.0 ; python_version >= '3.8'", dependency.WithVersion("24.2.0"))
+
+	dependency, ok = ParseDependency("@typescript-eslint/parser@6.19.1")
+	assert.True(t, ok)
+	assert.Equal(t, "@typescript-eslint/parser@6.21.0", dependency.WithVersion("6.21.0"))
+}
+
+func TestRepo_PinnedDependencies(t *testing.T) {
+	repo := Repo{
+		Hooks: []Hook{
+			{ID: "flake8", AdditionalDependencies: []string{"flake8-bugbear==22.1.11", "flake8-docstrings"}},
+			{ID: "mypy", AdditionalDependencies: []string{"types-requests==2.31.0"}},
+		},
+	}
+
+	dependencies := repo.PinnedDependencies()
+
+	assert.Len(t, dependencies, 2)
+	assert.Equal(t, "flake8", dependencies[0].HookID)
+	assert.Equal(t, "flake8-bugbear", dependencies[0].Name)
+	assert.Equal(t, "mypy", dependencies[1].HookID)
+	assert.Equal(t, "types-requests", dependencies[1].Name)
+}
+
+func TestParseLocalVersionPattern(t *testing.T) {
+	tests := []struct {
+		name           string
+		value          string
+		expectedSource string
+		expectedError  string
+	}{
+		{name: "pypi pattern", value: `pypi=(?P<name>ruff)==(?P<version>[0-9.]+)`, expectedSource: config.DependencySourcePyPI},
+		{name: "npm pattern", value: `npm=(?P<name>prettier)@(?P<version>[0-9.]+)`, expectedSource: config.DependencySourceNpm},
+		{name: "missing registry", value: `(?P<name>ruff)(?P<version>[0-9.]+)`, expectedError: "expected <registry>=<regex>"},
+		{name: "unknown registry", value: `cargo=(?P<name>ripgrep)@(?P<version>[0-9.]+)`, expectedError: `invalid registry "cargo"`},
+		{name: "invalid regex", value: `pypi=(?P<name>ruff`, expectedError: "invalid regex"},
+		{name: "missing version group", value: `pypi=(?P<name>ruff)==[0-9.]+`, expectedError: "has no name and version group"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pattern, err := ParseLocalVersionPattern(tt.value)
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedSource, pattern.Source)
+		})
+	}
+}
+
+func TestRepo_LocalPinnedVersions(t *testing.T) {
+	pattern, err := ParseLocalVersionPattern(`pypi=(?P<name>ruff|mypy)==(?P<version>[0-9.]+)`)
+	assert.NoError(t, err)
+
+	hooks := []Hook{
+		{ID: "ruff", Entry: "uvx ruff==0.4.1 check"},
+		{ID: "mypy", Entry: "uvx", Args: []string{"--from", "mypy==1.9.0", "mypy"}},
+		{ID: "black", Entry: "uvx black==24.1.0"},
+		{ID: "ruff-bin", Entry: "run", Args: []string{"--with=ruff==0.4.2/bin"}},
+	}
+
+	local := Repo{Repo: config.SentinelLocal, Hooks: hooks}
+	dependencies := local.LocalPinnedVersions([]*LocalVersionPattern{pattern})
+
+	assert.Len(t, dependencies, 3)
+	assert.Equal(t, "ruff", dependencies[0].HookID)
+	assert.Equal(t, "ruff==0.4.1", dependencies[0].Spec)
+	assert.Equal(t, "ruff", dependencies[0].Name)
+	assert.Equal(t, "0.4.1", dependencies[0].Version)
+	assert.Equal(t, config.DependencySourcePyPI, dependencies[0].Source)
+	assert.Equal(t, "ruff==0.5.0", dependencies[0].WithVersion("0.5.0"))
+	assert.Equal(t, "mypy", dependencies[1].HookID)
+	assert.Equal(t, "mypy==1.9.0", dependencies[1].Spec)
+	assert.Equal(t, "--with=ruff==0.4.2/bin", dependencies[2].Spec, "the spec is the delimited token of the match")
+	assert.Equal(t, "0.4.2", dependencies[2].Version)
+	assert.Equal(t, "--with=ruff==0.5.0/bin", dependencies[2].WithVersion("0.5.0"))
+
+	remote := Repo{Repo: "https://github.com/owner/repo", Hooks: hooks}
+	assert.Empty(t, remote.LocalPinnedVersions([]*LocalVersionPattern{pattern}), "only local hooks are scanned")
+}
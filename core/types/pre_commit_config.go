@@ -2,37 +2,202 @@ package types
 
 import (
 	"fmt"
+	"path"
+	"regexp"
 	"slices"
 	"strings"
 
+	"github.com/goccy/go-yaml"
 	"github.com/ramonvermeulen/pre-commit-bump/config"
+	"github.com/ramonvermeulen/pre-commit-bump/core/utils"
 	"go.uber.org/zap"
 )
 
 // Repo represents a single repository configuration in the pre-commit config file.
 // It contains the repository URL and the revision (branch, tag, or commit) to use
 type Repo struct {
-	Repo   string `yaml:"repo"`
-	Rev    string `yaml:"rev"`
-	SemVer *SemanticVersion
+	Repo   string           `yaml:"repo"`
+	Rev    string           `yaml:"rev"`
+	Scheme VersionScheme    `yaml:"-"`
+	SemVer *SemanticVersion `yaml:"-"`
+	Hooks  []Hook           `yaml:"hooks"`
+	// RepoLine is the line of the repo key in the pre-commit configuration file, zero when unknown
+	RepoLine int `yaml:"-"`
+	// RevLine is the line of the rev key in the pre-commit configuration file, zero when unknown
+	RevLine int `yaml:"-"`
+	// Vendor is the vendor resolved from the configured host to vendor mapping, it takes precedence over host matching
+	Vendor string `yaml:"-"`
+	// AllowOverride is the allowed bump type set with a "# pcb:allow=<type>" annotation, it takes precedence over the global policy
+	AllowOverride string `yaml:"-"`
+	// Frozen is the tag of a revision pinned to a commit SHA, read from a "# frozen: <tag>" comment on the rev key
+	Frozen string `yaml:"-"`
+	// Constraint is the version range set with a "# pcb:constraint=<range>" annotation, e.g. ">=1.0, <2.0"
+	Constraint string `yaml:"-"`
+	// TagPrefix is the prefix the tags of the repository start with, set with a "# pcb:tag-prefix=<prefix>" annotation or --tag-prefix
+	TagPrefix string `yaml:"-"`
+	// Strict only accepts a revision or tag that is the version as a whole, optionally preceded by "v" or the tag prefix, set with --strict-versions
+	Strict bool `yaml:"-"`
 }
 
-// GetVendor determines the vendor of the repository based on its URL.
+// GetVendor determines the vendor of the repository.
+// A vendor resolved from the configured host mapping wins, otherwise the vendor is derived from the host of the URL when
+// it is one of the public hosts. A public host elsewhere in the URL, e.g. "https://example.org/github.com/owner/repo",
+// does not make it a repository of that vendor.
 func (r *Repo) GetVendor() string {
-	vendor := ""
-	if strings.Contains(r.Repo, config.VendorGitHubHost) {
-		vendor = config.VendorGitHub
-	} else if strings.Contains(r.Repo, config.VendorGitLabHost) {
-		vendor = config.VendorGitLab
+	if r.Vendor != "" {
+		return r.Vendor
 	}
-	return vendor
+
+	host, _, _ := strings.Cut(NormalizeRepoURL(r.Repo), "/")
+	switch host {
+	case config.VendorGitHubHost:
+		return config.VendorGitHub
+	case config.VendorGitLabHost:
+		return config.VendorGitLab
+	case config.VendorGiteaHost:
+		return config.VendorGitea
+	}
+	return ""
+}
+
+// Host returns the host of the repository URL, including the port if present.
+// It returns an empty string if the URL has no recognizable host.
+func (r *Repo) Host() string {
+	re := regexp.MustCompile(config.ReRepoHost)
+	matches := re.FindStringSubmatch(r.Repo)
+	return utils.GetGroup(re, matches, "host")
+}
+
+// NormalizeRepoURL normalizes a repository URL to the "host/owner/repo" form.
+// Scheme prefixes like "git+https://" and "ssh://", user info, ports and a trailing ".git" are stripped,
+// so "ssh://git@github.com:22/owner/repo.git" and "git@github.com:owner/repo" both become "github.com/owner/repo".
+// URLs without a recognizable host are returned unchanged.
+func NormalizeRepoURL(repoURL string) string {
+	re := regexp.MustCompile(config.ReRepoURL)
+	matches := re.FindStringSubmatch(strings.TrimSpace(repoURL))
+	host := utils.GetGroup(re, matches, "host")
+	if host == "" {
+		return repoURL
+	}
+
+	repoPath := strings.TrimSuffix(strings.Trim(utils.GetGroup(re, matches, "path"), "/"), ".git")
+	return strings.ToLower(host) + "/" + repoPath
+}
+
+// VersionRev returns the revision the version of the repository is read from.
+// For revisions pinned to a commit SHA this is the tag of the "# frozen: <tag>" comment, otherwise it is the revision itself.
+func (r *Repo) VersionRev() string {
+	if r.Frozen != "" {
+		return r.Frozen
+	}
+	return r.Rev
+}
+
+// FormatRevision formats the version as a revision in the same format as the current revision.
+// The prefix and suffix around the version in the current revision are reapplied, so "v1.2.3" becomes "v1.2.4"
+// and "release-1.2.3" becomes "release-1.2.4". For frozen revisions the tag of the frozen comment is used as format.
+// The style of the tag the version was read from is ignored, so "1.2.3" stays without a "v" when newer tags have one.
+// When the repository has a tag prefix that the current revision does not start with, the prefix is prepended instead.
+func (r *Repo) FormatRevision(version *SemanticVersion) string {
+	rev := r.VersionRev()
+	if r.TagPrefix != "" && !strings.HasPrefix(rev, r.TagPrefix) {
+		return r.TagPrefix + version.String()
+	}
+
+	if r.SemVer == nil || r.SemVer.Original == "" {
+		return version.String()
+	}
+
+	index := strings.Index(rev, r.SemVer.Original)
+	if index < 0 {
+		return version.String()
+	}
+
+	return rev[:index] + version.String() + rev[index+len(r.SemVer.Original):]
+}
+
+// CompareURL returns the URL of the web page that compares two revisions of the repository, e.g.
+// "https://github.com/owner/repo/compare/v1.0.0...v1.1.0". SSH and scp-like URLs are normalized to HTTPS first.
+// It returns an empty string for vendors without a known compare page, like plain git hosts.
+func (r *Repo) CompareURL(oldRev, newRev string) string {
+	switch r.GetVendor() {
+	case config.VendorGitHub, config.VendorGitea:
+		return fmt.Sprintf("https://%s/compare/%s...%s", NormalizeRepoURL(r.Repo), oldRev, newRev)
+	case config.VendorGitLab:
+		return fmt.Sprintf("https://%s/-/compare/%s...%s", NormalizeRepoURL(r.Repo), oldRev, newRev)
+	}
+	return ""
 }
 
+// MatchesAny reports whether the repository URL matches any of the given patterns.
+// A pattern matches when it equals the URL, matches it as a glob (e.g. "https://github.com/psf/*") or is a substring of it.
+func (r *Repo) MatchesAny(patterns []string) bool {
+	for _, pattern := range patterns {
+		if pattern == "" {
+			continue
+		}
+		if r.Repo == pattern || strings.Contains(r.Repo, pattern) {
+			return true
+		}
+		if matched, err := path.Match(pattern, r.Repo); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// ProvidesHook reports whether the repository provides a hook with any of the given ids.
+func (r *Repo) ProvidesHook(ids []string) bool {
+	return slices.ContainsFunc(r.Hooks, func(hook Hook) bool {
+		return slices.Contains(ids, hook.ID)
+	})
+}
+
+// location returns the position of the repository in the configuration file for error messages, e.g. " (line 4)".
+// It returns an empty string if the line is unknown.
+func (r *Repo) location() string {
+	if r.RepoLine == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (line %d)", r.RepoLine)
+}
+
+// reposKey is the top-level key of the repositories in the pre-commit configuration file.
+const reposKey = "repos"
+
 // PreCommitConfig represents the entire pre-commit configuration file.
 // It contains a slice of Repo structs, each representing a repository configuration.
+// The other top-level keys, like default_language_version, ci and fail_fast, are kept in Extra in the order of the file,
+// so marshaling the configuration does not drop them.
 type PreCommitConfig struct {
-	Repos  []Repo `yaml:"repos"`
-	Logger *zap.Logger
+	Repos  []Repo        `yaml:"repos"`
+	Extra  yaml.MapSlice `yaml:"-"`
+	Logger *zap.Logger   `yaml:"-"`
+	// reposIndex is the position of the repos key among the top-level keys
+	reposIndex int
+}
+
+// SetTopLevelKeys keeps the top-level keys of the document other than repos in Extra and remembers the position of repos.
+func (c *PreCommitConfig) SetTopLevelKeys(document yaml.MapSlice) {
+	c.Extra = nil
+	c.reposIndex = 0
+	for _, item := range document {
+		if item.Key == reposKey {
+			c.reposIndex = len(c.Extra)
+			continue
+		}
+		c.Extra = append(c.Extra, item)
+	}
+}
+
+// MarshalYAML marshals the configuration with repos and the keys of Extra in the order they were read in.
+func (c PreCommitConfig) MarshalYAML() (any, error) {
+	reposIndex := min(c.reposIndex, len(c.Extra))
+	document := make(yaml.MapSlice, 0, len(c.Extra)+1)
+	document = append(document, c.Extra[:reposIndex]...)
+	document = append(document, yaml.MapItem{Key: reposKey, Value: c.Repos})
+	document = append(document, c.Extra[reposIndex:]...)
+	return document, nil
 }
 
 // Validate checks the PreCommitConfig for required fields and valid values.
@@ -45,11 +210,19 @@ func (c *PreCommitConfig) Validate() error {
 
 	for _, repo := range c.Repos {
 		if repo.Repo == "" {
-			return fmt.Errorf("repository URL is empty")
+			return fmt.Errorf("repository URL is empty%s", repo.location())
 		}
 		if !slices.Contains(sentinelValues, repo.Repo) {
 			if repo.Rev == "" {
-				return fmt.Errorf("revision is empty for repository: %s", repo.Repo)
+				return fmt.Errorf("revision is empty for repository: %s%s", repo.Repo, repo.location())
+			}
+		}
+		if repo.AllowOverride != "" && !slices.Contains(config.AllowValues, repo.AllowOverride) {
+			return fmt.Errorf("invalid allow annotation %q for repository: %s%s. Allowed values are: %v", repo.AllowOverride, repo.Repo, repo.location(), config.AllowValues)
+		}
+		if repo.Constraint != "" {
+			if _, err := ParseConstraint(repo.Constraint); err != nil {
+				return fmt.Errorf("invalid constraint annotation for repository: %s%s: %w", repo.Repo, repo.location(), err)
 			}
 		}
 	}
@@ -57,29 +230,145 @@ func (c *PreCommitConfig) Validate() error {
 	return nil
 }
 
+// Warnings returns the problems of the PreCommitConfig that do not prevent it from being processed, like a repository
+// that is listed more than once or a revision that is not a version and is therefore never bumped.
+// Repositories are compared by their normalized URL, so "https://github.com/owner/repo.git" duplicates "git@github.com:owner/repo".
+// It expects PopulateSemVer to have been called, the warnings are in the order of the repositories.
+func (c *PreCommitConfig) Warnings() []string {
+	var warnings []string
+	firstOccurrence := map[string]Repo{}
+
+	for _, repo := range c.Repos {
+		if repo.SkipReason() == config.SkipReasonSentinel {
+			continue
+		}
+
+		key := NormalizeRepoURL(repo.Repo)
+		if first, ok := firstOccurrence[key]; ok {
+			warnings = append(warnings, duplicateWarning(first, repo))
+		} else {
+			firstOccurrence[key] = repo
+		}
+
+		if repo.SkipReason() == config.SkipReasonNoVersion {
+			warnings = append(warnings, fmt.Sprintf("revision %s of repository %s is not a version and is never bumped%s", repo.Rev, repo.Repo, repo.location()))
+		}
+	}
+
+	return warnings
+}
+
+// duplicateWarning describes a repository that is listed again after its first occurrence.
+// Listing a repository twice at different revisions is almost always a mistake, so the revisions are included then.
+func duplicateWarning(first, repo Repo) string {
+	warning := fmt.Sprintf("repository %s is listed more than once%s", repo.Repo, repo.location())
+	if first.Rev != repo.Rev {
+		warning = fmt.Sprintf("repository %s is listed more than once with different revisions %s and %s%s", repo.Repo, first.Rev, repo.Rev, repo.location())
+	}
+	if first.RepoLine > 0 {
+		warning += fmt.Sprintf(", first listed on line %d", first.RepoLine)
+	}
+	return warning
+}
+
 // PopulateSemVer populates the SemVer field of each Repo in the PreCommitConfig.
-// It parses the Rev field of each Repo and sets the SemVer field if the revision is a valid semantic version.
+// It parses the Rev field, or the frozen tag of revisions pinned to a commit SHA, of each Repo using its version scheme and sets the SemVer field if the revision is a valid version.
+// Repos without an explicit version scheme get their scheme detected from the revision.
 func (c *PreCommitConfig) PopulateSemVer() {
 	for i := range c.Repos {
-		if semVer, ok := GetSemanticVersion(c.Repos[i].Rev); ok {
-			c.Repos[i].SemVer = semVer
+		repo := &c.Repos[i]
+		repo.SemVer = nil
+
+		if repo.Scheme == "" || repo.Scheme == VersionSchemeAuto {
+			scheme, ok := DetectVersionScheme(repo.VersionRev())
+			if !ok {
+				continue
+			}
+			repo.Scheme = scheme
+		}
+
+		if semVer, ok := repo.ParseVersion(repo.VersionRev()); ok {
+			repo.SemVer = semVer
 		}
 	}
 }
 
+// ParseVersion parses a revision or tag of the repository with its version scheme.
+// When the repository is strict, the version has to be the whole string, optionally preceded by "v" or the tag prefix.
+func (r *Repo) ParseVersion(version string) (*SemanticVersion, bool) {
+	if r.Strict {
+		return ParseStrictVersion(version, r.Scheme, r.TagPrefix)
+	}
+	return ParseVersion(version, r.Scheme)
+}
+
+// SetVersionScheme sets the version scheme of every Repo in the PreCommitConfig and re-parses their revisions.
+func (c *PreCommitConfig) SetVersionScheme(scheme VersionScheme) {
+	for i := range c.Repos {
+		c.Repos[i].Scheme = scheme
+	}
+	c.PopulateSemVer()
+}
+
+// SetTagPrefix sets the tag prefix of every Repo that has no tag prefix annotation.
+func (c *PreCommitConfig) SetTagPrefix(prefix string) {
+	for i := range c.Repos {
+		if c.Repos[i].TagPrefix == "" {
+			c.Repos[i].TagPrefix = prefix
+		}
+	}
+}
+
+// SetStrictVersions makes every Repo in the PreCommitConfig strict and re-parses their revisions.
+func (c *PreCommitConfig) SetStrictVersions() {
+	for i := range c.Repos {
+		c.Repos[i].Strict = true
+	}
+	c.PopulateSemVer()
+}
+
+// SetVendorHosts resolves the vendor of every Repo whose host is present in the given host to vendor mapping.
+func (c *PreCommitConfig) SetVendorHosts(vendorHosts map[string]string) {
+	for i := range c.Repos {
+		if vendor, ok := vendorHosts[c.Repos[i].Host()]; ok {
+			c.Repos[i].Vendor = vendor
+		}
+	}
+}
+
+// SkipReason returns why the repository is not checked for updates, or an empty string if it is checked.
+// Sentinel repositories ("local" and "meta") have no revision, and revisions like commit SHAs or branch names are not a version.
+func (r *Repo) SkipReason() string {
+	if slices.Contains([]string{config.SentinelMeta, config.SentinelLocal}, r.Repo) {
+		return config.SkipReasonSentinel
+	}
+	if r.SemVer == nil {
+		return config.SkipReasonNoVersion
+	}
+	return ""
+}
+
+// IsMutableRef reports whether the repository is pinned to a ref that can move, like a branch name or "HEAD".
+// Sentinel repositories, revisions that are a version and full commit SHAs are not mutable.
+func (r *Repo) IsMutableRef() bool {
+	if r.SkipReason() != config.SkipReasonNoVersion {
+		return false
+	}
+	return !regexp.MustCompile(config.ReCommitSHA).MatchString(r.Rev)
+}
+
 // ValidRepos filters out sentinel values from the Repos slice and returns a slice of valid Repo structs.
 // Sentinel values are "local" and "meta", which are not considered valid repositories.
 // This function is useful for excluding certain repositories that are not meant to be processed.
 func (c *PreCommitConfig) ValidRepos() []Repo {
 	var validRepos []Repo
 
-	sentinelValues := []string{config.SentinelMeta, config.SentinelLocal}
 	for _, repo := range c.Repos {
-		if slices.Contains(sentinelValues, repo.Repo) {
+		switch repo.SkipReason() {
+		case config.SkipReasonSentinel:
 			c.Logger.Sugar().Debugf("Skipping sentinel repo: %s", repo.Repo)
 			continue
-		}
-		if repo.SemVer == nil {
+		case config.SkipReasonNoVersion:
 			c.Logger.Sugar().Debugf("Skipping repo with invalid semantic version: %s, rev: %s", repo.Repo, repo.Rev)
 			continue
 		}
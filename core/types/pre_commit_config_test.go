@@ -0,0 +1,364 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ramonvermeulen/pre-commit-bump/config"
+)
+
+func TestRepo_GetVendor(t *testing.T) {
+	vendorHosts := map[string]string{
+		"git.example.org":      "gitea",
+		"github.mycorp.com":    "github",
+		"git.example.org:3000": "gitea",
+	}
+
+	tests := []struct {
+		name         string
+		repoURL      string
+		expectedHost string
+		expected     string
+	}{
+		{
+			name:         "public GitHub",
+			repoURL:      "https://github.com/owner/repo",
+			expectedHost: "github.com",
+			expected:     "github",
+		},
+		{
+			name:         "public GitLab over ssh",
+			repoURL:      "git@gitlab.com:owner/repo.git",
+			expectedHost: "gitlab.com",
+			expected:     "gitlab",
+		},
+		{
+			name:         "public GitHub over git+ssh",
+			repoURL:      "git+ssh://git@github.com/owner/repo.git",
+			expectedHost: "github.com",
+			expected:     "github",
+		},
+		{
+			name:         "public GitLab over ssh scheme with port",
+			repoURL:      "ssh://git@gitlab.com:22/owner/repo.git",
+			expectedHost: "gitlab.com:22",
+			expected:     "gitlab",
+		},
+		{
+			name:         "public Gitea",
+			repoURL:      "https://gitea.com/owner/repo",
+			expectedHost: "gitea.com",
+			expected:     "gitea",
+		},
+		{
+			name:         "self-hosted Gitea from host mapping",
+			repoURL:      "https://git.example.org/owner/repo",
+			expectedHost: "git.example.org",
+			expected:     "gitea",
+		},
+		{
+			name:         "self-hosted Gitea with port from host mapping",
+			repoURL:      "https://git.example.org:3000/owner/repo",
+			expectedHost: "git.example.org:3000",
+			expected:     "gitea",
+		},
+		{
+			name:         "enterprise GitHub from host mapping",
+			repoURL:      "git@github.mycorp.com:owner/repo.git",
+			expectedHost: "github.mycorp.com",
+			expected:     "github",
+		},
+		{
+			name:         "unknown host",
+			repoURL:      "https://git.unknown.org/owner/repo",
+			expectedHost: "git.unknown.org",
+			expected:     "",
+		},
+		{
+			name:         "public host in the path of another host",
+			repoURL:      "https://attacker.example/github.com/owner/repo",
+			expectedHost: "attacker.example",
+			expected:     "",
+		},
+		{
+			name:         "public host as a subdomain of another host",
+			repoURL:      "https://gitlab.com.attacker.example/owner/repo",
+			expectedHost: "gitlab.com.attacker.example",
+			expected:     "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pCfg := &PreCommitConfig{Repos: []Repo{{Repo: tt.repoURL}}}
+			pCfg.SetVendorHosts(vendorHosts)
+
+			assert.Equal(t, tt.expectedHost, pCfg.Repos[0].Host())
+			assert.Equal(t, tt.expected, pCfg.Repos[0].GetVendor())
+		})
+	}
+}
+
+func TestNormalizeRepoURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		repoURL  string
+		expected string
+	}{
+		{
+			name:     "https URL",
+			repoURL:  "https://github.com/owner/repo",
+			expected: "github.com/owner/repo",
+		},
+		{
+			name:     "git+https URL",
+			repoURL:  "git+https://gitlab.com/group/subgroup/repo.git",
+			expected: "gitlab.com/group/subgroup/repo",
+		},
+		{
+			name:     "ssh scheme URL with port",
+			repoURL:  "ssh://git@github.com:22/owner/repo.git",
+			expected: "github.com/owner/repo",
+		},
+		{
+			name:     "scp-like URL",
+			repoURL:  "git@gitlab.com:owner/repo.git",
+			expected: "gitlab.com/owner/repo",
+		},
+		{
+			name:     "uppercase host and trailing slash",
+			repoURL:  "https://GitHub.com/owner/repo/",
+			expected: "github.com/owner/repo",
+		},
+		{
+			name:     "sentinel without host",
+			repoURL:  "local",
+			expected: "local",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NormalizeRepoURL(tt.repoURL))
+		})
+	}
+}
+
+func TestRepo_CompareURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		repo     Repo
+		expected string
+	}{
+		{
+			name:     "github https",
+			repo:     Repo{Repo: "https://github.com/owner/repo"},
+			expected: "https://github.com/owner/repo/compare/v1.0.0...v1.1.0",
+		},
+		{
+			name:     "github ssh is normalized to https",
+			repo:     Repo{Repo: "git@github.com:owner/repo.git"},
+			expected: "https://github.com/owner/repo/compare/v1.0.0...v1.1.0",
+		},
+		{
+			name:     "gitlab with subgroup",
+			repo:     Repo{Repo: "https://gitlab.com/group/subgroup/repo.git"},
+			expected: "https://gitlab.com/group/subgroup/repo/-/compare/v1.0.0...v1.1.0",
+		},
+		{
+			name:     "gitlab ssh with port is normalized to https",
+			repo:     Repo{Repo: "ssh://git@gitlab.com:22/owner/repo.git"},
+			expected: "https://gitlab.com/owner/repo/-/compare/v1.0.0...v1.1.0",
+		},
+		{
+			name:     "gitea",
+			repo:     Repo{Repo: "https://gitea.com/owner/repo"},
+			expected: "https://gitea.com/owner/repo/compare/v1.0.0...v1.1.0",
+		},
+		{
+			name:     "self-hosted gitlab mapped by host",
+			repo:     Repo{Repo: "https://git.example.org/team/repo", Vendor: "gitlab"},
+			expected: "https://git.example.org/team/repo/-/compare/v1.0.0...v1.1.0",
+		},
+		{
+			name: "unknown vendor",
+			repo: Repo{Repo: "https://git.example.org/team/repo"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.repo.CompareURL("v1.0.0", "v1.1.0"))
+		})
+	}
+}
+
+func TestRepo_MatchesAny(t *testing.T) {
+	repo := Repo{Repo: "https://github.com/psf/black"}
+
+	tests := []struct {
+		name     string
+		patterns []string
+		expected bool
+	}{
+		{
+			name:     "exact URL",
+			patterns: []string{"https://github.com/psf/black"},
+			expected: true,
+		},
+		{
+			name:     "glob",
+			patterns: []string{"https://github.com/psf/*"},
+			expected: true,
+		},
+		{
+			name:     "glob on another owner",
+			patterns: []string{"https://github.com/pycqa/*"},
+			expected: false,
+		},
+		{
+			name:     "substring",
+			patterns: []string{"psf/black"},
+			expected: true,
+		},
+		{
+			name:     "any of multiple patterns",
+			patterns: []string{"flake8", "black"},
+			expected: true,
+		},
+		{
+			name:     "no patterns",
+			patterns: nil,
+			expected: false,
+		},
+		{
+			name:     "empty pattern matches nothing",
+			patterns: []string{""},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, repo.MatchesAny(tt.patterns))
+		})
+	}
+}
+
+func TestRepo_ProvidesHook(t *testing.T) {
+	repo := Repo{Repo: "https://github.com/psf/black", Hooks: []Hook{{ID: "black"}, {ID: "black-jupyter"}}}
+
+	tests := []struct {
+		name     string
+		ids      []string
+		expected bool
+	}{
+		{name: "provided hook", ids: []string{"black"}, expected: true},
+		{name: "any of multiple ids", ids: []string{"flake8", "black-jupyter"}, expected: true},
+		{name: "other hook", ids: []string{"flake8"}, expected: false},
+		{name: "prefix of a hook id", ids: []string{"bla"}, expected: false},
+		{name: "no ids", ids: nil, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, repo.ProvidesHook(tt.ids))
+		})
+	}
+}
+
+func TestRepo_IsMutableRef(t *testing.T) {
+	tests := []struct {
+		name     string
+		repo     Repo
+		expected bool
+	}{
+		{name: "main branch", repo: Repo{Repo: "https://github.com/owner/repo", Rev: "main"}, expected: true},
+		{name: "HEAD", repo: Repo{Repo: "https://github.com/owner/repo", Rev: "HEAD"}, expected: true},
+		{name: "short branch name", repo: Repo{Repo: "https://github.com/owner/repo", Rev: "dev"}, expected: true},
+		{name: "short commit SHA", repo: Repo{Repo: "https://github.com/owner/repo", Rev: "a1b2c3d"}, expected: true},
+		{name: "full commit SHA", repo: Repo{Repo: "https://github.com/owner/repo", Rev: "a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2"}, expected: false},
+		{name: "version", repo: Repo{Repo: "https://github.com/owner/repo", Rev: "v1.0.0", SemVer: &SemanticVersion{Major: 1}}, expected: false},
+		{name: "local hooks", repo: Repo{Repo: config.SentinelLocal}, expected: false},
+		{name: "meta hooks", repo: Repo{Repo: config.SentinelMeta}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.repo.IsMutableRef())
+		})
+	}
+}
+
+func TestPreCommitConfig_Warnings(t *testing.T) {
+	tests := []struct {
+		name     string
+		repos    []Repo
+		expected []string
+	}{
+		{
+			name:  "no warnings",
+			repos: []Repo{{Repo: "https://github.com/psf/black", Rev: "24.1.0", SemVer: &SemanticVersion{Major: 24, Minor: 1}}, {Repo: config.SentinelLocal}},
+		},
+		{
+			name: "duplicate repository",
+			repos: []Repo{
+				{Repo: "https://github.com/psf/black", Rev: "24.1.0", SemVer: &SemanticVersion{Major: 24, Minor: 1}, RepoLine: 2},
+				{Repo: "https://github.com/psf/black.git", Rev: "24.1.0", SemVer: &SemanticVersion{Major: 24, Minor: 1}, RepoLine: 7},
+			},
+			expected: []string{"repository https://github.com/psf/black.git is listed more than once (line 7), first listed on line 2"},
+		},
+		{
+			name: "duplicate repository with different revisions",
+			repos: []Repo{
+				{Repo: "https://github.com/psf/black", Rev: "24.1.0", SemVer: &SemanticVersion{Major: 24, Minor: 1}},
+				{Repo: "git@github.com:psf/black", Rev: "23.1.0", SemVer: &SemanticVersion{Major: 23, Minor: 1}},
+			},
+			expected: []string{"repository git@github.com:psf/black is listed more than once with different revisions 24.1.0 and 23.1.0"},
+		},
+		{
+			name:     "revision that is not a version",
+			repos:    []Repo{{Repo: "https://github.com/owner/repo", Rev: "main", RepoLine: 2}},
+			expected: []string{"revision main of repository https://github.com/owner/repo is not a version and is never bumped (line 2)"},
+		},
+		{
+			name:  "local and meta hooks listed more than once",
+			repos: []Repo{{Repo: config.SentinelLocal}, {Repo: config.SentinelLocal}, {Repo: config.SentinelMeta}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pCfg := PreCommitConfig{Repos: tt.repos}
+			assert.Equal(t, tt.expected, pCfg.Warnings())
+		})
+	}
+}
+
+func TestRepo_FormatRevision(t *testing.T) {
+	tests := []struct {
+		name      string
+		rev       string
+		scheme    VersionScheme
+		tagPrefix string
+		expected  string
+	}{
+		{name: "v prefix", rev: "v1.2.3", scheme: VersionSchemeSemVer, expected: "v2.0.0"},
+		{name: "bare", rev: "1.2.3", scheme: VersionSchemeSemVer, expected: "2.0.0"},
+		{name: "release- prefix", rev: "release-1.2.3", scheme: VersionSchemeSemVer, expected: "release-2.0.0"},
+		{name: "suffix", rev: "2024.03.1_lts", scheme: VersionSchemeCalVer, expected: "2.0.0_lts"},
+		{name: "tag prefix of the current revision", rev: "release/1.2.3", scheme: VersionSchemeSemVer, tagPrefix: "release/", expected: "release/2.0.0"},
+		{name: "tag prefix missing from the current revision", rev: "1.2.3", scheme: VersionSchemeSemVer, tagPrefix: "app-v", expected: "app-v2.0.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			semVer, ok := ParseVersion(tt.rev, tt.scheme)
+			assert.True(t, ok)
+
+			repo := Repo{Rev: tt.rev, SemVer: semVer, TagPrefix: tt.tagPrefix}
+			assert.Equal(t, tt.expected, repo.FormatRevision(&SemanticVersion{Major: 2}))
+		})
+	}
+}
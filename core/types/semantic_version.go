@@ -1,9 +1,12 @@
 package types
 
 import (
+	"cmp"
 	"fmt"
 	"regexp"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/ramonvermeulen/pre-commit-bump/core/utils"
 
@@ -17,6 +20,8 @@ type SemanticVersion struct {
 	Patch         int
 	PreRelease    string
 	BuildMetaData string
+	// Original is the version exactly as it was matched in the revision or tag, e.g. with zero-padded calendar segments
+	Original string
 }
 
 // GetSemanticVersion parses a version string and return a SemanticVersion struct if it matches the semantic versioning format.
@@ -43,11 +48,17 @@ func GetSemanticVersion(version string) (*SemanticVersion, bool) {
 		Patch:         patch,
 		PreRelease:    preRelease,
 		BuildMetaData: buildMetadata,
+		Original:      match[0],
 	}, true
 }
 
 // String returns the string representation of the SemanticVersion in the format "major.minor.patch-preRelease+buildMetaData".
+// Parsed versions are returned as they were matched, so the formatting of e.g. calendar versions is preserved.
 func (s *SemanticVersion) String() string {
+	if s.Original != "" {
+		return s.Original
+	}
+
 	version := fmt.Sprintf("%d.%d.%d", s.Major, s.Minor, s.Patch)
 	if s.PreRelease != "" {
 		version += "-" + s.PreRelease
@@ -58,6 +69,32 @@ func (s *SemanticVersion) String() string {
 	return version
 }
 
+// Compare compares the SemanticVersion with another SemanticVersion using the semver precedence rules.
+// It returns -1 when s is older than other, 0 when both are equal and 1 when s is newer than other.
+// Build metadata is ignored. A nil version is considered older than any non-nil version, and two nil versions are equal.
+func (s *SemanticVersion) Compare(other *SemanticVersion) int {
+	switch {
+	case s == nil && other == nil:
+		return 0
+	case s == nil:
+		return -1
+	case other == nil:
+		return 1
+	}
+
+	if result := cmp.Compare(s.Major, other.Major); result != 0 {
+		return result
+	}
+	if result := cmp.Compare(s.Minor, other.Minor); result != 0 {
+		return result
+	}
+	if result := cmp.Compare(s.Patch, other.Patch); result != 0 {
+		return result
+	}
+
+	return comparePreRelease(s.PreRelease, other.PreRelease)
+}
+
 // IsNewerVersionThan compares the newVersion SemanticVersion with another SemanticVersion.
 // It returns true if the newVersion version is newer than the currentVersion version, false otherwise.
 func (s *SemanticVersion) IsNewerVersionThan(other *SemanticVersion) bool {
@@ -65,42 +102,106 @@ func (s *SemanticVersion) IsNewerVersionThan(other *SemanticVersion) bool {
 		return false
 	}
 
-	if s.Major > other.Major {
-		return true
+	return s.Compare(other) > 0
+}
+
+// SortVersions sorts the versions in ascending order using the semver precedence rules, including pre-releases.
+// The sort is stable, so versions that only differ in build metadata keep their original order.
+func SortVersions(versions []*SemanticVersion) {
+	slices.SortStableFunc(versions, func(a, b *SemanticVersion) int {
+		return a.Compare(b)
+	})
+}
+
+// comparePreRelease compares two pre-release strings according to the semver precedence rules.
+// It returns -1 when a has a lower precedence than b, 0 when they are equal and 1 when a has a higher precedence.
+// An empty pre-release (a normal release) has a higher precedence than any pre-release.
+func comparePreRelease(a, b string) int {
+	if a == b {
+		return 0
 	}
-	if s.Major == other.Major && s.Minor > other.Minor {
-		return true
+	if a == "" {
+		return 1
 	}
-	if s.Major == other.Major && s.Minor == other.Minor && s.Patch > other.Patch {
-		return true
+	if b == "" {
+		return -1
 	}
 
-	return false
+	aIdentifiers := strings.Split(a, ".")
+	bIdentifiers := strings.Split(b, ".")
+
+	for i := 0; i < len(aIdentifiers) && i < len(bIdentifiers); i++ {
+		if result := comparePreReleaseIdentifier(aIdentifiers[i], bIdentifiers[i]); result != 0 {
+			return result
+		}
+	}
+
+	// a larger set of pre-release identifiers has a higher precedence if all preceding identifiers are equal
+	return cmp.Compare(len(aIdentifiers), len(bIdentifiers))
+}
+
+// comparePreReleaseIdentifier compares a single dot separated pre-release identifier.
+// Numeric identifiers, which only consist of digits, are compared numerically and always have a lower precedence than
+// alphanumeric identifiers, e.g. "-1", which are compared lexically in ASCII sort order.
+func comparePreReleaseIdentifier(a, b string) int {
+	aNumeric := isNumericIdentifier(a)
+	bNumeric := isNumericIdentifier(b)
+
+	switch {
+	case aNumeric && bNumeric:
+		// numeric identifiers have no leading zeros, so a longer number is larger, also beyond the range of an int
+		if result := cmp.Compare(len(a), len(b)); result != 0 {
+			return result
+		}
+		return strings.Compare(a, b)
+	case aNumeric:
+		return -1
+	case bNumeric:
+		return 1
+	}
+
+	return strings.Compare(a, b)
+}
+
+// isNumericIdentifier reports whether the pre-release identifier is numeric, i.e. a non-empty string of digits.
+func isNumericIdentifier(identifier string) bool {
+	if identifier == "" {
+		return false
+	}
+	for _, char := range identifier {
+		if char < '0' || char > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// IsInitialDevelopment reports whether the version is a 0.y.z version, for which semver allows anything to change at any time.
+func (s *SemanticVersion) IsInitialDevelopment() bool {
+	return s != nil && s.Major == 0
 }
 
 // GetBumpType determines the type of version bump between the newVersion SemanticVersion and another SemanticVersion.
 // It returns "major", "minor", or "patch" if the newVersion version is newer than the currentVersion version.
+// Moving from a pre-release to a newer pre-release or the final release of the same version is reported as "patch".
 func (s *SemanticVersion) GetBumpType(other *SemanticVersion) string {
-	if other == nil {
+	if other == nil || s.Compare(other) <= 0 {
 		return ""
 	}
 
-	if s.Major > other.Major {
-		return "major"
-	}
-	if s.Major == other.Major && s.Minor > other.Minor {
-		return "minor"
-	}
-	if s.Major == other.Major && s.Minor == other.Minor && s.Patch > other.Patch {
-		return "patch"
+	switch {
+	case s.Major != other.Major:
+		return config.BumpMajor
+	case s.Minor != other.Minor:
+		return config.BumpMinor
 	}
 
-	return ""
+	return config.BumpPatch
 }
 
 // IsAllowedBumpFrom checks if the newVersion SemanticVersion is allowed to be bumped from the currentVersion SemanticVersion
 // based on the allowed bump type. It returns true if the bump is allowed, false otherwise.
-// allowedBumpType can be "major", "minor", or "patch".
+// allowedBumpType can be "major", "minor", "patch" or "none", where "none" never allows a bump.
 func (s *SemanticVersion) IsAllowedBumpFrom(other *SemanticVersion, allowedBumpType string) bool {
 	if other == nil || s == nil {
 		return false
@@ -109,12 +210,12 @@ func (s *SemanticVersion) IsAllowedBumpFrom(other *SemanticVersion, allowedBumpT
 	bumpType := s.GetBumpType(other)
 
 	switch allowedBumpType {
-	case "major":
-		return bumpType == "major" || bumpType == "minor" || bumpType == "patch"
-	case "minor":
-		return bumpType == "minor" || bumpType == "patch"
-	case "patch":
-		return bumpType == "patch"
+	case config.BumpMajor:
+		return bumpType == config.BumpMajor || bumpType == config.BumpMinor || bumpType == config.BumpPatch
+	case config.BumpMinor:
+		return bumpType == config.BumpMinor || bumpType == config.BumpPatch
+	case config.BumpPatch:
+		return bumpType == config.BumpPatch
 	}
 
 	return false
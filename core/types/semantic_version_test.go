@@ -1,9 +1,12 @@
 package types
 
 import (
+	"math/rand/v2"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSemanticVersionEdgeCases(t *testing.T) {
@@ -178,6 +181,60 @@ func TestSemanticVersionComparison(t *testing.T) {
 			version2: "1.0.0",
 			expected: false,
 		},
+		{
+			name:     "release vs pre-release",
+			version1: "1.0.0",
+			version2: "1.0.0-alpha",
+			expected: true,
+		},
+		{
+			name:     "release vs release candidate",
+			version1: "1.0.0",
+			version2: "1.0.0-rc.1",
+			expected: true,
+		},
+		{
+			name:     "equal pre-releases",
+			version1: "1.0.0-rc.1",
+			version2: "1.0.0-rc.1",
+			expected: false,
+		},
+		{
+			name:     "longer pre-release is newer",
+			version1: "1.0.0-alpha.1",
+			version2: "1.0.0-alpha",
+			expected: true,
+		},
+		{
+			name:     "alphanumeric identifier is newer than numeric",
+			version1: "1.0.0-alpha.beta",
+			version2: "1.0.0-alpha.1",
+			expected: true,
+		},
+		{
+			name:     "lexical comparison of identifiers",
+			version1: "1.0.0-beta",
+			version2: "1.0.0-alpha.beta",
+			expected: true,
+		},
+		{
+			name:     "numeric identifiers compared numerically",
+			version1: "1.0.0-beta.11",
+			version2: "1.0.0-beta.2",
+			expected: true,
+		},
+		{
+			name:     "numeric identifiers compared numerically reversed",
+			version1: "1.0.0-beta.2",
+			version2: "1.0.0-beta.11",
+			expected: false,
+		},
+		{
+			name:     "pre-release of a newer patch is newer than release",
+			version1: "1.0.1-alpha",
+			version2: "1.0.0",
+			expected: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -194,6 +251,185 @@ func TestSemanticVersionComparison(t *testing.T) {
 	}
 }
 
+func TestSemanticVersionCompare(t *testing.T) {
+	tests := []struct {
+		name     string
+		version1 string
+		version2 string
+		expected int
+	}{
+		{
+			name:     "equal versions",
+			version1: "1.2.3",
+			version2: "1.2.3",
+			expected: 0,
+		},
+		{
+			name:     "equal pre-releases",
+			version1: "1.2.3-rc.1",
+			version2: "1.2.3-rc.1",
+			expected: 0,
+		},
+		{
+			name:     "build metadata is ignored",
+			version1: "1.2.3+build.1",
+			version2: "1.2.3+build.2",
+			expected: 0,
+		},
+		{
+			name:     "older major",
+			version1: "1.9.9",
+			version2: "2.0.0",
+			expected: -1,
+		},
+		{
+			name:     "newer minor",
+			version1: "1.3.0",
+			version2: "1.2.9",
+			expected: 1,
+		},
+		{
+			name:     "older patch",
+			version1: "1.2.2",
+			version2: "1.2.3",
+			expected: -1,
+		},
+		{
+			name:     "pre-release is older than release",
+			version1: "1.2.3-alpha",
+			version2: "1.2.3",
+			expected: -1,
+		},
+		{
+			name:     "newer pre-release",
+			version1: "1.2.3-beta",
+			version2: "1.2.3-alpha.1",
+			expected: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v1, ok1 := GetSemanticVersion(tt.version1)
+			v2, ok2 := GetSemanticVersion(tt.version2)
+
+			assert.True(t, ok1, "Failed to parse version1: %q", tt.version1)
+			assert.True(t, ok2, "Failed to parse version2: %q", tt.version2)
+
+			assert.Equal(t, tt.expected, v1.Compare(v2), "Compare(%q, %q)", tt.version1, tt.version2)
+			assert.Equal(t, -tt.expected, v2.Compare(v1), "Compare(%q, %q)", tt.version2, tt.version1)
+		})
+	}
+}
+
+func TestSemanticVersionCompareNil(t *testing.T) {
+	var nilVersion *SemanticVersion
+	version := &SemanticVersion{Major: 1}
+
+	assert.Equal(t, 0, nilVersion.Compare(nil), "two nil versions should be equal")
+	assert.Equal(t, -1, nilVersion.Compare(version), "nil version should be older than any version")
+	assert.Equal(t, 1, version.Compare(nil), "any version should be newer than a nil version")
+}
+
+func TestSortVersions(t *testing.T) {
+	expected := []string{
+		"0.9.0",
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.2.0",
+		"2.0.0",
+	}
+
+	var versions []*SemanticVersion
+	for _, version := range expected {
+		semVer, ok := GetSemanticVersion(version)
+		assert.True(t, ok, "Failed to parse version: %q", version)
+		versions = append(versions, semVer)
+	}
+
+	rng := rand.New(rand.NewPCG(1, 2))
+	for i := 0; i < 10; i++ {
+		shuffled := slices.Clone(versions)
+		rng.Shuffle(len(shuffled), func(a, b int) {
+			shuffled[a], shuffled[b] = shuffled[b], shuffled[a]
+		})
+
+		SortVersions(shuffled)
+
+		var result []string
+		for _, semVer := range shuffled {
+			result = append(result, semVer.String())
+		}
+		assert.Equal(t, expected, result)
+	}
+}
+
+func TestSortVersionsStableForBuildMetadata(t *testing.T) {
+	versions := []*SemanticVersion{
+		{Major: 1, Minor: 0, Patch: 0, BuildMetaData: "b"},
+		{Major: 0, Minor: 1, Patch: 0},
+		{Major: 1, Minor: 0, Patch: 0, BuildMetaData: "a"},
+	}
+
+	SortVersions(versions)
+
+	assert.Equal(t, "0.1.0", versions[0].String())
+	assert.Equal(t, "1.0.0+b", versions[1].String())
+	assert.Equal(t, "1.0.0+a", versions[2].String())
+}
+
+func TestSemanticVersionStringBuildMetadata(t *testing.T) {
+	for _, version := range []string{"1.0.0+a", "1.0.0-rc.1+b.2", "1.0.0"} {
+		semVer, ok := GetSemanticVersion(version)
+		require.True(t, ok)
+		assert.Equal(t, version, semVer.String())
+
+		semVer.Original = ""
+		assert.Equal(t, version, semVer.String(), "the formatted version should keep the build metadata")
+	}
+
+	a, _ := GetSemanticVersion("1.0.0+a")
+	b, _ := GetSemanticVersion("1.0.0+b")
+	assert.Equal(t, 0, a.Compare(b))
+	assert.False(t, b.IsNewerVersionThan(a))
+	assert.False(t, b.IsAllowedBumpFrom(a, "major"))
+}
+
+func TestSemanticVersionGetBumpType(t *testing.T) {
+	tests := []struct {
+		name           string
+		newVersion     string
+		currentVersion string
+		expected       string
+	}{
+		{name: "major bump", newVersion: "2.0.0", currentVersion: "1.5.3", expected: "major"},
+		{name: "minor bump", newVersion: "1.6.0", currentVersion: "1.5.3", expected: "minor"},
+		{name: "patch bump", newVersion: "1.5.4", currentVersion: "1.5.3", expected: "patch"},
+		{name: "pre-release to release", newVersion: "1.5.3", currentVersion: "1.5.3-rc.1", expected: "patch"},
+		{name: "same version", newVersion: "1.5.3", currentVersion: "1.5.3", expected: ""},
+		{name: "downgrade", newVersion: "1.5.2", currentVersion: "1.5.3", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newVersion, ok1 := GetSemanticVersion(tt.newVersion)
+			currentVersion, ok2 := GetSemanticVersion(tt.currentVersion)
+
+			assert.True(t, ok1, "Failed to parse newVersion: %q", tt.newVersion)
+			assert.True(t, ok2, "Failed to parse currentVersion: %q", tt.currentVersion)
+
+			assert.Equal(t, tt.expected, newVersion.GetBumpType(currentVersion))
+		})
+	}
+}
+
 func TestSemanticVersionIsAllowedBump(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -299,6 +535,22 @@ func TestSemanticVersionIsAllowedBump(t *testing.T) {
 			expected:       false,
 			description:    "should return false when newVersion is older than currentVersion",
 		},
+		{
+			name:           "none allowed - major bump",
+			newVersion:     "2.0.0",
+			currentVersion: "1.0.0",
+			allowedType:    "none",
+			expected:       false,
+			description:    "major bump should not be allowed when none is allowed",
+		},
+		{
+			name:           "none allowed - patch bump",
+			newVersion:     "1.0.1",
+			currentVersion: "1.0.0",
+			allowedType:    "none",
+			expected:       false,
+			description:    "patch bump should not be allowed when none is allowed",
+		},
 		{
 			name:           "invalid allowed type",
 			newVersion:     "1.1.0",
@@ -323,6 +575,14 @@ func TestSemanticVersionIsAllowedBump(t *testing.T) {
 			expected:       false,
 			description:    "minor bump from pre-release should not be allowed when only patch allowed",
 		},
+		{
+			name:           "release candidate to final release",
+			newVersion:     "1.0.0",
+			currentVersion: "1.0.0-rc.1",
+			allowedType:    "patch",
+			expected:       true,
+			description:    "bump from a release candidate to its final release should be allowed",
+		},
 		{
 			name:           "large version major bump",
 			newVersion:     "100.0.0",
@@ -360,3 +620,30 @@ func TestSemanticVersionIsAllowedBump(t *testing.T) {
 		})
 	}
 }
+
+func TestComparePreReleaseIdentifier(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        string
+		b        string
+		expected int
+	}{
+		{name: "numeric", a: "2", b: "10", expected: -1},
+		{name: "equal numeric", a: "10", b: "10", expected: 0},
+		{name: "numeric before alphanumeric", a: "1", b: "alpha", expected: -1},
+		{name: "alphanumeric", a: "alpha", b: "beta", expected: -1},
+		{name: "negative sign is alphanumeric", a: "-1", b: "1", expected: 1},
+		{name: "positive sign is alphanumeric", a: "+1", b: "1", expected: 1},
+		{name: "signed identifiers compared lexically", a: "-2", b: "-10", expected: 1},
+		{name: "numbers beyond the int range", a: "99999999999999999999", b: "100000000000000000000", expected: -1},
+		{name: "equal numbers beyond the int range", a: "100000000000000000000", b: "100000000000000000000", expected: 0},
+		{name: "numbers of the same length beyond the int range", a: "200000000000000000000", b: "100000000000000000000", expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, comparePreReleaseIdentifier(tt.a, tt.b))
+			assert.Equal(t, -tt.expected, comparePreReleaseIdentifier(tt.b, tt.a), "the comparison should be antisymmetric")
+		})
+	}
+}
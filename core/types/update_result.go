@@ -1,9 +1,72 @@
 package types
 
+import "fmt"
+
 // UpdateResult holds the result of checking a repository for updates.
+// ConfigPath is the pre-commit configuration file the repository was found in.
+// When Dependency is set, the result is about a pinned additional dependency of one of the hooks of the repository.
+// LatestVersion is the absolute latest version, AllowedVersion the highest version the allowed bump type permits and
+// Candidates all versions that were considered, sorted in ascending order.
+// LatestStable is the newest stable release and LatestPrerelease the newest pre-release, only set when it is newer than
+// LatestStable.
+// Unpublished is set when the current version of the repository is not among its published versions, e.g. because the
+// release was yanked or its tag deleted.
+// FrozenRev is the commit SHA the bumped tag points to, only set for repositories with a frozen revision that require an update.
+// SkipReason is set when the repository is not checked at all, e.g. for local hooks or a revision that is not a version.
+// Duplicate is set for a repeated occurrence of a repository in the same configuration file, it shares the outcome of the
+// first occurrence and is rewritten on update, but not reported again.
+// Declined is set for an available update that was not approved, e.g. in the interactive mode, it is not written.
+// Deferred is set for an available update beyond the --max-bumps limit, it is not written and left for a later run.
 type UpdateResult struct {
-	Repo           Repo
-	LatestVersion  *SemanticVersion
-	UpdateRequired bool
-	Error          error
+	ConfigPath       string
+	Repo             Repo
+	Dependency       *Dependency
+	LatestVersion    *SemanticVersion
+	LatestStable     *SemanticVersion
+	LatestPrerelease *SemanticVersion
+	AllowedVersion   *SemanticVersion
+	Candidates       []*SemanticVersion
+	UpdateRequired   bool
+	Ignored          bool
+	Unpublished      bool
+	FrozenRev        string
+	SkipReason       string
+	Duplicate        bool
+	Declined         bool
+	Deferred         bool
+	Error            error
+}
+
+// Name returns the name of what was checked, the repository URL or the dependency name with the repository URL.
+func (r UpdateResult) Name() string {
+	if r.Dependency != nil {
+		return fmt.Sprintf("%s (%s: %s)", r.Repo.Repo, r.Dependency.HookID, r.Dependency.Name)
+	}
+	return r.Repo.Repo
+}
+
+// CurrentVersion returns the current revision of the repository or the pinned version of the dependency.
+// For frozen revisions the tag of the frozen comment is returned instead of the commit SHA.
+func (r UpdateResult) CurrentVersion() string {
+	if r.Dependency != nil {
+		return r.Dependency.Version
+	}
+	return r.Repo.VersionRev()
+}
+
+// BumpVersion returns the version the repository or dependency is bumped to, the highest version the allowed bump type permits.
+// It falls back to LatestVersion when no allowed version was determined.
+func (r UpdateResult) BumpVersion() *SemanticVersion {
+	if r.AllowedVersion != nil {
+		return r.AllowedVersion
+	}
+	return r.LatestVersion
+}
+
+// CurrentSemVer returns the parsed current version of the repository or the dependency.
+func (r UpdateResult) CurrentSemVer() *SemanticVersion {
+	if r.Dependency != nil {
+		return r.Dependency.SemVer
+	}
+	return r.Repo.SemVer
 }
@@ -0,0 +1,100 @@
+package types
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/ramonvermeulen/pre-commit-bump/config"
+	"github.com/ramonvermeulen/pre-commit-bump/core/utils"
+)
+
+// VersionScheme determines how the revision and tags of a repository are parsed and compared.
+type VersionScheme string
+
+// Supported version schemes
+const (
+	// VersionSchemeAuto uses semantic versioning, and falls back to calendar versioning for revisions that are not a valid semantic version.
+	VersionSchemeAuto VersionScheme = config.VersionSchemeAuto
+	// VersionSchemeSemVer only accepts semantic versions, e.g. 1.2.3
+	VersionSchemeSemVer VersionScheme = config.VersionSchemeSemVer
+	// VersionSchemeCalVer accepts calendar versions with zero-padded segments, e.g. 2024.03.1
+	VersionSchemeCalVer VersionScheme = config.VersionSchemeCalVer
+)
+
+// ParseVersion parses a version string using the given version scheme.
+// Unknown schemes are parsed as semantic versions.
+func ParseVersion(version string, scheme VersionScheme) (*SemanticVersion, bool) {
+	if scheme == VersionSchemeCalVer {
+		return GetCalendarVersion(version)
+	}
+	return GetSemanticVersion(version)
+}
+
+// ParseStrictVersion parses a version string like ParseVersion, but only accepts it when the version is the whole string,
+// optionally preceded by "v", "V" or one of the given prefixes. The lenient ParseVersion picks the first version out of
+// a longer string, which is what revisions like "https://host/repo.git?rev=v1.9.1" need, but it also turns
+// "foo-1.2.3-bar-4.5.6" into 1.2.3 and finds a version in hash-like revisions such as "deadbeef1.2.3".
+func ParseStrictVersion(version string, scheme VersionScheme, prefixes ...string) (*SemanticVersion, bool) {
+	semVer, ok := ParseVersion(version, scheme)
+	if !ok || !isWholeVersion(version, semVer.Original, prefixes) {
+		return &SemanticVersion{}, false
+	}
+	return semVer, true
+}
+
+// isWholeVersion reports whether the string is the matched version, optionally preceded by "v", "V" or one of the prefixes.
+func isWholeVersion(version, original string, prefixes []string) bool {
+	if version == original {
+		return true
+	}
+	for _, prefix := range append([]string{"v", "V"}, prefixes...) {
+		if prefix != "" && version == prefix+original {
+			return true
+		}
+	}
+	return false
+}
+
+// DetectVersionScheme determines the version scheme of a revision.
+// Semantic versioning takes precedence, calendar versioning is only used when the revision is not a valid semantic version.
+// It returns false if the revision matches neither scheme.
+func DetectVersionScheme(version string) (VersionScheme, bool) {
+	if _, ok := GetSemanticVersion(version); ok {
+		return VersionSchemeSemVer, true
+	}
+	if _, ok := GetCalendarVersion(version); ok {
+		return VersionSchemeCalVer, true
+	}
+	return "", false
+}
+
+// GetCalendarVersion parses a calendar version string like 2024.03.1 and returns it as a SemanticVersion,
+// where the year, month (or minor) and patch (or micro) segments map to the major, minor and patch fields.
+// Zero-padded segments are accepted and compared numerically, which orders calendar versions chronologically.
+func GetCalendarVersion(version string) (*SemanticVersion, bool) {
+	re := regexp.MustCompile(config.ReCalendarVersion)
+	match := re.FindStringSubmatch(version)
+	if match == nil {
+		return &SemanticVersion{}, false
+	}
+
+	major, err1 := strconv.Atoi(utils.GetGroup(re, match, "major"))
+	minor, err2 := strconv.Atoi(utils.GetGroup(re, match, "minor"))
+	patch := 0
+	var err3 error
+	if rawPatch := utils.GetGroup(re, match, "patch"); rawPatch != "" {
+		patch, err3 = strconv.Atoi(rawPatch)
+	}
+
+	if err1 != nil || err2 != nil || err3 != nil {
+		return &SemanticVersion{}, false
+	}
+
+	return &SemanticVersion{
+		Major:      major,
+		Minor:      minor,
+		Patch:      patch,
+		PreRelease: utils.GetGroup(re, match, "prerelease"),
+		Original:   utils.GetGroup(re, match, "version"),
+	}, true
+}
@@ -0,0 +1,182 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ramonvermeulen/pre-commit-bump/config"
+)
+
+func TestGetCalendarVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  string
+		expected SemanticVersion
+		valid    bool
+	}{
+		{
+			name:     "zero-padded month",
+			version:  "2024.03.1",
+			expected: SemanticVersion{Major: 2024, Minor: 3, Patch: 1, Original: "2024.03.1"},
+			valid:    true,
+		},
+		{
+			name:     "short year",
+			version:  "22.3.0",
+			expected: SemanticVersion{Major: 22, Minor: 3, Patch: 0, Original: "22.3.0"},
+			valid:    true,
+		},
+		{
+			name:     "without patch segment",
+			version:  "v2024.01",
+			expected: SemanticVersion{Major: 2024, Minor: 1, Patch: 0, Original: "2024.01"},
+			valid:    true,
+		},
+		{
+			name:     "zero-padded segments rejected by semver",
+			version:  "01.02.03",
+			expected: SemanticVersion{Major: 1, Minor: 2, Patch: 3, Original: "01.02.03"},
+			valid:    true,
+		},
+		{
+			name:     "with pre-release",
+			version:  "2024.03.1-rc.1",
+			expected: SemanticVersion{Major: 2024, Minor: 3, Patch: 1, PreRelease: "rc.1", Original: "2024.03.1-rc.1"},
+			valid:    true,
+		},
+		{
+			name:    "not a calendar version",
+			version: "main",
+			valid:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, ok := GetCalendarVersion(tt.version)
+
+			assert.Equal(t, tt.valid, ok, "GetCalendarVersion(%q) validity", tt.version)
+			if tt.valid {
+				assert.Equal(t, tt.expected, *result)
+			}
+		})
+	}
+}
+
+func TestCalendarVersionComparison(t *testing.T) {
+	older, ok1 := GetCalendarVersion("2024.03.1")
+	newer, ok2 := GetCalendarVersion("2024.10.0")
+
+	assert.True(t, ok1)
+	assert.True(t, ok2)
+	assert.True(t, newer.IsNewerVersionThan(older), "2024.10.0 should be newer than 2024.03.1")
+	assert.Equal(t, "minor", newer.GetBumpType(older))
+	assert.Equal(t, "2024.10.0", newer.String(), "String should preserve the calendar formatting")
+	assert.Equal(t, "2024.03.1", older.String(), "String should preserve zero-padded segments")
+}
+
+func TestParseStrictVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  string
+		scheme   VersionScheme
+		prefixes []string
+		expected string
+		valid    bool
+		lenient  string
+	}{
+		{name: "plain version", version: "1.2.3", scheme: VersionSchemeSemVer, expected: "1.2.3", valid: true, lenient: "1.2.3"},
+		{name: "v prefix", version: "v1.2.3", scheme: VersionSchemeSemVer, expected: "1.2.3", valid: true, lenient: "1.2.3"},
+		{name: "capital V prefix", version: "V1.2.3-rc.1", scheme: VersionSchemeSemVer, expected: "1.2.3-rc.1", valid: true, lenient: "1.2.3-rc.1"},
+		{name: "known tag prefix", version: "release-1.2.3", scheme: VersionSchemeSemVer, prefixes: []string{"release-"}, expected: "1.2.3", valid: true, lenient: "1.2.3"},
+		{name: "two embedded versions", version: "foo-1.2.3-bar-4.5.6", scheme: VersionSchemeSemVer, valid: false, lenient: "1.2.3-bar-4.5.6"},
+		{name: "hash-like prefix", version: "deadbeef1.2.3", scheme: VersionSchemeSemVer, valid: false, lenient: "1.2.3"},
+		{name: "url with rev parameter", version: "https://github.com/owner/repo.git?rev=v1.9.1&param=value", scheme: VersionSchemeSemVer, valid: false, lenient: "1.9.1"},
+		{name: "unknown prefix", version: "backend-1.2.3", scheme: VersionSchemeSemVer, prefixes: []string{"frontend-"}, valid: false, lenient: "1.2.3"},
+		{name: "trailing text", version: "1.2.3.4", scheme: VersionSchemeSemVer, valid: false, lenient: "1.2.3"},
+		{name: "calendar version", version: "v2024.03.1", scheme: VersionSchemeCalVer, expected: "2024.03.1", valid: true, lenient: "2024.03.1"},
+		{name: "embedded calendar version", version: "build-2024.03.1", scheme: VersionSchemeCalVer, valid: false, lenient: "2024.03.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, ok := ParseStrictVersion(tt.version, tt.scheme, tt.prefixes...)
+
+			assert.Equal(t, tt.valid, ok, "ParseStrictVersion(%q) validity", tt.version)
+			if tt.valid {
+				assert.Equal(t, tt.expected, result.String())
+			}
+
+			lenient, ok := ParseVersion(tt.version, tt.scheme)
+			assert.True(t, ok, "the lenient mode should find a version in %q", tt.version)
+			assert.Equal(t, tt.lenient, lenient.String())
+		})
+	}
+}
+
+func TestPreCommitConfig_SetStrictVersions(t *testing.T) {
+	pCfg := &PreCommitConfig{
+		Repos: []Repo{
+			{Repo: "https://github.com/psf/black", Rev: "v24.1.0"},
+			{Repo: "https://github.com/owner/embedded", Rev: "foo-1.2.3-bar-4.5.6"},
+			{Repo: "https://github.com/owner/monorepo", Rev: "backend-1.2.3", TagPrefix: "backend-"},
+		},
+	}
+
+	pCfg.PopulateSemVer()
+	require.NotNil(t, pCfg.Repos[1].SemVer)
+	assert.Equal(t, "1.2.3-bar-4.5.6", pCfg.Repos[1].SemVer.String())
+
+	pCfg.SetStrictVersions()
+
+	assert.NotNil(t, pCfg.Repos[0].SemVer)
+	assert.Nil(t, pCfg.Repos[1].SemVer, "a revision with the version embedded in a longer string is not a version in strict mode")
+	assert.Equal(t, config.SkipReasonNoVersion, pCfg.Repos[1].SkipReason())
+	require.NotNil(t, pCfg.Repos[2].SemVer)
+	assert.Equal(t, "1.2.3", pCfg.Repos[2].SemVer.String())
+}
+
+func TestDetectVersionScheme(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  string
+		expected VersionScheme
+		valid    bool
+	}{
+		{name: "semantic version", version: "v1.2.3", expected: VersionSchemeSemVer, valid: true},
+		{name: "black style version stays semver", version: "22.3.0", expected: VersionSchemeSemVer, valid: true},
+		{name: "zero-padded calendar version", version: "2024.03.1", expected: VersionSchemeCalVer, valid: true},
+		{name: "branch name", version: "main", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme, ok := DetectVersionScheme(tt.version)
+
+			assert.Equal(t, tt.valid, ok)
+			assert.Equal(t, tt.expected, scheme)
+		})
+	}
+}
+
+func TestPreCommitConfig_SetVersionScheme(t *testing.T) {
+	pCfg := &PreCommitConfig{
+		Repos: []Repo{
+			{Repo: "https://github.com/psf/black", Rev: "22.3.0"},
+			{Repo: "https://github.com/owner/calver", Rev: "2024.03.1"},
+		},
+	}
+
+	pCfg.PopulateSemVer()
+
+	assert.Equal(t, VersionSchemeSemVer, pCfg.Repos[0].Scheme)
+	assert.Equal(t, VersionSchemeCalVer, pCfg.Repos[1].Scheme)
+	assert.Equal(t, 2024, pCfg.Repos[1].SemVer.Major)
+
+	pCfg.SetVersionScheme(VersionSchemeSemVer)
+
+	assert.NotNil(t, pCfg.Repos[0].SemVer)
+	assert.Nil(t, pCfg.Repos[1].SemVer, "zero-padded calendar version is not a valid semantic version")
+}
@@ -8,8 +8,8 @@ case "${INPUT_COMMAND}" in
 esac
 
 case "${INPUT_ALLOW}" in
-    major|minor|patch) ;;
-    *) echo "Error: Invalid allow value '${INPUT_ALLOW}'. Must be 'major', 'minor', or 'patch'" >&2; exit 1 ;;
+    major|minor|patch|none) ;;
+    *) echo "Error: Invalid allow value '${INPUT_ALLOW}'. Must be 'major', 'minor', 'patch', or 'none'" >&2; exit 1 ;;
 esac
 
 case "${INPUT_VERBOSE}" in